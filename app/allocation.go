@@ -0,0 +1,59 @@
+package app
+
+import (
+	"changeme/internal/allocation"
+	"context"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventAllocationProgress is emitted after each student ApplyAllocation adds to a room
+const EventAllocationProgress = "allocation:progress"
+
+// AllocationProgress is the payload of EventAllocationProgress
+type AllocationProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ProposeAllocation plans rooms for every student without one. Nothing is
+// changed until the reviewed plan is passed to ApplyAllocation.
+func (a *App) ProposeAllocation(preferences []allocation.Preference, requestID string) (*allocation.Plan, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return allocation.NewAllocator(ws.api).Propose(ctx, preferences)
+}
+
+// CheckAllocation reports what would stop an edited plan from being applied
+func (a *App) CheckAllocation(assignments []allocation.Assignment) ([]allocation.Issue, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return allocation.NewAllocator(ws.api).Check(a.ctx, assignments)
+}
+
+// ApplyAllocation adds the students of a reviewed plan to their rooms
+func (a *App) ApplyAllocation(assignments []allocation.Assignment) (*allocation.ApplyResult, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return allocation.NewAllocator(ws.api).Apply(a.ctx, assignments, func(done, total int) {
+		runtime.EventsEmit(a.ctx, EventAllocationProgress, AllocationProgress{Done: done, Total: total})
+	})
+}
@@ -3,122 +3,247 @@ package app
 import (
 	"changeme/internal/api"
 	"changeme/internal/client"
+	"changeme/internal/config"
+	"changeme/internal/logger"
+	"changeme/internal/models"
+	"changeme/internal/session"
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"strconv"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// EventSessionExpired is emitted when the refresh token is rejected and the user must sign in again
+const EventSessionExpired = "session:expired"
+
 type App struct {
-	ctx        context.Context
-	api        *api.API
-	httpClient *client.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// updateMu makes config saves, and the profile switches they cause, run one at a time
+	updateMu sync.Mutex
+	configMu sync.Mutex
+	config   *config.Config
+	// configSaved is closed and replaced every time the config is saved
+	configSaved chan struct{}
+
+	wsMu sync.RWMutex
+	ws   *workspace
+
+	sessionMu sync.Mutex
+	session   *session.Session
+
+	requestsMu sync.Mutex
+	requests   map[string]*trackedRequest
 }
 
-func NewApp(httpClient *client.Client) *App {
-	return &App{
-		api:        api.NewAPI(httpClient),
-		httpClient: httpClient,
+func NewApp(cfg *config.Config) (*App, error) {
+	a := &App{
+		config:      cfg,
+		configSaved: make(chan struct{}),
+		requests:    make(map[string]*trackedRequest),
+	}
+
+	ws, err := openWorkspace(cfg.Active())
+	if err != nil {
+		return nil, err
 	}
+	a.bind(ws)
+	a.ws = ws
+
+	return a, nil
 }
 
 func (a *App) Startup(ctx context.Context) {
-	a.ctx = ctx
+	// Every request derives from this context, so Shutdown aborts whatever is in flight
+	a.ctx, a.cancel = context.WithCancel(ctx)
+	a.start(a.current())
+}
+
+func (a *App) Shutdown(ctx context.Context) {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	ws := a.current()
+	ws.halt()
+	ws.close()
+}
+
+// current returns the workspace of the active server profile
+func (a *App) current() *workspace {
+	a.wsMu.RLock()
+	defer a.wsMu.RUnlock()
+	return a.ws
 }
 
-func (a *App) LogData(messages *string, data ...interface{}) {
-	log.Println(messages, data)
+// acquire returns the workspace of the active server profile and keeps it
+// open until release is called, so a profile switch cannot close the stores
+// a binding is still using
+func (a *App) acquire() (ws *workspace, release func()) {
+	a.wsMu.RLock()
+	defer a.wsMu.RUnlock()
+
+	a.ws.inflight.Add(1)
+	return a.ws, a.ws.inflight.Done
+}
+
+// bind wires the workspace's token lifecycle to the app's session handling
+func (a *App) bind(ws *workspace) {
+	ws.client.SetRefreshFunc(ws.api.Auth().TokenRefresher())
+	ws.client.OnTokenRefreshed(func(token client.Token) {
+		a.updateSession(ws, token.RefreshToken)
+	})
+	ws.client.OnSessionExpired(func() {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, EventSessionExpired)
+		}
+	})
+}
+
+// LogData writes a frontend log entry at the given level (debug, info, warn or error)
+func (a *App) LogData(level string, message string, data map[string]interface{}) {
+	lvl, err := logger.ParseLevel(level)
+	if err != nil {
+		lvl = slog.LevelInfo
+	}
+
+	attrs := make([]any, 0, len(data)+1)
+	attrs = append(attrs, slog.String("source", "frontend"))
+	for key, value := range data {
+		if logger.Sensitive(key) {
+			value = logger.Redacted
+		}
+		attrs = append(attrs, slog.Any(key, logger.RedactValue(value)))
+	}
+
+	slog.Log(context.Background(), lvl, message, attrs...)
 }
 
-func (a *App) SetToken(token string) error {
+func (a *App) SetToken(accessToken, refreshToken string) error {
 	if a.ctx == nil {
 		return context.Canceled
 	}
 
-	if a.httpClient != nil {
-		a.httpClient.SetHeader("Authorization", "Bearer "+token)
-		return nil
-	}
+	ws, release := a.acquire()
+	defer release()
 
-	return errors.New("HTTP client is not initialized")
+	ws.client.SetToken(accessToken, refreshToken)
+	return nil
 }
 
-func (a *App) Login(email, password string) (*client.Response, error) {
+func (a *App) Login(email, password string) (*api.Response[models.Login], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	result, err := a.api.Auth().Login(email, password)
+	ws, release := a.acquire()
+	defer release()
+	result, err := ws.api.Auth().Login(a.ctx, email, password)
 	if err != nil {
 		return nil, err
 	}
 
+	ws.client.SetToken(result.Data.AccessToken, result.Data.RefreshToken)
+	ws.client.SetCacheScope(strconv.Itoa(result.Data.User.ID))
+	if result.Data.IsVerified {
+		a.saveSession(ws, &result.Data.User, result.Data.RefreshToken)
+	}
+
 	return result, nil
 }
 
-func (a *App) Logout() (*client.Response, error) {
+func (a *App) Logout() (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Auth().Logout()
+	ws, release := a.acquire()
+	defer release()
+	result, err := ws.api.Auth().Logout(a.ctx)
+	a.clearSession(ws)
+
+	return result, err
 }
 
-func (a *App) Register(email, password, fullName, phone string) (*client.Response, error) {
+func (a *App) Register(email, password, fullName, phone string) (*api.Response[models.User], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Auth().Register(email, password, fullName, phone)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().Register(a.ctx, email, password, fullName, phone)
 }
 
-func (a *App) GetMe() (*client.Response, error) {
+func (a *App) GetMe() (*api.Response[models.User], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Auth().GetMe()
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().GetMe(a.ctx)
 }
 
-func (a *App) VerifyAccount(token, email string) (*client.Response, error) {
+func (a *App) VerifyAccount(token, email string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Auth().VerifyAccount(token, email)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().VerifyAccount(a.ctx, token, email)
 }
 
-func (a *App) ResendVerifyAccount(email string) (*client.Response, error) {
+func (a *App) ResendVerifyAccount(email string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Auth().ResendVerifyAccount(email)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().ResendVerifyAccount(a.ctx, email)
 }
 
-func (a *App) SendForgotPasswordEmail(email string) (*client.Response, error) {
+func (a *App) SendForgotPasswordEmail(email string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
-	return a.api.Auth().SendForgotPasswordEmail(email)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().SendForgotPasswordEmail(a.ctx, email)
 }
 
-func (a *App) ResetPassword(data map[string]interface{}) (*client.Response, error) {
+func (a *App) ResetPassword(data map[string]interface{}) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
-	return a.api.Auth().ResetPassword(data)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Auth().ResetPassword(a.ctx, data)
 }
 
-func (a *App) GetUserDetails(userID string) (*client.Response, error) {
+func (a *App) GetUserDetails(userID string) (*api.Response[models.User], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.User().GetUserDetails(userID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.User().GetUserDetails(client.AllowStale(a.ctx), userID)
 }
 
-func (a *App) GetListUsers(page string, keyword string, order string, status string, gender string, statusAccount string, role string, hasRoom *bool) (*client.Response, error) {
+func (a *App) GetListUsers(page string, keyword string, order string, status string, gender string, statusAccount string, role string, hasRoom *bool, requestID string) (*api.Response[[]models.User], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -129,18 +254,27 @@ func (a *App) GetListUsers(page string, keyword string, order string, status str
 		return nil, errors.New("invalid page number: " + page)
 	}
 
-	return a.api.User().GetListUsers(pageInt, keyword, order, status, gender, statusAccount, role, hasRoom)
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.User().GetListUsers(client.AllowStale(ctx), pageInt, keyword, order, status, gender, statusAccount, role, hasRoom)
 }
 
-func (a *App) UpdateUserStatus(userID string, statusAccount string) (*client.Response, error) {
+func (a *App) UpdateUserStatus(userID string, statusAccount string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.User().UpdateUserStatus(userID, statusAccount)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.User().UpdateUserStatus(a.ctx, userID, statusAccount)
 }
 
-func (a *App) GetRoomDetails(roomID string) (*client.Response, error) {
+func (a *App) GetRoomDetails(roomID string) (*api.Response[models.Room], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -151,10 +285,13 @@ func (a *App) GetRoomDetails(roomID string) (*client.Response, error) {
 		return nil, errors.New("invalid room ID: " + roomID)
 	}
 
-	return a.api.Room().GetRoomDetails(roomIDInt)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Room().GetRoomDetails(client.AllowStale(a.ctx), roomIDInt)
 }
 
-func (a *App) GetListRooms(page string) (*client.Response, error) {
+func (a *App) GetListRooms(page string, requestID string) (*api.Response[[]models.Room], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -165,18 +302,27 @@ func (a *App) GetListRooms(page string) (*client.Response, error) {
 		return nil, errors.New("invalid page number: " + page)
 	}
 
-	return a.api.Room().GetListRooms(pageInt)
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Room().GetListRooms(client.AllowStale(ctx), pageInt)
 }
 
-func (a *App) CreateRoom(roomData map[string]interface{}) (*client.Response, error) {
+func (a *App) CreateRoom(roomData map[string]interface{}) (*api.Response[models.Room], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Room().CreateRoom(roomData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Room().CreateRoom(a.ctx, roomData)
 }
 
-func (a *App) DeleteRoom(roomID string) (*client.Response, error) {
+func (a *App) DeleteRoom(roomID string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -187,9 +333,12 @@ func (a *App) DeleteRoom(roomID string) (*client.Response, error) {
 		return nil, errors.New("invalid room ID: " + roomID)
 	}
 
-	return a.api.Room().DeleteRoom(roomIDInt)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Room().DeleteRoom(a.ctx, roomIDInt)
 }
-func (a *App) UpdateRoom(roomID string, roomData map[string]interface{}) (*client.Response, error) {
+func (a *App) UpdateRoom(roomID string, roomData map[string]interface{}) (*api.Response[models.Room], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -200,10 +349,13 @@ func (a *App) UpdateRoom(roomID string, roomData map[string]interface{}) (*clien
 		return nil, errors.New("invalid room ID: " + roomID)
 	}
 
-	return a.api.Room().UpdateRoom(roomIDInt, roomData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Room().UpdateRoom(a.ctx, roomIDInt, roomData)
 }
 
-func (a *App) AddStudentToRoom(roomID string, userID string) (*client.Response, error) {
+func (a *App) AddStudentToRoom(roomID string, userID string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -216,10 +368,13 @@ func (a *App) AddStudentToRoom(roomID string, userID string) (*client.Response,
 		return nil, errors.New("invalid user ID: " + userID)
 	}
 
-	return a.api.User().AddStudentToRoom(roomIDInt, userIDInt)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.User().AddStudentToRoom(a.ctx, roomIDInt, userIDInt)
 }
 
-func (a *App) GetContractDetails(contractID string) (*client.Response, error) {
+func (a *App) GetContractDetails(contractID string) (*api.Response[models.Contract], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -230,10 +385,13 @@ func (a *App) GetContractDetails(contractID string) (*client.Response, error) {
 		return nil, errors.New("invalid contract ID: " + contractID)
 	}
 
-	return a.api.Contract().GetContractDetails(contractIDInt)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Contract().GetContractDetails(client.AllowStale(a.ctx), contractIDInt)
 }
 
-func (a *App) GetListContracts(page string, keyword *string) (*client.Response, error) {
+func (a *App) GetListContracts(page string, keyword *string, requestID string) (*api.Response[[]models.Contract], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -244,26 +402,38 @@ func (a *App) GetListContracts(page string, keyword *string) (*client.Response,
 		return nil, errors.New("invalid page number: " + page)
 	}
 
-	return a.api.Contract().GetListContracts(pageInt, keyword)
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Contract().GetListContracts(client.AllowStale(ctx), pageInt, keyword)
 }
 
-func (a *App) CreateContract(contractData map[string]interface{}) (*client.Response, error) {
+func (a *App) CreateContract(contractData map[string]interface{}) (*api.Response[models.Contract], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Contract().CreateContract(contractData)
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).Create(a.ctx, contractData)
 }
 
-func (a *App) GetAmenityDetails(amenityID string) (*client.Response, error) {
+func (a *App) GetAmenityDetails(amenityID string) (*api.Response[models.Amenity], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Amenities().GetAmenityDetails(amenityID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Amenities().GetAmenityDetails(a.ctx, amenityID)
 }
 
-func (a *App) GetListAmenities(page string) (*client.Response, error) {
+func (a *App) GetListAmenities(page string) (*api.Response[[]models.Amenity], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
@@ -274,93 +444,129 @@ func (a *App) GetListAmenities(page string) (*client.Response, error) {
 		return nil, errors.New("invalid page number: " + page)
 	}
 
-	return a.api.Amenities().GetListAmenities(pageInt)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Amenities().GetListAmenities(a.ctx, pageInt)
 }
 
-func (a *App) CreateAmenity(amenityData map[string]interface{}) (*client.Response, error) {
+func (a *App) CreateAmenity(amenityData map[string]interface{}) (*api.Response[models.Amenity], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Amenities().CreateAmenity(amenityData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Amenities().CreateAmenity(a.ctx, amenityData)
 }
 
-func (a *App) DeleteAmenity(amenityID string) (*client.Response, error) {
+func (a *App) DeleteAmenity(amenityID string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Amenities().DeleteAmenity(amenityID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Amenities().DeleteAmenity(a.ctx, amenityID)
 }
 
-func (a *App) UpdateAmenity(amenityID string, amenityData map[string]interface{}) (*client.Response, error) {
+func (a *App) UpdateAmenity(amenityID string, amenityData map[string]interface{}) (*api.Response[models.Amenity], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.Amenities().UpdateAmenity(amenityID, amenityData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Amenities().UpdateAmenity(a.ctx, amenityID, amenityData)
 }
 
-func (a *App) GetRoomCategoryDetails(categoryID string) (*client.Response, error) {
+func (a *App) GetRoomCategoryDetails(categoryID string) (*api.Response[models.RoomCategory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.RoomCategory().GetRoomCategoryDetails(categoryID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.RoomCategory().GetRoomCategoryDetails(a.ctx, categoryID)
 }
 
-func (a *App) GetListRoomCategories(page string) (*client.Response, error) {
+func (a *App) GetListRoomCategories(page string) (*api.Response[[]models.RoomCategory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.RoomCategory().GetListRoomCategories(page)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.RoomCategory().GetListRoomCategories(a.ctx, page)
 }
 
-func (a *App) CreateRoomCategory(categoryData map[string]interface{}) (*client.Response, error) {
+func (a *App) CreateRoomCategory(categoryData map[string]interface{}) (*api.Response[models.RoomCategory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.RoomCategory().CreateRoomCategory(categoryData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.RoomCategory().CreateRoomCategory(a.ctx, categoryData)
 }
 
-func (a *App) GetMaintenanceHistoryDetails(historyID string) (*client.Response, error) {
+func (a *App) GetMaintenanceHistoryDetails(historyID string) (*api.Response[models.MaintenanceHistory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.MaintenanceHistory().GetMaintenanceHistoryDetails(historyID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MaintenanceHistory().GetMaintenanceHistoryDetails(a.ctx, historyID)
 }
 
-func (a *App) GetListMaintenanceHistories(page string, roomID string) (*client.Response, error) {
+func (a *App) GetListMaintenanceHistories(page string, roomID string) (*api.Response[[]models.MaintenanceHistory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.MaintenanceHistory().GetListMaintenanceHistories(page, roomID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MaintenanceHistory().GetListMaintenanceHistories(a.ctx, page, roomID)
 }
 
-func (a *App) CreateMaintenanceHistory(historyData map[string]interface{}) (*client.Response, error) {
+func (a *App) CreateMaintenanceHistory(historyData map[string]interface{}) (*api.Response[models.MaintenanceHistory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.MaintenanceHistory().CreateMaintenanceHistory(historyData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MaintenanceHistory().CreateMaintenanceHistory(a.ctx, historyData)
 }
 
-func (a *App) DeleteMaintenanceHistory(historyID string) (*client.Response, error) {
+func (a *App) DeleteMaintenanceHistory(historyID string) (*api.Response[any], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.MaintenanceHistory().DeleteMaintenanceHistory(historyID)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MaintenanceHistory().DeleteMaintenanceHistory(a.ctx, historyID)
 }
 
-func (a *App) UpdateMaintenanceHistory(historyID string, historyData map[string]interface{}) (*client.Response, error) {
+func (a *App) UpdateMaintenanceHistory(historyID string, historyData map[string]interface{}) (*api.Response[models.MaintenanceHistory], error) {
 	if a.ctx == nil {
 		return nil, context.Canceled
 	}
 
-	return a.api.MaintenanceHistory().UpdateMaintenanceHistory(historyID, historyData)
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MaintenanceHistory().UpdateMaintenanceHistory(a.ctx, historyID, historyData)
 }
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"changeme/internal/audit"
+	"context"
+	"time"
+)
+
+// RunOccupancyAudit checks every room, user and contract for occupancy
+// inconsistencies and suggests fixes
+func (a *App) RunOccupancyAudit(requestID string) (*audit.Report, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return audit.NewAuditor(ws.api).Run(ctx, time.Now())
+}
+
+// ApplyAuditFixes applies the fixes staff picked from an audit report
+func (a *App) ApplyAuditFixes(fixes []audit.Fix) ([]audit.Outcome, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return audit.NewAuditor(ws.api).Apply(a.ctx, fixes, time.Now()), nil
+}
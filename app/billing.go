@@ -0,0 +1,41 @@
+package app
+
+import (
+	"changeme/internal/billing"
+	"context"
+)
+
+// PreviewInvoices shows the room fee invoices GenerateInvoices would create
+// for a period (YYYY-MM) without creating them
+func (a *App) PreviewInvoices(period string) (*billing.Plan, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	p, err := billing.ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return billing.NewEngine(ws.api, billing.Options{}).Preview(a.ctx, p)
+}
+
+// GenerateInvoices creates the room fee invoices of a period (YYYY-MM) from the active contracts
+func (a *App) GenerateInvoices(period string) (*billing.Result, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	p, err := billing.ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return billing.NewEngine(ws.api, billing.Options{}).Generate(a.ctx, p)
+}
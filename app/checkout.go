@@ -0,0 +1,65 @@
+package app
+
+import (
+	"changeme/internal/checkout"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// GetCheckoutChecklist prepares checking a student out on date (YYYY-MM-DD):
+// the room's amenities to inspect, unpaid invoices and the deposit
+func (a *App) GetCheckoutChecklist(userID string, date string) (*checkout.Checklist, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert userID string to int
+	userIDInt, err := strconv.Atoi(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID: " + userID)
+	}
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, errors.New("invalid check-out date: " + date)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return checkout.NewDesk(ws.api).Checklist(a.ctx, userIDInt, day)
+}
+
+// CheckOutStudent completes a check-out with the inspected checklist items.
+// Failures after the first change are reported in the result's error.
+func (a *App) CheckOutStudent(userID string, date string, items []checkout.Item, deductDamages bool, payOutstanding bool, paymentMethod string) (*checkout.Result, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert userID string to int
+	userIDInt, err := strconv.Atoi(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID: " + userID)
+	}
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, errors.New("invalid check-out date: " + date)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return checkout.NewDesk(ws.api).Complete(a.ctx, checkout.Request{
+		UserID:         userIDInt,
+		Date:           day,
+		Items:          items,
+		DeductDamages:  deductDamages,
+		PayOutstanding: payOutstanding,
+		PaymentMethod:  models.PaymentMethod(paymentMethod),
+	})
+}
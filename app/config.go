@@ -0,0 +1,108 @@
+package app
+
+import (
+	"changeme/internal/config"
+	"context"
+	"slices"
+)
+
+// GetConfig returns the configuration the app is currently running with
+func (a *App) GetConfig() (*config.Config, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	return cloneConfig(a.config), nil
+}
+
+// UpdateConfig validates cfg, saves the changed fields to the user config file and reconnects
+// if the active profile changed. Logging changes take effect after a restart.
+func (a *App) UpdateConfig(cfg config.Config) (*config.Config, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	return a.updateConfig(func(current *config.Config) error {
+		*current = cfg
+		return nil
+	})
+}
+
+// updateConfig applies change to a copy of the config, validates and saves it,
+// and switches workspaces when the active profile is no longer the same
+func (a *App) updateConfig(change func(cfg *config.Config) error) (*config.Config, error) {
+	a.updateMu.Lock()
+	defer a.updateMu.Unlock()
+
+	previous, next, err := a.saveConfig(change)
+	if err != nil {
+		return nil, err
+	}
+
+	// configMu is free again here: switching waits for the previous
+	// workspace's background jobs, which read the config
+	if active := next.Active(); active != previous.Active() {
+		if err := a.activate(active); err != nil {
+			return nil, err
+		}
+	}
+
+	return cloneConfig(next), nil
+}
+
+// saveConfig applies change to a copy of the config, validates and saves it
+// and makes it current. It returns the config it replaced.
+func (a *App) saveConfig(change func(cfg *config.Config) error) (previous, next *config.Config, err error) {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	next = cloneConfig(a.config)
+	if err := change(next); err != nil {
+		return nil, nil, err
+	}
+
+	if err := next.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := config.Save(a.config, next); err != nil {
+		return nil, nil, err
+	}
+
+	previous = a.config
+	a.config = next
+	close(a.configSaved)
+	a.configSaved = make(chan struct{})
+
+	return previous, next, nil
+}
+
+func cloneConfig(cfg *config.Config) *config.Config {
+	clone := *cfg
+	clone.Profiles = slices.Clone(cfg.Profiles)
+	clone.Metering.Electricity.Tiers = slices.Clone(cfg.Metering.Electricity.Tiers)
+	clone.Metering.Water.Tiers = slices.Clone(cfg.Metering.Water.Tiers)
+	clone.Contracts.Expiry.Windows = slices.Clone(cfg.Contracts.Expiry.Windows)
+	return &clone
+}
+
+// currentConfig returns a copy of the configuration for background work
+func (a *App) currentConfig() *config.Config {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	return cloneConfig(a.config)
+}
+
+// watchConfig returns a copy of the configuration for background work and a
+// channel that is closed once it is replaced, so schedulers can pick up a new
+// interval without waiting for the old one to elapse
+func (a *App) watchConfig() (*config.Config, <-chan struct{}) {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	return cloneConfig(a.config), a.configSaved
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/lease"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// UpdateContract amends a contract's dates, price, room or description
+func (a *App) UpdateContract(contractID string, contractData map[string]interface{}) (*api.Response[models.Contract], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).Update(a.ctx, contractIDInt, contractData)
+}
+
+// RenewContract continues a contract until endDate (YYYY-MM-DD). A zero
+// price takes the room category's current price.
+func (a *App) RenewContract(contractID string, endDate string, price float64, carryOver bool) (*api.Response[models.Contract], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	end, err := lease.ParseDate(endDate)
+	if err != nil {
+		return nil, errors.New("invalid end date: " + endDate)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).Renew(a.ctx, contractIDInt, lease.Renewal{EndDate: end, Price: price, CarryOver: carryOver})
+}
+
+// PreviewContractTermination shows the penalty for ending a contract early on endDate
+func (a *App) PreviewContractTermination(contractID string, endDate string) (*lease.Termination, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	end, err := lease.ParseDate(endDate)
+	if err != nil {
+		return nil, errors.New("invalid end date: " + endDate)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).PlanTermination(a.ctx, contractIDInt, end, time.Now())
+}
+
+// TerminateContract ends a contract early on endDate. A nil penalty charges
+// the one computed from the notice given.
+func (a *App) TerminateContract(contractID string, endDate string, reason string, penalty *float64) (*lease.TerminationResult, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	end, err := lease.ParseDate(endDate)
+	if err != nil {
+		return nil, errors.New("invalid end date: " + endDate)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).Terminate(a.ctx, contractIDInt, end, reason, penalty, time.Now())
+}
+
+// CancelContract voids a contract that has not started yet
+func (a *App) CancelContract(contractID string, reason string) (*api.Response[models.Contract], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.lease(ws).Cancel(a.ctx, contractIDInt, reason, time.Now())
+}
+
+func (a *App) lease(ws *workspace) *lease.Manager {
+	return lease.NewManager(ws.api, a.currentConfig().Contracts)
+}
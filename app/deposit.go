@@ -0,0 +1,124 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/deposit"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// Deposit ledger methods
+func (a *App) GetDepositStatement(contractID string) (*deposit.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return deposit.NewLedger(ws.api).Statement(a.ctx, contractIDInt)
+}
+
+func (a *App) RecordDepositReceived(contractID string, amount float64, description string) (*deposit.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return deposit.NewLedger(ws.api).Receive(a.ctx, contractIDInt, amount, description)
+}
+
+// DeductFromDeposit charges a maintenance history entry to the deposit; an
+// amount of 0 uses the cost of the repair
+func (a *App) DeductFromDeposit(contractID string, historyID string, amount float64, description string) (*deposit.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID and historyID strings to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+	historyIDInt, err := strconv.Atoi(historyID)
+	if err != nil {
+		return nil, errors.New("invalid maintenance history ID: " + historyID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return deposit.NewLedger(ws.api).Deduct(a.ctx, contractIDInt, historyIDInt, amount, description)
+}
+
+func (a *App) RefundDeposit(contractID string, amount float64, description string) (*deposit.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return deposit.NewLedger(ws.api).Refund(a.ctx, contractIDInt, amount, description)
+}
+
+func (a *App) SettleDeposit(contractID string) (*deposit.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return deposit.NewLedger(ws.api).Settle(a.ctx, contractIDInt)
+}
+
+// CloseContract marks a contract inactive once its deposit has been settled
+func (a *App) CloseContract(contractID string) (*api.Response[models.Contract], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return nil, errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	if err := deposit.NewLedger(ws.api).EnsureSettled(a.ctx, contractIDInt); err != nil {
+		return nil, err
+	}
+
+	return ws.api.Contract().UpdateContractStatus(a.ctx, contractIDInt, models.ContractStatusInactive)
+}
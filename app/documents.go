@@ -0,0 +1,115 @@
+package app
+
+import (
+	"changeme/internal/document"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// savePDF asks where to save a rendered PDF and writes it. It returns the
+// chosen path, or an empty string when the user cancels the dialog.
+func (a *App) savePDF(filename string, render func() ([]byte, error)) (string, error) {
+	data, err := render()
+	if err != nil {
+		return "", err
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: filename,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "PDF (*.pdf)", Pattern: "*.pdf"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to save PDF: %w", err)
+	}
+	return path, nil
+}
+
+// SaveContractPDF renders a contract and saves it through the save-file dialog
+func (a *App) SaveContractPDF(contractID string) (string, error) {
+	if a.ctx == nil {
+		return "", context.Canceled
+	}
+
+	// Convert contractID string to int
+	contractIDInt, err := strconv.Atoi(contractID)
+	if err != nil {
+		return "", errors.New("invalid contract ID: " + contractID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	contract, err := ws.api.Contract().GetContractDetails(a.ctx, contractIDInt)
+	if err != nil {
+		return "", err
+	}
+
+	return a.savePDF("HopDong-"+contract.Data.Code+".pdf", func() ([]byte, error) {
+		return document.Contract(contract.Data)
+	})
+}
+
+// SaveInvoicePDF renders an invoice and saves it through the save-file dialog
+func (a *App) SaveInvoicePDF(invoiceID string) (string, error) {
+	if a.ctx == nil {
+		return "", context.Canceled
+	}
+
+	// Convert invoiceID string to int
+	invoiceIDInt, err := strconv.Atoi(invoiceID)
+	if err != nil {
+		return "", errors.New("invalid invoice ID: " + invoiceID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	invoice, err := ws.api.Invoice().GetInvoiceDetails(a.ctx, invoiceIDInt)
+	if err != nil {
+		return "", err
+	}
+
+	return a.savePDF("HoaDon-"+invoice.Data.Code+".pdf", func() ([]byte, error) {
+		return document.Invoice(invoice.Data)
+	})
+}
+
+// SaveReceiptPDF renders the receipt of a payment and saves it through the save-file dialog
+func (a *App) SaveReceiptPDF(paymentID string) (string, error) {
+	if a.ctx == nil {
+		return "", context.Canceled
+	}
+
+	// Convert paymentID string to int
+	paymentIDInt, err := strconv.Atoi(paymentID)
+	if err != nil {
+		return "", errors.New("invalid payment ID: " + paymentID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	payment, err := ws.api.Payment().GetPaymentDetails(a.ctx, paymentIDInt)
+	if err != nil {
+		return "", err
+	}
+	invoice, err := ws.api.Invoice().GetInvoiceDetails(a.ctx, payment.Data.InvoiceID)
+	if err != nil {
+		return "", err
+	}
+
+	return a.savePDF(fmt.Sprintf("BienLai-%06d.pdf", payment.Data.ID), func() ([]byte, error) {
+		return document.Receipt(payment.Data, invoice.Data)
+	})
+}
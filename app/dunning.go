@@ -0,0 +1,113 @@
+package app
+
+import (
+	"changeme/internal/dunning"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventDunningCompleted is emitted with the report of every dunning run that changed something
+const EventDunningCompleted = "dunning:completed"
+
+// startDunning chases overdue invoices every configured interval until ctx
+// is done. Runs are skipped while signed out or when dunning is disabled, and
+// the schedule restarts whenever the config is saved.
+func (a *App) startDunning(ctx context.Context, ws *workspace) {
+	if ws.dunning == nil {
+		return
+	}
+
+	ws.jobs.Add(1)
+	go func() {
+		defer ws.jobs.Done()
+
+		for {
+			current, changed := a.watchConfig()
+			timer := newSchedule(time.Duration(current.Dunning.Interval) * time.Minute)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-changed:
+				timer.Stop()
+				continue
+			case <-timer.C:
+			}
+
+			cfg := a.currentConfig().Dunning
+			if !cfg.Enabled || ws.client.Token() == nil {
+				continue
+			}
+
+			report, err := ws.dunning.Run(ctx, cfg, time.Now())
+			if err != nil {
+				slog.Warn("dunning run failed", "profile", ws.profile.Name, "error", err)
+				continue
+			}
+			if len(report.Actions) > 0 || len(report.Failures) > 0 {
+				runtime.EventsEmit(a.ctx, EventDunningCompleted, report)
+			}
+		}
+	}()
+}
+
+// newSchedule returns a timer that fires after interval. A disabled interval
+// of zero or less returns a timer that never fires.
+func newSchedule(interval time.Duration) *time.Timer {
+	if interval <= 0 {
+		timer := time.NewTimer(time.Hour)
+		timer.Stop()
+		return timer
+	}
+	return time.NewTimer(interval)
+}
+
+// RunDunning chases overdue invoices now instead of waiting for the schedule
+func (a *App) RunDunning() (*dunning.Report, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.dunning == nil {
+		return nil, errors.New("dunning journal is not available")
+	}
+
+	return ws.dunning.Run(a.ctx, a.currentConfig().Dunning, time.Now())
+}
+
+// ListDunningActions returns every action the dunning engine took, oldest first
+func (a *App) ListDunningActions() ([]dunning.Action, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.dunning == nil {
+		return []dunning.Action{}, nil
+	}
+
+	return ws.dunning.List()
+}
+
+// ReverseDunningAction undoes an action; it is not applied to the same invoice again
+func (a *App) ReverseDunningAction(actionID string) (*dunning.Action, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.dunning == nil {
+		return nil, errors.New("dunning journal is not available")
+	}
+
+	return ws.dunning.Reverse(a.ctx, actionID)
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"changeme/internal/expiry"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gen2brain/beeep"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventRenewalsDue is emitted with the renewals view after every scan that
+// found contracts entering a tighter window
+const EventRenewalsDue = "contracts:renewals-due"
+
+// expiryFirstCheck gives the user time to sign in before the first scan
+const expiryFirstCheck = time.Minute
+
+// RenewalsDueEvent is the payload of EventRenewalsDue
+type RenewalsDueEvent struct {
+	Renewals *expiry.Renewals `json:"renewals"`
+	New      []expiry.Due     `json:"new"`
+}
+
+// startExpiryWatch looks for contracts about to end shortly after start and
+// then every configured interval. Scans are skipped while signed out or when
+// the watcher is disabled, and the schedule restarts whenever the config is
+// saved, so an interval set after start takes effect.
+func (a *App) startExpiryWatch(ctx context.Context, ws *workspace) {
+	ws.jobs.Add(1)
+	go func() {
+		defer ws.jobs.Done()
+
+		first := true
+		for {
+			current, changed := a.watchConfig()
+			cfg := current.Contracts.Expiry
+			delay := time.Duration(cfg.Interval) * time.Minute
+			if first && delay > 0 {
+				delay = expiryFirstCheck
+			}
+			timer := newSchedule(delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-changed:
+				timer.Stop()
+				continue
+			case <-timer.C:
+			}
+			first = false
+
+			if !cfg.Enabled || ws.client.Token() == nil {
+				continue
+			}
+
+			renewals, fresh, err := ws.expiry.Check(ctx, cfg, time.Now())
+			if err != nil {
+				slog.Warn("contract expiry check failed", "profile", ws.profile.Name, "error", err)
+				continue
+			}
+			if len(fresh) == 0 {
+				continue
+			}
+
+			runtime.EventsEmit(a.ctx, EventRenewalsDue, RenewalsDueEvent{Renewals: renewals, New: fresh})
+			if cfg.DesktopNotifications {
+				title, message := renewalsNotification(fresh)
+				if err := beeep.Notify(title, message, ""); err != nil {
+					slog.Warn("failed to show desktop notification", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// GetRenewalsDue scans contracts now and returns the renewals view for the dashboard
+func (a *App) GetRenewalsDue() (*expiry.Renewals, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.expiry.Renewals(a.ctx, a.currentConfig().Contracts.Expiry, time.Now())
+}
+
+func renewalsNotification(fresh []expiry.Due) (string, string) {
+	expired := 0
+	for _, due := range fresh {
+		if due.Expired {
+			expired++
+		}
+	}
+
+	if len(fresh) == 1 {
+		due := fresh[0]
+		if due.Expired {
+			return "Hợp đồng đã hết hạn", fmt.Sprintf("Hợp đồng %s của %s (phòng %s) đã hết hạn ngày %s nhưng vẫn còn hiệu lực",
+				due.ContractCode, due.StudentName, due.RoomNumber, due.EndDate.Format("02/01/2006"))
+		}
+		return "Hợp đồng sắp hết hạn", fmt.Sprintf("Hợp đồng %s của %s (phòng %s) hết hạn sau %d ngày, ngày %s",
+			due.ContractCode, due.StudentName, due.RoomNumber, due.DaysLeft, due.EndDate.Format("02/01/2006"))
+	}
+
+	var parts []string
+	if expired > 0 {
+		parts = append(parts, fmt.Sprintf("%d hợp đồng đã hết hạn nhưng vẫn còn hiệu lực", expired))
+	}
+	if expiring := len(fresh) - expired; expiring > 0 {
+		parts = append(parts, fmt.Sprintf("%d hợp đồng sắp hết hạn", expiring))
+	}
+	return "Hợp đồng cần gia hạn", strings.Join(parts, ", ")
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"changeme/internal/export"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventExportProgress is emitted after every page ExportList fetches
+const EventExportProgress = "export:progress"
+
+// ExportProgress is the payload of EventExportProgress
+type ExportProgress struct {
+	RequestID string      `json:"request_id"`
+	Kind      export.Kind `json:"kind"`
+	Fetched   int         `json:"fetched"`
+	Total     int         `json:"total"`
+}
+
+// ExportResult describes a saved export
+type ExportResult struct {
+	Path    string `json:"path"`
+	Records int    `json:"records"`
+}
+
+var exportFilenames = map[export.Kind]string{
+	export.KindUsers:                "DanhSachSinhVien",
+	export.KindRooms:                "DanhSachPhong",
+	export.KindContracts:            "DanhSachHopDong",
+	export.KindMaintenanceHistories: "LichSuBaoTri",
+}
+
+// ExportList saves every page of a list, filtered like the screen it is
+// exported from, as .xlsx or .csv. kind is one of users, rooms, contracts
+// and maintenance_histories. It returns nil when the user cancels the dialog;
+// the export itself can be cancelled through requestID.
+func (a *App) ExportList(kind string, filter export.Filter, requestID string) (*ExportResult, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	name, ok := exportFilenames[export.Kind(kind)]
+	if !ok {
+		return nil, fmt.Errorf("unknown list %q", kind)
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: name + "-" + time.Now().Format("20060102") + ".xlsx",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Excel (*.xlsx)", Pattern: "*.xlsx"},
+			{DisplayName: "CSV (*.csv)", Pattern: "*.csv"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	data, records, err := export.NewExporter(ws.api).Export(ctx, export.Kind(kind), filter, path, func(fetched, total int) {
+		runtime.EventsEmit(a.ctx, EventExportProgress, ExportProgress{
+			RequestID: requestID,
+			Kind:      export.Kind(kind),
+			Fetched:   fetched,
+			Total:     total,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to save export: %w", err)
+	}
+	return &ExportResult{Path: path, Records: records}, nil
+}
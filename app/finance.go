@@ -0,0 +1,122 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// Invoice API methods
+func (a *App) GetInvoiceDetails(invoiceID string) (*api.Response[models.Invoice], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert invoiceID string to int
+	invoiceIDInt, err := strconv.Atoi(invoiceID)
+	if err != nil {
+		return nil, errors.New("invalid invoice ID: " + invoiceID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Invoice().GetInvoiceDetails(client.AllowStale(a.ctx), invoiceIDInt)
+}
+
+func (a *App) GetListInvoices(query api.InvoiceQuery, requestID string) (*api.Response[[]models.Invoice], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ctx, done := a.track(requestID)
+	defer done()
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Invoice().GetListInvoices(client.AllowStale(ctx), query)
+}
+
+func (a *App) CreateInvoice(invoiceData map[string]interface{}) (*api.Response[models.Invoice], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Invoice().CreateInvoice(a.ctx, invoiceData)
+}
+
+func (a *App) VoidInvoice(invoiceID string, reason string) (*api.Response[models.Invoice], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert invoiceID string to int
+	invoiceIDInt, err := strconv.Atoi(invoiceID)
+	if err != nil {
+		return nil, errors.New("invalid invoice ID: " + invoiceID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Invoice().VoidInvoice(a.ctx, invoiceIDInt, reason)
+}
+
+// Payment API methods
+func (a *App) GetPaymentDetails(paymentID string) (*api.Response[models.Payment], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert paymentID string to int
+	paymentIDInt, err := strconv.Atoi(paymentID)
+	if err != nil {
+		return nil, errors.New("invalid payment ID: " + paymentID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Payment().GetPaymentDetails(a.ctx, paymentIDInt)
+}
+
+func (a *App) GetStudentPayments(userID string, page string) (*api.Response[[]models.Payment], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert userID and page strings to int
+	userIDInt, err := strconv.Atoi(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID: " + userID)
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		return nil, errors.New("invalid page number: " + page)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Payment().GetStudentPayments(client.AllowStale(a.ctx), userIDInt, pageInt)
+}
+
+// RecordPayment records a full or partial payment; paymentData carries
+// invoice_id, amount, method and optionally reference and note
+func (a *App) RecordPayment(paymentData map[string]interface{}) (*api.Response[models.Payment], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.Payment().RecordPayment(a.ctx, paymentData)
+}
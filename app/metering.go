@@ -0,0 +1,103 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/client"
+	"changeme/internal/metering"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// Meter reading API methods
+func (a *App) GetListMeterReadings(page string, roomID string, period string) (*api.Response[[]models.MeterReading], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert page string to int
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		return nil, errors.New("invalid page number: " + page)
+	}
+
+	// An empty roomID lists the readings of every room
+	var roomIDInt *int
+	if roomID != "" {
+		id, err := strconv.Atoi(roomID)
+		if err != nil {
+			return nil, errors.New("invalid room ID: " + roomID)
+		}
+		roomIDInt = &id
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MeterReading().GetListMeterReadings(client.AllowStale(a.ctx), pageInt, roomIDInt, period)
+}
+
+func (a *App) CreateMeterReading(readingData map[string]interface{}) (*api.Response[models.MeterReading], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MeterReading().CreateMeterReading(a.ctx, readingData)
+}
+
+func (a *App) UpdateMeterReading(readingID string, readingData map[string]interface{}) (*api.Response[models.MeterReading], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert readingID string to int
+	readingIDInt, err := strconv.Atoi(readingID)
+	if err != nil {
+		return nil, errors.New("invalid meter reading ID: " + readingID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.MeterReading().UpdateMeterReading(a.ctx, readingIDInt, readingData)
+}
+
+// PreviewUtilityCharges computes each room's electricity and water charges
+// for a period (YYYY-MM) and their split among occupants, flagging anomalies
+func (a *App) PreviewUtilityCharges(period string) (*metering.Statement, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	p, err := billing.ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return metering.NewEngine(ws.api, a.currentConfig().Metering).Preview(a.ctx, p)
+}
+
+// BillUtilityCharges invoices every occupant's share of a period's utility charges
+func (a *App) BillUtilityCharges(period string) (*metering.Result, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	p, err := billing.ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return metering.NewEngine(ws.api, a.currentConfig().Metering).Bill(a.ctx, p)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"changeme/internal/outbox"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// EventOperationReplayed is emitted when a queued write reached the server
+	EventOperationReplayed = "outbox:replayed"
+	// EventOperationFailed is emitted when the server rejected a queued write
+	EventOperationFailed = "outbox:failed"
+)
+
+const replayInterval = 30 * time.Second
+
+// startReplayer replays the workspace's queued writes in the background until ctx is done
+func (a *App) startReplayer(ctx context.Context, ws *workspace) {
+	if ws.operations == nil {
+		return
+	}
+
+	ws.replayer = outbox.NewReplayer(ws.operations, ws.client, replayInterval, func(result outbox.Result) {
+		if result.Replayed {
+			runtime.EventsEmit(a.ctx, EventOperationReplayed, result)
+			return
+		}
+		runtime.EventsEmit(a.ctx, EventOperationFailed, result)
+	})
+	ws.jobs.Add(1)
+	go func() {
+		defer ws.jobs.Done()
+		ws.replayer.Run(ctx)
+	}()
+}
+
+// ListPendingOperations returns the writes queued while offline, oldest first,
+// including the ones the server rejected
+func (a *App) ListPendingOperations() ([]outbox.Operation, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.operations == nil {
+		return []outbox.Operation{}, nil
+	}
+
+	return ws.operations.List()
+}
+
+// DiscardOperation drops a queued write without sending it
+func (a *App) DiscardOperation(operationID string) error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.operations == nil {
+		return errors.New("offline queue is not available")
+	}
+
+	return ws.operations.Delete(operationID)
+}
+
+// ReplayPendingOperations sends the queued writes now instead of waiting for the next retry
+func (a *App) ReplayPendingOperations() error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.replayer == nil {
+		return errors.New("offline queue is not available")
+	}
+
+	ws.replayer.Trigger()
+	return nil
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"changeme/internal/config"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventProfileChanged is emitted after the app switched to another server profile
+const EventProfileChanged = "profile:changed"
+
+type ProfileInfo struct {
+	Profile config.ProfileConfig `json:"profile"`
+	Active  bool                 `json:"active"`
+}
+
+// ListProfiles returns the configured server profiles and marks the active one
+func (a *App) ListProfiles() ([]ProfileInfo, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	active := a.config.Active().Name
+	profiles := a.config.ServerProfiles()
+	infos := make([]ProfileInfo, len(profiles))
+	for i, profile := range profiles {
+		infos[i] = ProfileInfo{Profile: profile, Active: profile.Name == active}
+	}
+
+	return infos, nil
+}
+
+// AddProfile saves a new server profile without switching to it
+func (a *App) AddProfile(profile config.ProfileConfig) error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	_, err := a.updateConfig(func(cfg *config.Config) error {
+		if _, exists := cfg.Profile(profile.Name); exists {
+			return fmt.Errorf("profile %q already exists", profile.Name)
+		}
+
+		// The implicit default profile becomes explicit so that it is kept
+		cfg.Profiles = append(cfg.ServerProfiles(), profile)
+		return nil
+	})
+
+	return err
+}
+
+// RemoveProfile deletes a server profile together with its stored session,
+// cache and queued writes. The active profile cannot be removed.
+func (a *App) RemoveProfile(name string) error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	_, err := a.updateConfig(func(cfg *config.Config) error {
+		if cfg.Active().Name == name {
+			return fmt.Errorf("cannot remove the active profile %q", name)
+		}
+
+		profiles := cfg.ServerProfiles()
+		for i, profile := range profiles {
+			if profile.Name == name {
+				cfg.Profiles = append(profiles[:i], profiles[i+1:]...)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no profile named %q", name)
+	})
+	if err != nil {
+		return err
+	}
+
+	if dir, err := config.ProfileDir(name); err == nil {
+		if err := os.RemoveAll(dir); err != nil {
+			slog.Error("failed to remove profile data", "profile", name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// SwitchProfile makes another server profile active. The user is signed out
+// of the previous server and can restore the session stored for the new one.
+func (a *App) SwitchProfile(name string) error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	_, err := a.updateConfig(func(cfg *config.Config) error {
+		if _, ok := cfg.Profile(name); !ok {
+			return fmt.Errorf("no profile named %q", name)
+		}
+
+		cfg.ActiveProfile = name
+		return nil
+	})
+
+	return err
+}
+
+// activate replaces the current workspace with one for profile. The new
+// workspace is opened before it is swapped in, so bindings never see a closed
+// one; the old workspace is closed once the requests still using it finish.
+// Its background jobs are stopped first: reopening the same profile hands its
+// outbox, journal and expiry stores to the new workspace, and two replayers
+// or dunning runs on the same stores would send the same writes twice.
+func (a *App) activate(profile config.ProfileConfig) error {
+	a.wsMu.Lock()
+	defer a.wsMu.Unlock()
+
+	previous := a.ws
+	previous.halt()
+
+	var (
+		ws  *workspace
+		err error
+	)
+	if previous.profile.Name == profile.Name {
+		ws, err = reopenWorkspace(profile, previous)
+	} else {
+		ws, err = openWorkspace(profile)
+		if err == nil {
+			// The user is signed out of the previous server, so its cache goes too
+			previous.clearCache()
+		}
+	}
+	if err != nil {
+		a.start(previous)
+		return err
+	}
+
+	a.bind(ws)
+	a.start(ws)
+	a.ws = ws
+
+	a.sessionMu.Lock()
+	a.session = nil
+	a.sessionMu.Unlock()
+
+	go previous.retire()
+
+	runtime.EventsEmit(a.ctx, EventProfileChanged, profile)
+
+	return nil
+}
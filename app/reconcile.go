@@ -0,0 +1,173 @@
+package app
+
+import (
+	"changeme/internal/reconcile"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+var errReconcileUnavailable = errors.New("reconciliation store is not available")
+
+// VietQRCode is the transfer QR code of an invoice
+type VietQRCode struct {
+	Payload       string  `json:"payload"`
+	Image         string  `json:"image"`
+	Amount        float64 `json:"amount"`
+	Memo          string  `json:"memo"`
+	BankBIN       string  `json:"bank_bin"`
+	AccountNumber string  `json:"account_number"`
+	AccountName   string  `json:"account_name"`
+}
+
+// ImportBankStatement asks for a CSV or Excel statement and matches its
+// transfers to invoices. It returns nil when the user cancels the dialog.
+func (a *App) ImportBankStatement() (*reconcile.ImportResult, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.reconciler == nil {
+		return nil, errReconcileUnavailable
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Chọn sao kê ngân hàng",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Sao kê (*.csv, *.xlsx)", Pattern: "*.csv;*.xlsx"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	return ws.reconciler.Import(a.ctx, filepath.Base(path), data)
+}
+
+// ListStatementLines returns imported lines with the given status
+// (proposed, review, applied, dismissed), or all lines for an empty status
+func (a *App) ListStatementLines(status string) ([]reconcile.Line, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.reconciler == nil {
+		return []reconcile.Line{}, nil
+	}
+
+	if status == "" {
+		return ws.reconciler.Lines()
+	}
+	return ws.reconciler.Lines(reconcile.LineStatus(status))
+}
+
+// ConfirmStatementLines records the proposed payments of the given lines
+func (a *App) ConfirmStatementLines(lineIDs []string) ([]reconcile.Line, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.reconciler == nil {
+		return nil, errReconcileUnavailable
+	}
+
+	return ws.reconciler.Confirm(a.ctx, lineIDs)
+}
+
+// ResolveStatementLine pays the invoice staff picked for a line from the review queue
+func (a *App) ResolveStatementLine(lineID string, invoiceID string) (*reconcile.Line, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert invoiceID string to int
+	invoiceIDInt, err := strconv.Atoi(invoiceID)
+	if err != nil {
+		return nil, errors.New("invalid invoice ID: " + invoiceID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.reconciler == nil {
+		return nil, errReconcileUnavailable
+	}
+
+	return ws.reconciler.Resolve(a.ctx, lineID, invoiceIDInt)
+}
+
+// DismissStatementLine takes a line out of the queue without recording a payment
+func (a *App) DismissStatementLine(lineID string, reason string) (*reconcile.Line, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.reconciler == nil {
+		return nil, errReconcileUnavailable
+	}
+
+	return ws.reconciler.Dismiss(lineID, reason)
+}
+
+// GetInvoiceVietQR returns a VietQR code for the outstanding amount of an
+// invoice, with the invoice number as the transfer description
+func (a *App) GetInvoiceVietQR(invoiceID string) (*VietQRCode, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert invoiceID string to int
+	invoiceIDInt, err := strconv.Atoi(invoiceID)
+	if err != nil {
+		return nil, errors.New("invalid invoice ID: " + invoiceID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	invoice, err := ws.api.Invoice().GetInvoiceDetails(a.ctx, invoiceIDInt)
+	if err != nil {
+		return nil, err
+	}
+
+	bank := a.currentConfig().Bank
+	amount := invoice.Data.Outstanding()
+	payload, err := reconcile.VietQR(bank, amount, invoice.Data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(payload, qrcode.Medium, 512)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	return &VietQRCode{
+		Payload:       payload,
+		Image:         "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+		Amount:        amount,
+		Memo:          invoice.Data.Code,
+		BankBIN:       bank.BIN,
+		AccountNumber: bank.AccountNumber,
+		AccountName:   bank.AccountName,
+	}, nil
+}
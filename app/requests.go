@@ -0,0 +1,48 @@
+package app
+
+import "context"
+
+type trackedRequest struct {
+	cancel context.CancelFunc
+}
+
+// track returns a context for a request the UI may abort via CancelRequest.
+// Starting another request with the same id aborts the previous one, so a
+// search box can reuse one id while the user keeps typing. An empty id
+// returns the app context untracked.
+func (a *App) track(requestID string) (context.Context, func()) {
+	if requestID == "" {
+		return a.ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	req := &trackedRequest{cancel: cancel}
+
+	a.requestsMu.Lock()
+	if prev, ok := a.requests[requestID]; ok {
+		prev.cancel()
+	}
+	a.requests[requestID] = req
+	a.requestsMu.Unlock()
+
+	return ctx, func() {
+		cancel()
+
+		a.requestsMu.Lock()
+		if a.requests[requestID] == req {
+			delete(a.requests, requestID)
+		}
+		a.requestsMu.Unlock()
+	}
+}
+
+// CancelRequest aborts the in-flight request started with requestID, if any
+func (a *App) CancelRequest(requestID string) {
+	a.requestsMu.Lock()
+	defer a.requestsMu.Unlock()
+
+	if req, ok := a.requests[requestID]; ok {
+		req.cancel()
+		delete(a.requests, requestID)
+	}
+}
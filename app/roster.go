@@ -0,0 +1,112 @@
+package app
+
+import (
+	"changeme/internal/roster"
+	"changeme/internal/sheet"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventRosterProgress is emitted after each account an import creates
+const EventRosterProgress = "roster:progress"
+
+// RosterProgress is the payload of EventRosterProgress
+type RosterProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// RosterPreview is a validated roster file waiting for ImportStudents
+type RosterPreview struct {
+	Path   string         `json:"path"`
+	Report *roster.Report `json:"report"`
+}
+
+// PreviewStudentImport asks for an .xlsx or .csv roster and reports what
+// importing it would do. mapping maps User fields to header texts and may
+// be empty to detect the columns. It returns nil when the user cancels.
+func (a *App) PreviewStudentImport(mapping map[string]string) (*RosterPreview, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Chọn danh sách sinh viên",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Danh sách (*.xlsx, *.csv)", Pattern: "*.xlsx;*.csv"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	report, _, err := roster.NewImporter(ws.api, roster.DefaultConcurrency).Preview(a.ctx, filepath.Base(path), data, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RosterPreview{Path: path, Report: report}, nil
+}
+
+// ImportStudents creates the accounts of a previewed roster and returns the per-row report
+func (a *App) ImportStudents(path string, mapping map[string]string) (*roster.Report, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	importer := roster.NewImporter(ws.api, roster.DefaultConcurrency)
+	return importer.Import(a.ctx, filepath.Base(path), data, mapping, func(done, total int) {
+		runtime.EventsEmit(a.ctx, EventRosterProgress, RosterProgress{Done: done, Total: total})
+	})
+}
+
+// SaveStudentImportReport saves a report as .xlsx or .csv through the
+// save-file dialog. It returns the chosen path, or "" when cancelled.
+func (a *App) SaveStudentImportReport(report roster.Report) (string, error) {
+	if a.ctx == nil {
+		return "", context.Canceled
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: "KetQuaNhap-" + strings.TrimSuffix(report.Source, filepath.Ext(report.Source)) + ".xlsx",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Excel (*.xlsx)", Pattern: "*.xlsx"},
+			{DisplayName: "CSV (*.csv)", Pattern: "*.csv"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	header, rows := report.Table()
+	data, err := sheet.Write(path, header, rows)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to save report: %w", err)
+	}
+	return path, nil
+}
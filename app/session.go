@@ -0,0 +1,122 @@
+package app
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"changeme/internal/session"
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+)
+
+// RestoreSession signs the user back in with the refresh token saved by a
+// previous run. It returns nil when there is no stored session.
+func (a *App) RestoreSession() (*models.User, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+	if ws.sessions == nil {
+		return nil, nil
+	}
+
+	stored, err := ws.sessions.Load(ws.client.BaseURL())
+	if errors.Is(err, session.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := ws.api.Auth().TokenRefresher()(a.ctx, stored.RefreshToken)
+	if err != nil {
+		if errors.Is(err, client.ErrSessionExpired) {
+			ws.sessions.Delete(stored.BaseURL, stored.UserID)
+			return nil, nil
+		}
+		return nil, err
+	}
+	ws.client.SetToken(token.AccessToken, token.RefreshToken)
+
+	me, err := ws.api.Auth().GetMe(a.ctx)
+	if err != nil {
+		ws.client.ClearToken()
+		return nil, err
+	}
+
+	ws.client.SetCacheScope(strconv.Itoa(me.Data.ID))
+	a.saveSession(ws, &me.Data, token.RefreshToken)
+
+	return &me.Data, nil
+}
+
+// ForgetSession signs out locally and removes the stored session for the current server
+func (a *App) ForgetSession() error {
+	if a.ctx == nil {
+		return context.Canceled
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return a.clearSession(ws)
+}
+
+func (a *App) saveSession(ws *workspace, user *models.User, refreshToken string) {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	a.session = &session.Session{
+		BaseURL:      ws.client.BaseURL(),
+		UserID:       user.ID,
+		Email:        user.Email,
+		RefreshToken: refreshToken,
+	}
+
+	if ws.sessions != nil {
+		if err := ws.sessions.Save(a.session); err != nil {
+			slog.Error("failed to save session", "error", err)
+		}
+	}
+}
+
+// updateSession persists a rotated refresh token for the signed-in user
+func (a *App) updateSession(ws *workspace, refreshToken string) {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	if a.session == nil || ws.sessions == nil || a.session.BaseURL != ws.client.BaseURL() {
+		return
+	}
+
+	a.session.RefreshToken = refreshToken
+	if err := ws.sessions.Save(a.session); err != nil {
+		slog.Error("failed to save session", "error", err)
+	}
+}
+
+func (a *App) clearSession(ws *workspace) error {
+	// The token lock is taken before the session lock during a refresh, so
+	// never clear the token while holding the session lock
+	ws.client.ClearToken()
+	ws.clearCache()
+
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	current := a.session
+	a.session = nil
+
+	if ws.sessions == nil {
+		return nil
+	}
+
+	if current == nil {
+		return ws.sessions.DeleteAll(ws.client.BaseURL())
+	}
+
+	return ws.sessions.Delete(current.BaseURL, current.UserID)
+}
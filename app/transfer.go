@@ -0,0 +1,143 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"changeme/internal/transfer"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// PreviewRoomTransfer shows the contracts and invoices moving a student to
+// another room on moveDate (YYYY-MM-DD) would produce
+func (a *App) PreviewRoomTransfer(userID string, toRoomID string, moveDate string, reason string) (*transfer.Preview, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	req, err := transferRequest(userID, moveDate, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert toRoomID string to int
+	if req.ToRoomID, err = strconv.Atoi(toRoomID); err != nil {
+		return nil, errors.New("invalid room ID: " + toRoomID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return transfer.NewMover(ws.api).Preview(a.ctx, req)
+}
+
+// TransferRoom moves a student to another room on moveDate (YYYY-MM-DD)
+func (a *App) TransferRoom(userID string, toRoomID string, moveDate string, reason string) (*transfer.Result, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	req, err := transferRequest(userID, moveDate, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert toRoomID string to int
+	if req.ToRoomID, err = strconv.Atoi(toRoomID); err != nil {
+		return nil, errors.New("invalid room ID: " + toRoomID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return transfer.NewMover(ws.api).Execute(a.ctx, req)
+}
+
+// PreviewRoomSwap shows what swapping the rooms of two students on moveDate would produce
+func (a *App) PreviewRoomSwap(userID string, otherUserID string, moveDate string, reason string) (*transfer.Preview, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	req, err := transferRequest(userID, moveDate, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert otherUserID string to int
+	if req.SwapWithUserID, err = strconv.Atoi(otherUserID); err != nil {
+		return nil, errors.New("invalid user ID: " + otherUserID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return transfer.NewMover(ws.api).Preview(a.ctx, req)
+}
+
+// SwapRooms swaps the rooms of two students on moveDate (YYYY-MM-DD)
+func (a *App) SwapRooms(userID string, otherUserID string, moveDate string, reason string) (*transfer.Result, error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	req, err := transferRequest(userID, moveDate, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert otherUserID string to int
+	if req.SwapWithUserID, err = strconv.Atoi(otherUserID); err != nil {
+		return nil, errors.New("invalid user ID: " + otherUserID)
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return transfer.NewMover(ws.api).Execute(a.ctx, req)
+}
+
+func (a *App) GetListRoomTransfers(page string, userID string) (*api.Response[[]models.RoomTransfer], error) {
+	if a.ctx == nil {
+		return nil, context.Canceled
+	}
+
+	// Convert page string to int
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		return nil, errors.New("invalid page number: " + page)
+	}
+
+	var user *int
+	if userID != "" {
+		// Convert userID string to int
+		id, err := strconv.Atoi(userID)
+		if err != nil {
+			return nil, errors.New("invalid user ID: " + userID)
+		}
+		user = &id
+	}
+
+	ws, release := a.acquire()
+	defer release()
+
+	return ws.api.RoomTransfer().GetListRoomTransfers(client.AllowStale(a.ctx), pageInt, user)
+}
+
+func transferRequest(userID string, moveDate string, reason string) (transfer.Request, error) {
+	// Convert userID string to int
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		return transfer.Request{}, errors.New("invalid user ID: " + userID)
+	}
+
+	date, err := time.Parse(time.DateOnly, moveDate)
+	if err != nil {
+		return transfer.Request{}, errors.New("invalid move date: " + moveDate)
+	}
+
+	return transfer.Request{UserID: id, MoveDate: date, Reason: reason}, nil
+}
@@ -0,0 +1,245 @@
+package app
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/cache"
+	"changeme/internal/client"
+	"changeme/internal/config"
+	"changeme/internal/dunning"
+	"changeme/internal/expiry"
+	"changeme/internal/outbox"
+	"changeme/internal/reconcile"
+	"changeme/internal/session"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// workspace bundles everything bound to one server profile. Switching
+// profiles replaces the whole workspace, so sessions, cached responses and
+// queued writes never leak from one backend to another.
+type workspace struct {
+	profile    config.ProfileConfig
+	client     *client.Client
+	api        *api.API
+	sessions   *session.Store
+	cache      *cache.Store
+	operations *outbox.Store
+	replayer   *outbox.Replayer
+	dunning    *dunning.Engine
+	journal    *dunning.Journal
+	reconciler *reconcile.Reconciler
+	statements *reconcile.Store
+	expiry     *expiry.Watcher
+	reported   *expiry.Store
+	stop       context.CancelFunc
+	// jobs counts the running background jobs
+	jobs sync.WaitGroup
+	// inflight counts the bindings using the workspace
+	inflight sync.WaitGroup
+}
+
+// openWorkspace builds the client for profile and opens its stores. The stores
+// are optional: without them the user signs in on every start and needs the
+// server to be reachable.
+func openWorkspace(profile config.ProfileConfig) (*workspace, error) {
+	ws, err := newWorkspace(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	log := slog.Default().With("profile", profile.Name)
+	dir, err := config.ProfileDir(profile.Name)
+	if err != nil {
+		log.Error("failed to resolve profile dir", "error", err)
+		ws.attach()
+		return ws, nil
+	}
+
+	ws.sessions, err = session.NewStore(filepath.Join(dir, "sessions"), config.AppName)
+	if err != nil {
+		log.Error("failed to open session store", "error", err)
+	}
+
+	ws.cache, err = cache.Open(filepath.Join(dir, "cache.db"))
+	if err != nil {
+		log.Error("failed to open offline cache", "error", err)
+	}
+
+	ws.operations, err = outbox.Open(filepath.Join(dir, "outbox.db"))
+	if err != nil {
+		log.Error("failed to open offline queue", "error", err)
+	}
+
+	ws.journal, err = dunning.Open(filepath.Join(dir, "dunning.db"))
+	if err != nil {
+		log.Error("failed to open dunning journal", "error", err)
+	}
+
+	ws.statements, err = reconcile.Open(filepath.Join(dir, "reconcile.db"))
+	if err != nil {
+		log.Error("failed to open reconciliation store", "error", err)
+	}
+
+	ws.reported, err = expiry.Open(filepath.Join(dir, "expiry.db"))
+	if err != nil {
+		log.Error("failed to open contract expiry store", "error", err)
+	}
+
+	ws.attach()
+	return ws, nil
+}
+
+// reopenWorkspace builds a workspace for profile that takes over the stores
+// of previous, which belongs to the same profile. The stores hold file locks,
+// so they cannot be opened a second time while previous drains.
+func reopenWorkspace(profile config.ProfileConfig, previous *workspace) (*workspace, error) {
+	ws, err := newWorkspace(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// The user is signed out of the previous connection, so its cache goes too
+	previous.clearCache()
+	ws.sessions, previous.sessions = previous.sessions, nil
+	ws.cache, previous.cache = previous.cache, nil
+	ws.operations, previous.operations = previous.operations, nil
+	ws.journal, previous.journal = previous.journal, nil
+	ws.statements, previous.statements = previous.statements, nil
+	ws.reported, previous.reported = previous.reported, nil
+
+	ws.attach()
+	return ws, nil
+}
+
+// newWorkspace builds the client for profile, without any stores
+func newWorkspace(profile config.ProfileConfig) (*workspace, error) {
+	log := slog.Default().With("profile", profile.Name)
+
+	httpClient := client.New()
+	httpClient.SetLogger(log.With("component", "http"))
+	httpClient.SetBaseURL(profile.BaseURL)
+	httpClient.SetTimeout(time.Duration(profile.Timeout) * time.Second)
+
+	if profile.TLS.CAFile != "" || profile.TLS.InsecureSkipVerify {
+		tlsConfig, err := newTLSConfig(profile.TLS)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.SetTLSConfig(tlsConfig)
+	}
+
+	ws := &workspace{
+		profile: profile,
+		client:  httpClient,
+		api:     api.NewAPI(httpClient),
+	}
+	return ws, nil
+}
+
+// attach wires the open stores into the client and builds the engines that need them
+func (w *workspace) attach() {
+	if w.cache != nil {
+		w.client.SetCache(w.cache)
+	}
+	if w.operations != nil {
+		w.client.SetOutbox(w.operations)
+	}
+	if w.journal != nil {
+		w.dunning = dunning.NewEngine(w.api, w.journal)
+	}
+	if w.statements != nil {
+		w.reconciler = reconcile.NewReconciler(w.api, w.statements)
+	}
+	w.expiry = expiry.NewWatcher(w.api, w.reported)
+}
+
+// start runs the workspace's background jobs until the app shuts down or
+// the workspace is closed
+func (a *App) start(ws *workspace) {
+	ctx, stop := context.WithCancel(a.ctx)
+	ws.stop = stop
+
+	a.startReplayer(ctx, ws)
+	a.startDunning(ctx, ws)
+	a.startExpiryWatch(ctx, ws)
+}
+
+// clearCache stops serving cached responses and drops the stored ones, so
+// the next user signing in on this machine cannot read them
+func (w *workspace) clearCache() {
+	w.client.SetCacheScope("")
+	if w.cache == nil {
+		return
+	}
+	if err := w.cache.Clear(); err != nil {
+		slog.Error("failed to clear offline cache", "profile", w.profile.Name, "error", err)
+	}
+}
+
+// halt stops the background jobs and waits for them to exit
+func (w *workspace) halt() {
+	if w.stop != nil {
+		w.stop()
+	}
+	w.jobs.Wait()
+}
+
+// retire waits for the bindings still using a halted workspace and then
+// closes its stores
+func (w *workspace) retire() {
+	w.inflight.Wait()
+	w.close()
+}
+
+// close stops the background jobs and closes the stores
+func (w *workspace) close() {
+	if w.stop != nil {
+		w.stop()
+	}
+	if w.cache != nil {
+		w.cache.Close()
+	}
+	if w.operations != nil {
+		w.operations.Close()
+	}
+	if w.journal != nil {
+		w.journal.Close()
+	}
+	if w.statements != nil {
+		w.statements.Close()
+	}
+	if w.reported != nil {
+		w.reported.Close()
+	}
+}
+
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
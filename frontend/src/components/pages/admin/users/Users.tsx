@@ -118,7 +118,8 @@ export default function Users() {
         "",
         statusAccountFilter !== "all" ? statusAccountFilter : "",
         UserRole.STUDENT,
-        null
+        null,
+        "users:students"
       );
     },
     enabled: activeTab === UserRole.STUDENT,
@@ -135,7 +136,8 @@ export default function Users() {
         "",
         statusAccountFilter !== "all" ? statusAccountFilter : "",
         UserRole.STAFF,
-        null
+        null,
+        "users:staff"
       );
     },
     enabled: activeTab === UserRole.STAFF,
@@ -143,9 +145,7 @@ export default function Users() {
 
   const currentData = activeTab === UserRole.STUDENT ? listStudents : listStaff;
 
-  const totalPage = currentData?.ParsedBody?.total
-    ? Math.ceil(currentData.ParsedBody.total / 10)
-    : 0;
+  const totalPage = currentData?.total ? Math.ceil(currentData.total / 10) : 0;
 
   const handleResetPage = () => {
     setSearchParams((searchParams) => {
@@ -181,9 +181,9 @@ export default function Users() {
             className="flex items-center gap-2"
           >
             Sinh viên
-            {listStudents?.ParsedBody?.total && (
+            {listStudents?.total && (
               <Badge variant="secondary" className="ml-1">
-                {listStudents.ParsedBody.total}
+                {listStudents.total}
               </Badge>
             )}
           </TabsTrigger>
@@ -192,9 +192,9 @@ export default function Users() {
             className="flex items-center gap-2"
           >
             Nhân viên
-            {listStaff?.ParsedBody?.total && (
+            {listStaff?.total && (
               <Badge variant="secondary" className="ml-1">
-                {listStaff.ParsedBody.total}
+                {listStaff.total}
               </Badge>
             )}
           </TabsTrigger>
@@ -33,12 +33,12 @@ import {
   DropdownMenuItem,
   DropdownMenuTrigger,
 } from "@/components/ui/dropdown-menu";
-import { client } from "wailsjs/go/models";
+import { api } from "wailsjs/go/models";
 
 interface TabsContentStudentProps {
   searchTerm: string;
   setSearchTerm: React.Dispatch<React.SetStateAction<string>>;
-  listStaff: client.Response | undefined;
+  listStaff: api.Response___changeme_internal_models_User_ | undefined;
   totalPage: number;
   staffStatus: "error" | "success" | "pending";
   currentPage: string;
@@ -128,9 +128,8 @@ export default function TabsContentStaff({
                       Đang tải dữ liệu...
                     </TableCell>
                   </TableRow>
-                ) : listStaff?.ParsedBody.data &&
-                  listStaff?.ParsedBody.data.length > 0 ? (
-                  listStaff.ParsedBody.data.map((staff: IUser) => (
+                ) : listStaff?.data && listStaff.data.length > 0 ? (
+                  (listStaff.data as IUser[]).map((staff) => (
                     <TableRow key={staff.id}>
                       <TableCell className="font-medium">
                         {staff.student_code || staff.id}
@@ -238,7 +237,7 @@ export default function TabsContentStaff({
             {totalPage > 1 && staffStatus !== "pending" && (
               <PaginationWithLinks
                 page={parseInt(currentPage)}
-                totalCount={listStaff?.ParsedBody?.total ?? 0}
+                totalCount={listStaff?.total ?? 0}
                 pageSearchParam="page"
               />
             )}
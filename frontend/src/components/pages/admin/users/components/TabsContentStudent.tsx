@@ -16,12 +16,12 @@ import { User as IUser } from "@/interfaces/user";
 import { UserRole, UserStatus } from "@/enums/user";
 import { getGenderTextUser, getStatusTextUser } from "@/utils/getText";
 import { PaginationWithLinks } from "@/components/ui/pagination-with-links";
-import { client } from "wailsjs/go/models";
+import { api } from "wailsjs/go/models";
 
 interface TabsContentStudentProps {
   searchTerm: string;
   setSearchTerm: React.Dispatch<React.SetStateAction<string>>;
-  listStudents: client.Response | undefined;
+  listStudents: api.Response___changeme_internal_models_User_ | undefined;
   totalPage: number;
   studentsStatus: "error" | "success" | "pending";
   currentPage: string;
@@ -73,9 +73,8 @@ export default function TabsContentStudent({
                       Đang tải dữ liệu...
                     </TableCell>
                   </TableRow>
-                ) : listStudents?.ParsedBody.data &&
-                  listStudents?.ParsedBody.data.length > 0 ? (
-                  listStudents.ParsedBody.data.map((student: IUser) => (
+                ) : listStudents?.data && listStudents.data.length > 0 ? (
+                  (listStudents.data as IUser[]).map((student) => (
                     <TableRow key={student.id}>
                       <TableCell className="font-medium">
                         {student.student_code}
@@ -131,7 +130,7 @@ export default function TabsContentStudent({
             {totalPage > 1 && studentsStatus !== "pending" && (
               <PaginationWithLinks
                 page={parseInt(currentPage)}
-                totalCount={listStudents?.ParsedBody?.total ?? 0}
+                totalCount={listStudents?.total ?? 0}
                 pageSearchParam="page"
               />
             )}
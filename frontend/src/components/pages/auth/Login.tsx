@@ -44,7 +44,7 @@ export default function LoginComponent() {
   const { mutate, isPending } = useMutation({
     mutationFn: (data: LoginFormValues) => Login(data.email, data.password),
     onSuccess: (data) => {
-      const responseData = data?.ParsedBody as IResponse<ILogin>;
+      const responseData = data as IResponse<ILogin>;
 
       if (!responseData.data) {
         toast.error(responseData.message || "Đăng nhập không thành công");
@@ -63,7 +63,10 @@ export default function LoginComponent() {
       if (responseData.data?.user) {
         dispatch(setUser(responseData.data.user));
         const userRole = responseData.data.user.role as UserRole;
-        SetToken(responseData.data.access_token || "");
+        SetToken(
+          responseData.data.access_token || "",
+          responseData.data.refresh_token || ""
+        );
         const redirectPath = MAP_ROLE_TO_PATH[userRole];
 
         if (redirectPath) {
@@ -75,16 +75,14 @@ export default function RegisterComponent() {
     mutationFn: (values: RegisterData) =>
       Register(values.email, values.password, values.fullName, values.phone),
     onSuccess: (data) => {
-      if (data.ParsedBody?.data) {
-        // Lưu email để hiển thị trong dialog
-        setUserEmail(data.ParsedBody.data.email || "");
-        setShowEmailVerificationDialog(true);
-      } else {
-        toast.error(data.ParsedBody?.message || "Đăng ký thất bại");
-      }
+      // Lưu email để hiển thị trong dialog
+      setUserEmail(data.data.email || "");
+      setShowEmailVerificationDialog(true);
     },
-    onError: () => {
-      toast.error("Có lỗi xảy ra khi đăng ký. Vui lòng thử lại.");
+    onError: (error) => {
+      toast.error(
+        error.message || "Có lỗi xảy ra khi đăng ký. Vui lòng thử lại."
+      );
     },
   });
 
@@ -13,6 +13,7 @@ import AddAmenityDialog from "./components/AddAmenityDialog";
 import { useQuery } from "@tanstack/react-query";
 import { Icons } from "@/components/ui/icons";
 import { GetListAmenities } from "wailsjs/go/app/App";
+import { Amenity } from "@/interfaces/amenity";
 
 export default function Amenities() {
   const [openAddDialog, setOpenAddDialog] = useState(false);
@@ -53,7 +54,7 @@ export default function Amenities() {
           </CardDescription>
         </CardHeader>
         <CardContent>
-          <AmenitiesList amenities={amenities?.ParsedBody.data} />
+          <AmenitiesList amenities={amenities?.data as Amenity[] | undefined} />
         </CardContent>
       </Card>
 
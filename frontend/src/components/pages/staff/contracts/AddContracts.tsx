@@ -117,14 +117,15 @@ export default function AddContracts() {
         "",
         "",
         UserRole.STUDENT,
-        null
+        null,
+        ""
       ),
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const hasMore = lastPage?.ParsedBody.data.length >= 10;
+      const hasMore = lastPage.data.length >= 10;
       const nextPage = hasMore ? allPages.length + 1 : undefined;
       console.log(`📄 Students getNextPageParam:`, {
-        currentItems: lastPage?.ParsedBody.data.length,
+        currentItems: lastPage.data.length,
         totalPages: allPages.length,
         nextPage,
       });
@@ -143,13 +144,14 @@ export default function AddContracts() {
     isError: isRoomsError,
   } = useInfiniteQuery({
     queryKey: ["rooms-infinite"],
-    queryFn: ({ pageParam = 1 }) => GetListRooms(String(pageParam as number)),
+    queryFn: ({ pageParam = 1 }) =>
+      GetListRooms(String(pageParam as number), ""),
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const hasMore = lastPage?.ParsedBody.data.length >= 10;
+      const hasMore = lastPage.data.length >= 10;
       const nextPage = hasMore ? allPages.length + 1 : undefined;
       console.log(`📄 Rooms getNextPageParam:`, {
-        currentItems: lastPage?.ParsedBody.data.length,
+        currentItems: lastPage.data.length,
         totalPages: allPages.length,
         nextPage,
       });
@@ -177,12 +179,12 @@ export default function AddContracts() {
   });
 
   const allStudents = useMemo(
-    () => studentsData?.pages.flatMap((page) => page?.ParsedBody.data) ?? [],
+    () => studentsData?.pages.flatMap((page) => page.data as IUser[]) ?? [],
     [studentsData]
   );
 
   const allRooms = useMemo(
-    () => roomsData?.pages.flatMap((page) => page?.ParsedBody.data) ?? [],
+    () => roomsData?.pages.flatMap((page) => page.data as Room[]) ?? [],
     [roomsData]
   );
 
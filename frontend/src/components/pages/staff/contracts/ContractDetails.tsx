@@ -69,7 +69,7 @@ export default function ContractDetails() {
     enabled: !!id,
   });
 
-  const contract: Contract | null = contractResponse?.ParsedBody?.data || null;
+  const contract = (contractResponse?.data as Contract | undefined) || null;
 
   if (isLoading) {
     return (
@@ -54,13 +54,16 @@ export default function Contracts() {
     ],
     queryFn: (query) => {
       const [, params] = query.queryKey as [string, ContractQueryParams];
-      return GetListContracts(String(params?.page || 1), params.keyword);
+      return GetListContracts(
+        String(params?.page || 1),
+        params.keyword,
+        "contracts"
+      );
     },
   });
 
-  const totalPage = data?.ParsedBody.total
-    ? Math.ceil(data.ParsedBody.total / 10)
-    : 0;
+  const contracts = (data?.data ?? []) as Contract[];
+  const totalPage = data?.total ? Math.ceil(data.total / 10) : 0;
 
   const handleResetPage = () => {
     setSearchParams((searchParams) => {
@@ -96,23 +99,23 @@ export default function Contracts() {
         <CardHeader>
           <CardTitle>Danh sách hợp đồng</CardTitle>
           <CardDescription>
-            Tổng số {data?.ParsedBody.data.length} hợp đồng,{" "}
+            Tổng số {contracts.length} hợp đồng,{" "}
             {
-              data?.ParsedBody.data.filter(
+              contracts.filter(
                 (c: { status: ContractStatus }) =>
                   c.status === ContractStatus.ACTIVE
               ).length
             }{" "}
             đang hiệu lực,{" "}
             {
-              data?.ParsedBody.data.filter(
+              contracts.filter(
                 (c: { status: ContractStatus }) =>
                   c.status === ContractStatus.INACTIVE
               ).length
             }{" "}
             đã hết hạn,{" "}
             {
-              data?.ParsedBody.data.filter(
+              contracts.filter(
                 (c: { status: ContractStatus }) =>
                   c.status === ContractStatus.CANCELLED
               ).length
@@ -175,8 +178,8 @@ export default function Contracts() {
                 </TableRow>
               </TableHeader>
               <TableBody>
-                {data?.ParsedBody.data && data?.ParsedBody.data.length > 0 ? (
-                  data.ParsedBody.data.map((contract: Contract) => (
+                {contracts.length > 0 ? (
+                  contracts.map((contract) => (
                     <TableRow key={contract.id}>
                       <TableCell className="font-medium">
                         {contract.code}
@@ -250,7 +253,7 @@ export default function Contracts() {
             {totalPage > 1 && status !== "pending" && (
               <PaginationWithLinks
                 page={+currentPage}
-                totalCount={data?.ParsedBody.total ?? 0}
+                totalCount={data?.total ?? 0}
                 pageSearchParam="page"
               />
             )}
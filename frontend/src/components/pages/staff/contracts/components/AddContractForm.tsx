@@ -101,15 +101,16 @@ export function AddContractForm({ onOpenChange }: AddContractFormProps) {
         "",
         "",
         UserRole.STUDENT,
-        null
+        null,
+        ""
       );
     },
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const hasMore = lastPage?.ParsedBody.data.length >= 10;
+      const hasMore = lastPage.data.length >= 10;
       const nextPage = hasMore ? allPages.length + 1 : undefined;
       console.log(`📄 Students getNextPageParam:`, {
-        currentItems: lastPage?.ParsedBody.data.length,
+        currentItems: lastPage.data.length,
         totalPages: allPages.length,
         nextPage,
       });
@@ -130,14 +131,14 @@ export function AddContractForm({ onOpenChange }: AddContractFormProps) {
     queryKey: ["rooms-infinite"],
     queryFn: ({ pageParam = 1 }) => {
       console.log(`🏠 Fetching rooms page: ${pageParam}`);
-      return GetListRooms(String(pageParam as number));
+      return GetListRooms(String(pageParam as number), "");
     },
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const hasMore = lastPage?.ParsedBody.data.length >= 10;
+      const hasMore = lastPage.data.length >= 10;
       const nextPage = hasMore ? allPages.length + 1 : undefined;
       console.log(`📄 Rooms getNextPageParam:`, {
-        currentItems: lastPage?.ParsedBody.data.length,
+        currentItems: lastPage.data.length,
         totalPages: allPages.length,
         nextPage,
       });
@@ -165,12 +166,12 @@ export function AddContractForm({ onOpenChange }: AddContractFormProps) {
   });
 
   const allStudents = useMemo(
-    () => studentsData?.pages.flatMap((page) => page?.ParsedBody.data) ?? [],
+    () => studentsData?.pages.flatMap((page) => page.data as User[]) ?? [],
     [studentsData]
   );
 
   const allRooms = useMemo(
-    () => roomsData?.pages.flatMap((page) => page?.ParsedBody.data) ?? [],
+    () => roomsData?.pages.flatMap((page) => page.data as Room[]) ?? [],
     [roomsData]
   );
 
@@ -17,6 +17,8 @@ import {
   GetListRoomCategories,
   GetRoomDetails,
 } from "wailsjs/go/app/App";
+import { Amenity } from "@/interfaces/amenity";
+import { Room, RoomCategory } from "@/interfaces/room";
 
 export default function EditRoom() {
   const { id } = useParams<{ id: string }>();
@@ -33,7 +35,7 @@ export default function EditRoom() {
       queryFn: () => GetListRoomCategories("1"),
     });
 
-  const { data: room, isLoading } = useQuery({
+  const { data: room, isLoading, error } = useQuery({
     queryKey: ["room", id],
     queryFn: () => GetRoomDetails(id || "0"),
   });
@@ -47,13 +49,13 @@ export default function EditRoom() {
     );
   }
 
-  if (!room?.ParsedBody?.data) {
+  if (!room?.data) {
     return (
       <div className="max-w-4xl mx-auto my-6">
         <Alert variant="destructive">
           <AlertCircle className="h-4 w-4" />
           <AlertTitle>Lỗi</AlertTitle>
-          <AlertDescription>{room?.ParsedBody?.message}</AlertDescription>
+          <AlertDescription>{error?.message}</AlertDescription>
         </Alert>
         <div className="mt-4">
           <Button onClick={() => navigate("/staff/rooms")}>
@@ -77,7 +79,7 @@ export default function EditRoom() {
         <div>
           <h2 className="text-3xl font-bold tracking-tight">Chỉnh sửa phòng</h2>
           <p className="text-muted-foreground">
-            Cập nhật thông tin phòng {room?.ParsedBody?.data.room_number}
+            Cập nhật thông tin phòng {room.data.room_number}
           </p>
         </div>
       </div>
@@ -90,16 +92,14 @@ export default function EditRoom() {
           </CardDescription>
         </CardHeader>
         <CardContent>
-          {room?.ParsedBody.data &&
-            amenities?.ParsedBody.data &&
-            listRoomCategories?.ParsedBody.data && (
-              <EditRoomForm
-                amenities={amenities?.ParsedBody.data || []}
-                listRoomCategories={listRoomCategories?.ParsedBody.data || []}
-                id={id ? +id : 0}
-                room={room.ParsedBody.data}
-              />
-            )}
+          {amenities?.data && listRoomCategories?.data && (
+            <EditRoomForm
+              amenities={amenities.data as Amenity[]}
+              listRoomCategories={listRoomCategories.data as RoomCategory[]}
+              id={id ? +id : 0}
+              room={room.data as Room}
+            />
+          )}
         </CardContent>
       </Card>
     </div>
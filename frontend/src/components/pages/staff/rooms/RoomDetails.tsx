@@ -9,6 +9,7 @@ import { MaintenanceTable } from "./components/MaintenanceTable";
 import { useQuery } from "@tanstack/react-query";
 import { Icons } from "@/components/ui/icons";
 import { GetRoomDetails } from "wailsjs/go/app/App";
+import { Room } from "@/interfaces/room";
 
 export default function RoomDetails() {
   const { id } = useParams<{ id: string }>();
@@ -51,13 +52,13 @@ export default function RoomDetails() {
   return (
     <div className="space-y-6">
       <RoomHeader
-        room={room?.ParsedBody.data}
+        room={room.data as Room}
         onEdit={handleEditRoom}
         id={id ? +id : 0}
       />
 
       <div className="grid gap-6 md:grid-cols-6">
-        <RoomInfoCard room={room?.ParsedBody.data} />
+        <RoomInfoCard room={room.data as Room} />
 
         <Card className="md:col-span-4">
           <Tabs defaultValue="occupants">
@@ -72,13 +73,13 @@ export default function RoomDetails() {
             </CardHeader>
             <CardContent>
               <OccupantsTable
-                room={room?.ParsedBody.data}
+                room={room.data as Room}
                 openStudentDialog={openStudentDialog}
                 setOpenStudentDialog={setOpenStudentDialog}
               />
 
               <MaintenanceTable
-                room={room?.ParsedBody.data}
+                room={room.data as Room}
                 openMaintenanceDialog={openMaintenanceDialog}
                 setOpenMaintenanceDialog={setOpenMaintenanceDialog}
               />
@@ -30,6 +30,7 @@ import { useQuery } from "@tanstack/react-query";
 import { Icons } from "@/components/ui/icons";
 import { RoomStatus } from "@/enums/rooms";
 import { GetListRooms } from "wailsjs/go/app/App";
+import { Room } from "@/interfaces/room";
 
 const getStatusText = (status: string) => {
   switch (status) {
@@ -64,8 +65,9 @@ export default function Rooms() {
 
   const { data: listRoom, isLoading } = useQuery({
     queryKey: ["rooms"],
-    queryFn: () => GetListRooms("1"),
+    queryFn: () => GetListRooms("1", ""),
   });
+  const rooms = (listRoom?.data ?? []) as Room[];
 
   return (
     <div className="space-y-6">
@@ -86,21 +88,21 @@ export default function Rooms() {
         <CardHeader>
           <CardTitle>Danh sách phòng</CardTitle>
           <CardDescription>
-            Tổng cộng {listRoom?.ParsedBody?.data.length} phòng,{" "}
+            Tổng cộng {rooms.length} phòng,{" "}
             {
-              listRoom?.ParsedBody?.data.filter(
+              rooms.filter(
                 (r: { status: string }) => r.status === "occupied"
               ).length
             }{" "}
             đã thuê,{" "}
             {
-              listRoom?.ParsedBody?.data.filter(
+              rooms.filter(
                 (r: { status: string }) => r.status === RoomStatus.AVAILABLE
               ).length
             }{" "}
             trống,{" "}
             {
-              listRoom?.ParsedBody?.data.filter(
+              rooms.filter(
                 (r: { status: string }) => r.status === "maintenance"
               ).length
             }{" "}
@@ -154,8 +156,8 @@ export default function Rooms() {
                   </TableRow>
                 </TableHeader>
                 <TableBody>
-                  {listRoom && listRoom?.ParsedBody?.data.length > 0 ? (
-                    listRoom?.ParsedBody?.data.map(
+                  {rooms.length > 0 ? (
+                    rooms.map(
                       (room: {
                         id: number;
                         room_number: string;
@@ -80,14 +80,15 @@ export function AddStudentForm({ setOpenStudentDialog }: AddStudentFormProps) {
         "",
         "",
         UserRole.STUDENT,
-        false
+        false,
+        ""
       ),
     initialPageParam: 1,
     getNextPageParam: (lastPage, allPages) => {
-      const hasMore = lastPage?.ParsedBody.data.length >= 10;
+      const hasMore = lastPage.data.length >= 10;
       const nextPage = hasMore ? allPages.length + 1 : undefined;
       console.log(`📄 Students getNextPageParam:`, {
-        currentItems: lastPage?.ParsedBody.data.length,
+        currentItems: lastPage.data.length,
         totalPages: allPages.length,
         nextPage,
       });
@@ -118,7 +119,7 @@ export function AddStudentForm({ setOpenStudentDialog }: AddStudentFormProps) {
     },
   });
   const allStudents = useMemo(
-    () => studentsData?.pages.flatMap((page) => page?.ParsedBody.data) ?? [],
+    () => studentsData?.pages.flatMap((page) => page.data as IUser[]) ?? [],
     [studentsData]
   );
 
@@ -21,6 +21,7 @@ import { StudentProfile } from "./components/StudentProfile";
 import { useQuery } from "@tanstack/react-query";
 import { Icons } from "@/components/ui/icons";
 import { GetUserDetails } from "wailsjs/go/app/App";
+import { User } from "@/interfaces/user";
 
 export default function StudentDetails() {
   const { id } = useParams<{ id: string }>();
@@ -72,17 +73,17 @@ export default function StudentDetails() {
           </Link>
           <div>
             <h2 className="text-3xl font-bold tracking-tight">
-              {student?.ParsedBody.data.full_name}
+              {student.data.full_name}
             </h2>
             <p className="text-muted-foreground">
-              MSSV: {student?.ParsedBody.data.student_code}
+              MSSV: {student.data.student_code}
             </p>
           </div>
         </div>
       </div>
 
       <div className="grid gap-6 md:grid-cols-6">
-        <StudentProfile student={student?.ParsedBody.data} />
+        <StudentProfile student={student.data as User} />
 
         <Card className="md:col-span-4">
           <Tabs defaultValue="contracts">
@@ -62,13 +62,13 @@ export default function Students() {
         params.gender || "",
         "",
         UserRole.STUDENT,
-        null
+        null,
+        "students"
       );
     },
   });
-  const totalPage = listStudent?.ParsedBody.total
-    ? Math.ceil(listStudent.ParsedBody.total / 10)
-    : 0;
+  const students = (listStudent?.data ?? []) as IUser[];
+  const totalPage = listStudent?.total ? Math.ceil(listStudent.total / 10) : 0;
 
   const handleResetPage = () => {
     setSearchParams((searchParams) => {
@@ -94,21 +94,21 @@ export default function Students() {
         <CardHeader>
           <CardTitle>Danh sách sinh viên</CardTitle>
           <CardDescription>
-            Tổng số {listStudent?.ParsedBody.data.length} sinh viên,{" "}
+            Tổng số {students.length} sinh viên,{" "}
             {
-              listStudent?.ParsedBody.data.filter(
+              students.filter(
                 (s: { status: UserStatus }) => s.status === UserStatus.ACTIVE
               ).length
             }{" "}
             đang ở,{" "}
             {
-              listStudent?.ParsedBody.data.filter(
+              students.filter(
                 (s: { status: UserStatus }) => s.status === UserStatus.INACTIVE
               ).length
             }{" "}
             tạm vắng,{" "}
             {
-              listStudent?.ParsedBody.data.filter(
+              students.filter(
                 (s: { status: UserStatus }) => s.status === UserStatus.ABSENT
               ).length
             }{" "}
@@ -188,9 +188,8 @@ export default function Students() {
                       Đang tải dữ liệu...
                     </TableCell>
                   </TableRow>
-                ) : listStudent?.ParsedBody.data &&
-                  listStudent?.ParsedBody.data.length > 0 ? (
-                  listStudent.ParsedBody.data.map((student: IUser) => (
+                ) : students.length > 0 ? (
+                  students.map((student) => (
                     <TableRow key={student.id}>
                       <TableCell className="font-medium">
                         {student.student_code}
@@ -257,7 +256,7 @@ export default function Students() {
             {totalPage > 1 && status !== "pending" && (
               <PaginationWithLinks
                 page={+currentPage}
-                totalCount={listStudent?.ParsedBody.total ?? 0}
+                totalCount={listStudent?.total ?? 0}
                 pageSearchParam="page"
               />
             )}
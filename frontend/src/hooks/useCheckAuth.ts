@@ -36,21 +36,21 @@ export function useCheckAuth() {
       return;
     }
 
-    if (currentUser?.ParsedBody?.data) {
-      if (currentUser && currentUser.ParsedBody?.data) {
-        dispatch(setUser(currentUser.ParsedBody?.data));
+    if (currentUser?.data) {
+      if (currentUser && currentUser.data) {
+        dispatch(setUser(currentUser.data as User));
         if (PUBLIC_ROUTES.includes(pathname)) {
           navigate(
-            MAP_ROLE_TO_PATH[currentUser.ParsedBody?.data?.role as UserRole]
+            MAP_ROLE_TO_PATH[currentUser.data.role as UserRole]
           );
         } else if (
-          currentUser.ParsedBody?.data.role === UserRole.ADMIN &&
+          currentUser.data.role === UserRole.ADMIN &&
           !pathname.startsWith("/admin")
         ) {
           navigate("/admin");
           return;
         } else if (
-          currentUser.ParsedBody?.data.role === UserRole.STAFF &&
+          currentUser.data.role === UserRole.STAFF &&
           !pathname.startsWith("/staff")
         ) {
           navigate("/staff");
@@ -68,7 +68,7 @@ export function useCheckAuth() {
 
   return {
     isLoading: isLoading,
-    user: currentUser?.ParsedBody?.data as User,
-    isAuthenticated: !!currentUser?.ParsedBody?.data,
+    user: currentUser?.data as User,
+    isAuthenticated: !!currentUser?.data,
   };
 }
@@ -1,73 +1,222 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {client} from '../models';
+import {config} from '../models';
+import {api} from '../models';
+import {allocation} from '../models';
+import {audit} from '../models';
+import {metering} from '../models';
+import {checkout} from '../models';
+import {reconcile} from '../models';
+import {deposit} from '../models';
+import {export} from '../models';
+import {app} from '../models';
+import {billing} from '../models';
+import {expiry} from '../models';
+import {roster} from '../models';
+import {dunning} from '../models';
+import {outbox} from '../models';
+import {lease} from '../models';
+import {transfer} from '../models';
+import {models} from '../models';
 
-export function AddStudentToRoom(arg1:string,arg2:string):Promise<client.Response>;
+export function AddProfile(arg1:config.ProfileConfig):Promise<void>;
 
-export function CreateAmenity(arg1:Record<string, any>):Promise<client.Response>;
+export function AddStudentToRoom(arg1:string,arg2:string):Promise<api.Response_interface____>;
 
-export function CreateContract(arg1:Record<string, any>):Promise<client.Response>;
+export function ApplyAllocation(arg1:Array<allocation.Assignment>):Promise<allocation.ApplyResult>;
 
-export function CreateMaintenanceHistory(arg1:Record<string, any>):Promise<client.Response>;
+export function ApplyAuditFixes(arg1:Array<audit.Fix>):Promise<Array<audit.Outcome>>;
 
-export function CreateRoom(arg1:Record<string, any>):Promise<client.Response>;
+export function BillUtilityCharges(arg1:string):Promise<metering.Result>;
 
-export function CreateRoomCategory(arg1:Record<string, any>):Promise<client.Response>;
+export function CancelContract(arg1:string,arg2:string):Promise<api.Response_changeme_internal_models_Contract_>;
 
-export function DeleteAmenity(arg1:string):Promise<client.Response>;
+export function CancelRequest(arg1:string):Promise<void>;
 
-export function DeleteMaintenanceHistory(arg1:string):Promise<client.Response>;
+export function CheckAllocation(arg1:Array<allocation.Assignment>):Promise<Array<allocation.Issue>>;
 
-export function DeleteRoom(arg1:string):Promise<client.Response>;
+export function CheckOutStudent(arg1:string,arg2:string,arg3:Array<checkout.Item>,arg4:boolean,arg5:boolean,arg6:string):Promise<checkout.Result>;
 
-export function GetAmenityDetails(arg1:string):Promise<client.Response>;
+export function CloseContract(arg1:string):Promise<api.Response_changeme_internal_models_Contract_>;
 
-export function GetContractDetails(arg1:string):Promise<client.Response>;
+export function ConfirmStatementLines(arg1:Array<string>):Promise<Array<reconcile.Line>>;
 
-export function GetListAmenities(arg1:string):Promise<client.Response>;
+export function CreateAmenity(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_Amenity_>;
 
-export function GetListContracts(arg1:string,arg2:any):Promise<client.Response>;
+export function CreateContract(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_Contract_>;
 
-export function GetListMaintenanceHistories(arg1:string,arg2:string):Promise<client.Response>;
+export function CreateInvoice(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_Invoice_>;
 
-export function GetListRoomCategories(arg1:string):Promise<client.Response>;
+export function CreateMaintenanceHistory(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_MaintenanceHistory_>;
 
-export function GetListRooms(arg1:string):Promise<client.Response>;
+export function CreateMeterReading(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_MeterReading_>;
 
-export function GetListUsers(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:any):Promise<client.Response>;
+export function CreateRoom(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_Room_>;
 
-export function GetMaintenanceHistoryDetails(arg1:string):Promise<client.Response>;
+export function CreateRoomCategory(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_RoomCategory_>;
 
-export function GetMe():Promise<client.Response>;
+export function DeductFromDeposit(arg1:string,arg2:string,arg3:number,arg4:string):Promise<deposit.Statement>;
 
-export function GetRoomCategoryDetails(arg1:string):Promise<client.Response>;
+export function DeleteAmenity(arg1:string):Promise<api.Response_interface____>;
 
-export function GetRoomDetails(arg1:string):Promise<client.Response>;
+export function DeleteMaintenanceHistory(arg1:string):Promise<api.Response_interface____>;
 
-export function GetUserDetails(arg1:string):Promise<client.Response>;
+export function DeleteRoom(arg1:string):Promise<api.Response_interface____>;
 
-export function LogData(arg1:any,arg2:Array<any>):Promise<void>;
+export function DiscardOperation(arg1:string):Promise<void>;
 
-export function Login(arg1:string,arg2:string):Promise<client.Response>;
+export function DismissStatementLine(arg1:string,arg2:string):Promise<reconcile.Line>;
 
-export function Logout():Promise<client.Response>;
+export function ExportList(arg1:string,arg2:export.Filter,arg3:string):Promise<app.ExportResult>;
 
-export function Register(arg1:string,arg2:string,arg3:string,arg4:string):Promise<client.Response>;
+export function ForgetSession():Promise<void>;
 
-export function ResendVerifyAccount(arg1:string):Promise<client.Response>;
+export function GenerateInvoices(arg1:string):Promise<billing.Result>;
 
-export function ResetPassword(arg1:Record<string, any>):Promise<client.Response>;
+export function GetAmenityDetails(arg1:string):Promise<api.Response_changeme_internal_models_Amenity_>;
 
-export function SendForgotPasswordEmail(arg1:string):Promise<client.Response>;
+export function GetCheckoutChecklist(arg1:string,arg2:string):Promise<checkout.Checklist>;
 
-export function SetToken(arg1:string):Promise<void>;
+export function GetConfig():Promise<config.Config>;
 
-export function UpdateAmenity(arg1:string,arg2:Record<string, any>):Promise<client.Response>;
+export function GetContractDetails(arg1:string):Promise<api.Response_changeme_internal_models_Contract_>;
 
-export function UpdateMaintenanceHistory(arg1:string,arg2:Record<string, any>):Promise<client.Response>;
+export function GetDepositStatement(arg1:string):Promise<deposit.Statement>;
 
-export function UpdateRoom(arg1:string,arg2:Record<string, any>):Promise<client.Response>;
+export function GetInvoiceDetails(arg1:string):Promise<api.Response_changeme_internal_models_Invoice_>;
 
-export function UpdateUserStatus(arg1:string,arg2:string):Promise<client.Response>;
+export function GetInvoiceVietQR(arg1:string):Promise<app.VietQRCode>;
 
-export function VerifyAccount(arg1:string,arg2:string):Promise<client.Response>;
+export function GetListAmenities(arg1:string):Promise<api.Response___changeme_internal_models_Amenity_>;
+
+export function GetListContracts(arg1:string,arg2:any,arg3:string):Promise<api.Response___changeme_internal_models_Contract_>;
+
+export function GetListInvoices(arg1:api.InvoiceQuery,arg2:string):Promise<api.Response___changeme_internal_models_Invoice_>;
+
+export function GetListMaintenanceHistories(arg1:string,arg2:string):Promise<api.Response___changeme_internal_models_MaintenanceHistory_>;
+
+export function GetListMeterReadings(arg1:string,arg2:string,arg3:string):Promise<api.Response___changeme_internal_models_MeterReading_>;
+
+export function GetListRoomCategories(arg1:string):Promise<api.Response___changeme_internal_models_RoomCategory_>;
+
+export function GetListRoomTransfers(arg1:string,arg2:string):Promise<api.Response___changeme_internal_models_RoomTransfer_>;
+
+export function GetListRooms(arg1:string,arg2:string):Promise<api.Response___changeme_internal_models_Room_>;
+
+export function GetListUsers(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:any,arg9:string):Promise<api.Response___changeme_internal_models_User_>;
+
+export function GetMaintenanceHistoryDetails(arg1:string):Promise<api.Response_changeme_internal_models_MaintenanceHistory_>;
+
+export function GetMe():Promise<api.Response_changeme_internal_models_User_>;
+
+export function GetPaymentDetails(arg1:string):Promise<api.Response_changeme_internal_models_Payment_>;
+
+export function GetRenewalsDue():Promise<expiry.Renewals>;
+
+export function GetRoomCategoryDetails(arg1:string):Promise<api.Response_changeme_internal_models_RoomCategory_>;
+
+export function GetRoomDetails(arg1:string):Promise<api.Response_changeme_internal_models_Room_>;
+
+export function GetStudentPayments(arg1:string,arg2:string):Promise<api.Response___changeme_internal_models_Payment_>;
+
+export function GetUserDetails(arg1:string):Promise<api.Response_changeme_internal_models_User_>;
+
+export function ImportBankStatement():Promise<reconcile.ImportResult>;
+
+export function ImportStudents(arg1:string,arg2:Record<string, string>):Promise<roster.Report>;
+
+export function ListDunningActions():Promise<Array<dunning.Action>>;
+
+export function ListPendingOperations():Promise<Array<outbox.Operation>>;
+
+export function ListProfiles():Promise<Array<app.ProfileInfo>>;
+
+export function ListStatementLines(arg1:string):Promise<Array<reconcile.Line>>;
+
+export function LogData(arg1:string,arg2:string,arg3:Record<string, any>):Promise<void>;
+
+export function Login(arg1:string,arg2:string):Promise<api.Response_changeme_internal_models_Login_>;
+
+export function Logout():Promise<api.Response_interface____>;
+
+export function PreviewContractTermination(arg1:string,arg2:string):Promise<lease.Termination>;
+
+export function PreviewInvoices(arg1:string):Promise<billing.Plan>;
+
+export function PreviewRoomSwap(arg1:string,arg2:string,arg3:string,arg4:string):Promise<transfer.Preview>;
+
+export function PreviewRoomTransfer(arg1:string,arg2:string,arg3:string,arg4:string):Promise<transfer.Preview>;
+
+export function PreviewStudentImport(arg1:Record<string, string>):Promise<app.RosterPreview>;
+
+export function PreviewUtilityCharges(arg1:string):Promise<metering.Statement>;
+
+export function ProposeAllocation(arg1:Array<allocation.Preference>,arg2:string):Promise<allocation.Plan>;
+
+export function RecordDepositReceived(arg1:string,arg2:number,arg3:string):Promise<deposit.Statement>;
+
+export function RecordPayment(arg1:Record<string, any>):Promise<api.Response_changeme_internal_models_Payment_>;
+
+export function RefundDeposit(arg1:string,arg2:number,arg3:string):Promise<deposit.Statement>;
+
+export function Register(arg1:string,arg2:string,arg3:string,arg4:string):Promise<api.Response_changeme_internal_models_User_>;
+
+export function RemoveProfile(arg1:string):Promise<void>;
+
+export function RenewContract(arg1:string,arg2:string,arg3:number,arg4:boolean):Promise<api.Response_changeme_internal_models_Contract_>;
+
+export function ReplayPendingOperations():Promise<void>;
+
+export function ResendVerifyAccount(arg1:string):Promise<api.Response_interface____>;
+
+export function ResetPassword(arg1:Record<string, any>):Promise<api.Response_interface____>;
+
+export function ResolveStatementLine(arg1:string,arg2:string):Promise<reconcile.Line>;
+
+export function RestoreSession():Promise<models.User>;
+
+export function ReverseDunningAction(arg1:string):Promise<dunning.Action>;
+
+export function RunDunning():Promise<dunning.Report>;
+
+export function RunOccupancyAudit(arg1:string):Promise<audit.Report>;
+
+export function SaveContractPDF(arg1:string):Promise<string>;
+
+export function SaveInvoicePDF(arg1:string):Promise<string>;
+
+export function SaveReceiptPDF(arg1:string):Promise<string>;
+
+export function SaveStudentImportReport(arg1:roster.Report):Promise<string>;
+
+export function SendForgotPasswordEmail(arg1:string):Promise<api.Response_interface____>;
+
+export function SetToken(arg1:string,arg2:string):Promise<void>;
+
+export function SettleDeposit(arg1:string):Promise<deposit.Statement>;
+
+export function SwapRooms(arg1:string,arg2:string,arg3:string,arg4:string):Promise<transfer.Result>;
+
+export function SwitchProfile(arg1:string):Promise<void>;
+
+export function TerminateContract(arg1:string,arg2:string,arg3:string,arg4:any):Promise<lease.TerminationResult>;
+
+export function TransferRoom(arg1:string,arg2:string,arg3:string,arg4:string):Promise<transfer.Result>;
+
+export function UpdateAmenity(arg1:string,arg2:Record<string, any>):Promise<api.Response_changeme_internal_models_Amenity_>;
+
+export function UpdateConfig(arg1:config.Config):Promise<config.Config>;
+
+export function UpdateContract(arg1:string,arg2:Record<string, any>):Promise<api.Response_changeme_internal_models_Contract_>;
+
+export function UpdateMaintenanceHistory(arg1:string,arg2:Record<string, any>):Promise<api.Response_changeme_internal_models_MaintenanceHistory_>;
+
+export function UpdateMeterReading(arg1:string,arg2:Record<string, any>):Promise<api.Response_changeme_internal_models_MeterReading_>;
+
+export function UpdateRoom(arg1:string,arg2:Record<string, any>):Promise<api.Response_changeme_internal_models_Room_>;
+
+export function UpdateUserStatus(arg1:string,arg2:string):Promise<api.Response_interface____>;
+
+export function VerifyAccount(arg1:string,arg2:string):Promise<api.Response_interface____>;
+
+export function VoidInvoice(arg1:string,arg2:string):Promise<api.Response_changeme_internal_models_Invoice_>;
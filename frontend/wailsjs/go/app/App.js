@@ -2,10 +2,50 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddProfile(arg1) {
+  return window['go']['app']['App']['AddProfile'](arg1);
+}
+
 export function AddStudentToRoom(arg1, arg2) {
   return window['go']['app']['App']['AddStudentToRoom'](arg1, arg2);
 }
 
+export function ApplyAllocation(arg1) {
+  return window['go']['app']['App']['ApplyAllocation'](arg1);
+}
+
+export function ApplyAuditFixes(arg1) {
+  return window['go']['app']['App']['ApplyAuditFixes'](arg1);
+}
+
+export function BillUtilityCharges(arg1) {
+  return window['go']['app']['App']['BillUtilityCharges'](arg1);
+}
+
+export function CancelContract(arg1, arg2) {
+  return window['go']['app']['App']['CancelContract'](arg1, arg2);
+}
+
+export function CancelRequest(arg1) {
+  return window['go']['app']['App']['CancelRequest'](arg1);
+}
+
+export function CheckAllocation(arg1) {
+  return window['go']['app']['App']['CheckAllocation'](arg1);
+}
+
+export function CheckOutStudent(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['app']['App']['CheckOutStudent'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function CloseContract(arg1) {
+  return window['go']['app']['App']['CloseContract'](arg1);
+}
+
+export function ConfirmStatementLines(arg1) {
+  return window['go']['app']['App']['ConfirmStatementLines'](arg1);
+}
+
 export function CreateAmenity(arg1) {
   return window['go']['app']['App']['CreateAmenity'](arg1);
 }
@@ -14,10 +54,18 @@ export function CreateContract(arg1) {
   return window['go']['app']['App']['CreateContract'](arg1);
 }
 
+export function CreateInvoice(arg1) {
+  return window['go']['app']['App']['CreateInvoice'](arg1);
+}
+
 export function CreateMaintenanceHistory(arg1) {
   return window['go']['app']['App']['CreateMaintenanceHistory'](arg1);
 }
 
+export function CreateMeterReading(arg1) {
+  return window['go']['app']['App']['CreateMeterReading'](arg1);
+}
+
 export function CreateRoom(arg1) {
   return window['go']['app']['App']['CreateRoom'](arg1);
 }
@@ -26,6 +74,10 @@ export function CreateRoomCategory(arg1) {
   return window['go']['app']['App']['CreateRoomCategory'](arg1);
 }
 
+export function DeductFromDeposit(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['DeductFromDeposit'](arg1, arg2, arg3, arg4);
+}
+
 export function DeleteAmenity(arg1) {
   return window['go']['app']['App']['DeleteAmenity'](arg1);
 }
@@ -38,36 +90,88 @@ export function DeleteRoom(arg1) {
   return window['go']['app']['App']['DeleteRoom'](arg1);
 }
 
+export function DiscardOperation(arg1) {
+  return window['go']['app']['App']['DiscardOperation'](arg1);
+}
+
+export function DismissStatementLine(arg1, arg2) {
+  return window['go']['app']['App']['DismissStatementLine'](arg1, arg2);
+}
+
+export function ExportList(arg1, arg2, arg3) {
+  return window['go']['app']['App']['ExportList'](arg1, arg2, arg3);
+}
+
+export function ForgetSession() {
+  return window['go']['app']['App']['ForgetSession']();
+}
+
+export function GenerateInvoices(arg1) {
+  return window['go']['app']['App']['GenerateInvoices'](arg1);
+}
+
 export function GetAmenityDetails(arg1) {
   return window['go']['app']['App']['GetAmenityDetails'](arg1);
 }
 
+export function GetCheckoutChecklist(arg1, arg2) {
+  return window['go']['app']['App']['GetCheckoutChecklist'](arg1, arg2);
+}
+
+export function GetConfig() {
+  return window['go']['app']['App']['GetConfig']();
+}
+
 export function GetContractDetails(arg1) {
   return window['go']['app']['App']['GetContractDetails'](arg1);
 }
 
+export function GetDepositStatement(arg1) {
+  return window['go']['app']['App']['GetDepositStatement'](arg1);
+}
+
+export function GetInvoiceDetails(arg1) {
+  return window['go']['app']['App']['GetInvoiceDetails'](arg1);
+}
+
+export function GetInvoiceVietQR(arg1) {
+  return window['go']['app']['App']['GetInvoiceVietQR'](arg1);
+}
+
 export function GetListAmenities(arg1) {
   return window['go']['app']['App']['GetListAmenities'](arg1);
 }
 
-export function GetListContracts(arg1, arg2) {
-  return window['go']['app']['App']['GetListContracts'](arg1, arg2);
+export function GetListContracts(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetListContracts'](arg1, arg2, arg3);
+}
+
+export function GetListInvoices(arg1, arg2) {
+  return window['go']['app']['App']['GetListInvoices'](arg1, arg2);
 }
 
 export function GetListMaintenanceHistories(arg1, arg2) {
   return window['go']['app']['App']['GetListMaintenanceHistories'](arg1, arg2);
 }
 
+export function GetListMeterReadings(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetListMeterReadings'](arg1, arg2, arg3);
+}
+
 export function GetListRoomCategories(arg1) {
   return window['go']['app']['App']['GetListRoomCategories'](arg1);
 }
 
-export function GetListRooms(arg1) {
-  return window['go']['app']['App']['GetListRooms'](arg1);
+export function GetListRoomTransfers(arg1, arg2) {
+  return window['go']['app']['App']['GetListRoomTransfers'](arg1, arg2);
 }
 
-export function GetListUsers(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8) {
-  return window['go']['app']['App']['GetListUsers'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8);
+export function GetListRooms(arg1, arg2) {
+  return window['go']['app']['App']['GetListRooms'](arg1, arg2);
+}
+
+export function GetListUsers(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9) {
+  return window['go']['app']['App']['GetListUsers'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
 }
 
 export function GetMaintenanceHistoryDetails(arg1) {
@@ -78,6 +182,14 @@ export function GetMe() {
   return window['go']['app']['App']['GetMe']();
 }
 
+export function GetPaymentDetails(arg1) {
+  return window['go']['app']['App']['GetPaymentDetails'](arg1);
+}
+
+export function GetRenewalsDue() {
+  return window['go']['app']['App']['GetRenewalsDue']();
+}
+
 export function GetRoomCategoryDetails(arg1) {
   return window['go']['app']['App']['GetRoomCategoryDetails'](arg1);
 }
@@ -86,12 +198,40 @@ export function GetRoomDetails(arg1) {
   return window['go']['app']['App']['GetRoomDetails'](arg1);
 }
 
+export function GetStudentPayments(arg1, arg2) {
+  return window['go']['app']['App']['GetStudentPayments'](arg1, arg2);
+}
+
 export function GetUserDetails(arg1) {
   return window['go']['app']['App']['GetUserDetails'](arg1);
 }
 
-export function LogData(arg1, arg2) {
-  return window['go']['app']['App']['LogData'](arg1, arg2);
+export function ImportBankStatement() {
+  return window['go']['app']['App']['ImportBankStatement']();
+}
+
+export function ImportStudents(arg1, arg2) {
+  return window['go']['app']['App']['ImportStudents'](arg1, arg2);
+}
+
+export function ListDunningActions() {
+  return window['go']['app']['App']['ListDunningActions']();
+}
+
+export function ListPendingOperations() {
+  return window['go']['app']['App']['ListPendingOperations']();
+}
+
+export function ListProfiles() {
+  return window['go']['app']['App']['ListProfiles']();
+}
+
+export function ListStatementLines(arg1) {
+  return window['go']['app']['App']['ListStatementLines'](arg1);
+}
+
+export function LogData(arg1, arg2, arg3) {
+  return window['go']['app']['App']['LogData'](arg1, arg2, arg3);
 }
 
 export function Login(arg1, arg2) {
@@ -102,10 +242,62 @@ export function Logout() {
   return window['go']['app']['App']['Logout']();
 }
 
+export function PreviewContractTermination(arg1, arg2) {
+  return window['go']['app']['App']['PreviewContractTermination'](arg1, arg2);
+}
+
+export function PreviewInvoices(arg1) {
+  return window['go']['app']['App']['PreviewInvoices'](arg1);
+}
+
+export function PreviewRoomSwap(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['PreviewRoomSwap'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewRoomTransfer(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['PreviewRoomTransfer'](arg1, arg2, arg3, arg4);
+}
+
+export function PreviewStudentImport(arg1) {
+  return window['go']['app']['App']['PreviewStudentImport'](arg1);
+}
+
+export function PreviewUtilityCharges(arg1) {
+  return window['go']['app']['App']['PreviewUtilityCharges'](arg1);
+}
+
+export function ProposeAllocation(arg1, arg2) {
+  return window['go']['app']['App']['ProposeAllocation'](arg1, arg2);
+}
+
+export function RecordDepositReceived(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RecordDepositReceived'](arg1, arg2, arg3);
+}
+
+export function RecordPayment(arg1) {
+  return window['go']['app']['App']['RecordPayment'](arg1);
+}
+
+export function RefundDeposit(arg1, arg2, arg3) {
+  return window['go']['app']['App']['RefundDeposit'](arg1, arg2, arg3);
+}
+
 export function Register(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['Register'](arg1, arg2, arg3, arg4);
 }
 
+export function RemoveProfile(arg1) {
+  return window['go']['app']['App']['RemoveProfile'](arg1);
+}
+
+export function RenewContract(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['RenewContract'](arg1, arg2, arg3, arg4);
+}
+
+export function ReplayPendingOperations() {
+  return window['go']['app']['App']['ReplayPendingOperations']();
+}
+
 export function ResendVerifyAccount(arg1) {
   return window['go']['app']['App']['ResendVerifyAccount'](arg1);
 }
@@ -114,22 +306,90 @@ export function ResetPassword(arg1) {
   return window['go']['app']['App']['ResetPassword'](arg1);
 }
 
+export function ResolveStatementLine(arg1, arg2) {
+  return window['go']['app']['App']['ResolveStatementLine'](arg1, arg2);
+}
+
+export function RestoreSession() {
+  return window['go']['app']['App']['RestoreSession']();
+}
+
+export function ReverseDunningAction(arg1) {
+  return window['go']['app']['App']['ReverseDunningAction'](arg1);
+}
+
+export function RunDunning() {
+  return window['go']['app']['App']['RunDunning']();
+}
+
+export function RunOccupancyAudit(arg1) {
+  return window['go']['app']['App']['RunOccupancyAudit'](arg1);
+}
+
+export function SaveContractPDF(arg1) {
+  return window['go']['app']['App']['SaveContractPDF'](arg1);
+}
+
+export function SaveInvoicePDF(arg1) {
+  return window['go']['app']['App']['SaveInvoicePDF'](arg1);
+}
+
+export function SaveReceiptPDF(arg1) {
+  return window['go']['app']['App']['SaveReceiptPDF'](arg1);
+}
+
+export function SaveStudentImportReport(arg1) {
+  return window['go']['app']['App']['SaveStudentImportReport'](arg1);
+}
+
 export function SendForgotPasswordEmail(arg1) {
   return window['go']['app']['App']['SendForgotPasswordEmail'](arg1);
 }
 
-export function SetToken(arg1) {
-  return window['go']['app']['App']['SetToken'](arg1);
+export function SetToken(arg1, arg2) {
+  return window['go']['app']['App']['SetToken'](arg1, arg2);
+}
+
+export function SettleDeposit(arg1) {
+  return window['go']['app']['App']['SettleDeposit'](arg1);
+}
+
+export function SwapRooms(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SwapRooms'](arg1, arg2, arg3, arg4);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['app']['App']['SwitchProfile'](arg1);
+}
+
+export function TerminateContract(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['TerminateContract'](arg1, arg2, arg3, arg4);
+}
+
+export function TransferRoom(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['TransferRoom'](arg1, arg2, arg3, arg4);
 }
 
 export function UpdateAmenity(arg1, arg2) {
   return window['go']['app']['App']['UpdateAmenity'](arg1, arg2);
 }
 
+export function UpdateConfig(arg1) {
+  return window['go']['app']['App']['UpdateConfig'](arg1);
+}
+
+export function UpdateContract(arg1, arg2) {
+  return window['go']['app']['App']['UpdateContract'](arg1, arg2);
+}
+
 export function UpdateMaintenanceHistory(arg1, arg2) {
   return window['go']['app']['App']['UpdateMaintenanceHistory'](arg1, arg2);
 }
 
+export function UpdateMeterReading(arg1, arg2) {
+  return window['go']['app']['App']['UpdateMeterReading'](arg1, arg2);
+}
+
 export function UpdateRoom(arg1, arg2) {
   return window['go']['app']['App']['UpdateRoom'](arg1, arg2);
 }
@@ -141,3 +401,7 @@ export function UpdateUserStatus(arg1, arg2) {
 export function VerifyAccount(arg1, arg2) {
   return window['go']['app']['App']['VerifyAccount'](arg1, arg2);
 }
+
+export function VoidInvoice(arg1, arg2) {
+  return window['go']['app']['App']['VoidInvoice'](arg1, arg2);
+}
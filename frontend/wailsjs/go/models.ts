@@ -1,38 +1,3905 @@
+export namespace allocation {
+	
+	export class Outcome {
+	    user_id: number;
+	    room_id: number;
+	    added: boolean;
+	    queued: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Outcome(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.room_id = source["room_id"];
+	        this.added = source["added"];
+	        this.queued = source["queued"];
+	        this.error = source["error"];
+	    }
+	}
+	export class Issue {
+	    user_id: number;
+	    room_id: number;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Issue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.room_id = source["room_id"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ApplyResult {
+	    issues: Issue[];
+	    outcomes: Outcome[];
+	    added: number;
+	    failed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApplyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.issues = this.convertValues(source["issues"], Issue);
+	        this.outcomes = this.convertValues(source["outcomes"], Outcome);
+	        this.added = source["added"];
+	        this.failed = source["failed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Assignment {
+	    user_id: number;
+	    student_code: string;
+	    full_name: string;
+	    gender: string;
+	    major: string;
+	    year: string;
+	    room_id: number;
+	    room_number: string;
+	    category_name: string;
+	    group: number;
+	    reasons: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Assignment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.student_code = source["student_code"];
+	        this.full_name = source["full_name"];
+	        this.gender = source["gender"];
+	        this.major = source["major"];
+	        this.year = source["year"];
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.category_name = source["category_name"];
+	        this.group = source["group"];
+	        this.reasons = source["reasons"];
+	    }
+	}
+	
+	
+	export class RoomLoad {
+	    room_id: number;
+	    room_number: string;
+	    category_id: number;
+	    category_name: string;
+	    capacity: number;
+	    occupied: number;
+	    planned: number;
+	    gender: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomLoad(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.category_id = source["category_id"];
+	        this.category_name = source["category_name"];
+	        this.capacity = source["capacity"];
+	        this.occupied = source["occupied"];
+	        this.planned = source["planned"];
+	        this.gender = source["gender"];
+	    }
+	}
+	export class Unplaced {
+	    user_id: number;
+	    student_code: string;
+	    full_name: string;
+	    gender: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Unplaced(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.student_code = source["student_code"];
+	        this.full_name = source["full_name"];
+	        this.gender = source["gender"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class Plan {
+	    assignments: Assignment[];
+	    unplaced: Unplaced[];
+	    rooms: RoomLoad[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Plan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.assignments = this.convertValues(source["assignments"], Assignment);
+	        this.unplaced = this.convertValues(source["unplaced"], Unplaced);
+	        this.rooms = this.convertValues(source["rooms"], RoomLoad);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Preference {
+	    user_id: number;
+	    category_ids: number[];
+	    roommates: number[];
+	    year: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Preference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.category_ids = source["category_ids"];
+	        this.roommates = source["roommates"];
+	        this.year = source["year"];
+	    }
+	}
+	
+
+}
+
+export namespace api {
+	
+	export class InvoiceQuery {
+	    page: number;
+	    keyword: string;
+	    status: string;
+	    type: string;
+	    user_id: number;
+	    billing_period: string;
+	    from_date: string;
+	    to_date: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InvoiceQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.page = source["page"];
+	        this.keyword = source["keyword"];
+	        this.status = source["status"];
+	        this.type = source["type"];
+	        this.user_id = source["user_id"];
+	        this.billing_period = source["billing_period"];
+	        this.from_date = source["from_date"];
+	        this.to_date = source["to_date"];
+	    }
+	}
+	export class Response___changeme_internal_models_Amenity_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Amenity[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_Amenity_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Amenity);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_Contract_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Contract[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_Contract_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Contract);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_Invoice_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Invoice[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_Invoice_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Invoice);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_MaintenanceHistory_ {
+	    success: boolean;
+	    message: string;
+	    data: models.MaintenanceHistory[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_MaintenanceHistory_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.MaintenanceHistory);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_MeterReading_ {
+	    success: boolean;
+	    message: string;
+	    data: models.MeterReading[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_MeterReading_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.MeterReading);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_Payment_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Payment[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_Payment_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Payment);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_RoomCategory_ {
+	    success: boolean;
+	    message: string;
+	    data: models.RoomCategory[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_RoomCategory_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.RoomCategory);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_RoomTransfer_ {
+	    success: boolean;
+	    message: string;
+	    data: models.RoomTransfer[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_RoomTransfer_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.RoomTransfer);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_Room_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Room[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_Room_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Room);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response___changeme_internal_models_User_ {
+	    success: boolean;
+	    message: string;
+	    data: models.User[];
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response___changeme_internal_models_User_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.User);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Amenity_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Amenity;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Amenity_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Amenity);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Contract_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Contract;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Contract_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Contract);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Invoice_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Invoice;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Invoice_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Invoice);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Login_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Login;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Login_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Login);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_MaintenanceHistory_ {
+	    success: boolean;
+	    message: string;
+	    data: models.MaintenanceHistory;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_MaintenanceHistory_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.MaintenanceHistory);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_MeterReading_ {
+	    success: boolean;
+	    message: string;
+	    data: models.MeterReading;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_MeterReading_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.MeterReading);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Payment_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Payment;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Payment_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Payment);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_RoomCategory_ {
+	    success: boolean;
+	    message: string;
+	    data: models.RoomCategory;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_RoomCategory_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.RoomCategory);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_Room_ {
+	    success: boolean;
+	    message: string;
+	    data: models.Room;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_Room_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.Room);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_changeme_internal_models_User_ {
+	    success: boolean;
+	    message: string;
+	    data: models.User;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_changeme_internal_models_User_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = this.convertValues(source["data"], models.User);
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response_interface____ {
+	    success: boolean;
+	    message: string;
+	    data: any;
+	    total: number;
+	    stale?: boolean;
+	    // Go type: time
+	    cached_at?: any;
+	    queued?: boolean;
+	    operation_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response_interface____(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.data = source["data"];
+	        this.total = source["total"];
+	        this.stale = source["stale"];
+	        this.cached_at = this.convertValues(source["cached_at"], null);
+	        this.queued = source["queued"];
+	        this.operation_id = source["operation_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace app {
+	
+	export class ExportResult {
+	    path: string;
+	    records: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.records = source["records"];
+	    }
+	}
+	export class ProfileInfo {
+	    profile: config.ProfileConfig;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = this.convertValues(source["profile"], config.ProfileConfig);
+	        this.active = source["active"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RosterPreview {
+	    path: string;
+	    report?: roster.Report;
+	
+	    static createFrom(source: any = {}) {
+	        return new RosterPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.report = this.convertValues(source["report"], roster.Report);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VietQRCode {
+	    payload: string;
+	    image: string;
+	    amount: number;
+	    memo: string;
+	    bank_bin: string;
+	    account_number: string;
+	    account_name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VietQRCode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.payload = source["payload"];
+	        this.image = source["image"];
+	        this.amount = source["amount"];
+	        this.memo = source["memo"];
+	        this.bank_bin = source["bank_bin"];
+	        this.account_number = source["account_number"];
+	        this.account_name = source["account_name"];
+	    }
+	}
+
+}
+
+export namespace audit {
+	
+	export class Fix {
+	    action: string;
+	    description: string;
+	    room_id?: number;
+	    user_id?: number;
+	    contract_id?: number;
+	    to_room_id?: number;
+	    user_count?: number;
+	    status?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Fix(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.description = source["description"];
+	        this.room_id = source["room_id"];
+	        this.user_id = source["user_id"];
+	        this.contract_id = source["contract_id"];
+	        this.to_room_id = source["to_room_id"];
+	        this.user_count = source["user_count"];
+	        this.status = source["status"];
+	    }
+	}
+	export class Finding {
+	    kind: string;
+	    message: string;
+	    room_id?: number;
+	    room_number?: string;
+	    user_id?: number;
+	    student_name?: string;
+	    contract_id?: number;
+	    contract_code?: string;
+	    fixes: Fix[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Finding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.fixes = this.convertValues(source["fixes"], Fix);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Outcome {
+	    fix: Fix;
+	    applied: boolean;
+	    queued: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Outcome(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fix = this.convertValues(source["fix"], Fix);
+	        this.applied = source["applied"];
+	        this.queued = source["queued"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Report {
+	    // Go type: time
+	    checked_at: any;
+	    rooms: number;
+	    users: number;
+	    contracts: number;
+	    findings: Finding[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checked_at = this.convertValues(source["checked_at"], null);
+	        this.rooms = source["rooms"];
+	        this.users = source["users"];
+	        this.contracts = source["contracts"];
+	        this.findings = this.convertValues(source["findings"], Finding);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace billing {
+	
+	export class Draft {
+	    contract_id: number;
+	    contract_code: string;
+	    user_id: number;
+	    student_name: string;
+	    room_id: number;
+	    room_number: string;
+	    code: string;
+	    billing_period: string;
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    days: number;
+	    period_days: number;
+	    prorated: boolean;
+	    monthly_price: number;
+	    amount: number;
+	    description: string;
+	    // Go type: time
+	    issue_date: any;
+	    // Go type: time
+	    due_date: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Draft(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.code = source["code"];
+	        this.billing_period = source["billing_period"];
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.days = source["days"];
+	        this.period_days = source["period_days"];
+	        this.prorated = source["prorated"];
+	        this.monthly_price = source["monthly_price"];
+	        this.amount = source["amount"];
+	        this.description = source["description"];
+	        this.issue_date = this.convertValues(source["issue_date"], null);
+	        this.due_date = this.convertValues(source["due_date"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Failure {
+	    draft: Draft;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Failure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.draft = this.convertValues(source["draft"], Draft);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Skip {
+	    contract_id: number;
+	    contract_code: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Skip(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class Plan {
+	    billing_period: string;
+	    drafts: Draft[];
+	    skipped: Skip[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Plan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.billing_period = source["billing_period"];
+	        this.drafts = this.convertValues(source["drafts"], Draft);
+	        this.skipped = this.convertValues(source["skipped"], Skip);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result {
+	    plan?: Plan;
+	    created: models.Invoice[];
+	    failed: Failure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.plan = this.convertValues(source["plan"], Plan);
+	        this.created = this.convertValues(source["created"], models.Invoice);
+	        this.failed = this.convertValues(source["failed"], Failure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace checkout {
+	
+	export class Item {
+	    room_amenity_id: number;
+	    amenity_id: number;
+	    name: string;
+	    condition: string;
+	    note: string;
+	    cost: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Item(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.room_amenity_id = source["room_amenity_id"];
+	        this.amenity_id = source["amenity_id"];
+	        this.name = source["name"];
+	        this.condition = source["condition"];
+	        this.note = source["note"];
+	        this.cost = source["cost"];
+	    }
+	}
+	export class Checklist {
+	    user_id: number;
+	    student_name: string;
+	    student_code: string;
+	    room_id: number;
+	    room_number: string;
+	    contract_id: number;
+	    contract_code: string;
+	    date: string;
+	    items: Item[];
+	    final_invoice?: billing.Draft;
+	    outstanding: models.Invoice[];
+	    outstanding_total: number;
+	    deposit?: deposit.Statement;
+	
+	    static createFrom(source: any = {}) {
+	        return new Checklist(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.student_code = source["student_code"];
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.date = source["date"];
+	        this.items = this.convertValues(source["items"], Item);
+	        this.final_invoice = this.convertValues(source["final_invoice"], billing.Draft);
+	        this.outstanding = this.convertValues(source["outstanding"], models.Invoice);
+	        this.outstanding_total = source["outstanding_total"];
+	        this.deposit = this.convertValues(source["deposit"], deposit.Statement);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Result {
+	    checklist?: Checklist;
+	    final_invoice?: models.Invoice;
+	    damages: models.MaintenanceHistory[];
+	    deposit?: deposit.Statement;
+	    payments: models.Payment[];
+	    contract?: models.Contract;
+	    room_released: boolean;
+	    release_queued: boolean;
+	    completed: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checklist = this.convertValues(source["checklist"], Checklist);
+	        this.final_invoice = this.convertValues(source["final_invoice"], models.Invoice);
+	        this.damages = this.convertValues(source["damages"], models.MaintenanceHistory);
+	        this.deposit = this.convertValues(source["deposit"], deposit.Statement);
+	        this.payments = this.convertValues(source["payments"], models.Payment);
+	        this.contract = this.convertValues(source["contract"], models.Contract);
+	        this.room_released = source["room_released"];
+	        this.release_queued = source["release_queued"];
+	        this.completed = source["completed"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace client {
 	
-	export class Response {
-	    Status: string;
-	    StatusCode: number;
-	    Proto: string;
-	    ProtoMajor: number;
-	    ProtoMinor: number;
-	    Header: Record<string, string[]>;
-	    ContentLength: number;
-	    TransferEncoding: string[];
-	    Close: boolean;
-	    Uncompressed: boolean;
-	    Trailer: Record<string, string[]>;
-	    ParsedBody: any;
+	export class QueuedRequest {
+	    kind: string;
+	    method: string;
+	    url: string;
+	    body: number[];
+	    headers: Record<string, string>;
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueuedRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.method = source["method"];
+	        this.url = source["url"];
+	        this.body = source["body"];
+	        this.headers = source["headers"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace config {
+	
+	export class BankConfig {
+	    bin: string;
+	    account_number: string;
+	    account_name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BankConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bin = source["bin"];
+	        this.account_number = source["account_number"];
+	        this.account_name = source["account_name"];
+	    }
+	}
+	export class ClientConfig {
+	    base_url: string;
+	    timeout: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.base_url = source["base_url"];
+	        this.timeout = source["timeout"];
+	    }
+	}
+	export class ExpiryConfig {
+	    enabled: boolean;
+	    interval: number;
+	    windows: number[];
+	    desktop_notifications: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExpiryConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.interval = source["interval"];
+	        this.windows = source["windows"];
+	        this.desktop_notifications = source["desktop_notifications"];
+	    }
+	}
+	export class ContractsConfig {
+	    notice_days: number;
+	    penalty_months: number;
+	    expiry: ExpiryConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractsConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.notice_days = source["notice_days"];
+	        this.penalty_months = source["penalty_months"];
+	        this.expiry = this.convertValues(source["expiry"], ExpiryConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DunningConfig {
+	    enabled: boolean;
+	    interval: number;
+	    grace_days: number;
+	    fee_type: string;
+	    fee_amount: number;
+	    reminder_days: number;
+	    warning_days: number;
+	    final_notice_days: number;
+	    flag_account: boolean;
+	    flag_status: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DunningConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.interval = source["interval"];
+	        this.grace_days = source["grace_days"];
+	        this.fee_type = source["fee_type"];
+	        this.fee_amount = source["fee_amount"];
+	        this.reminder_days = source["reminder_days"];
+	        this.warning_days = source["warning_days"];
+	        this.final_notice_days = source["final_notice_days"];
+	        this.flag_account = source["flag_account"];
+	        this.flag_status = source["flag_status"];
+	    }
+	}
+	export class TariffTier {
+	    up_to: number;
+	    price: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TariffTier(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.up_to = source["up_to"];
+	        this.price = source["price"];
+	    }
+	}
+	export class TariffConfig {
+	    meter_max: number;
+	    tiers: TariffTier[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TariffConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.meter_max = source["meter_max"];
+	        this.tiers = this.convertValues(source["tiers"], TariffTier);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MeteringConfig {
+	    electricity: TariffConfig;
+	    water: TariffConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeteringConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.electricity = this.convertValues(source["electricity"], TariffConfig);
+	        this.water = this.convertValues(source["water"], TariffConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoggerConfig {
+	    level: string;
+	    format: string;
+	    output: string;
+	    file: string;
+	    max_size: number;
+	    max_backups: number;
+	    max_age: number;
+	    compress: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoggerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.format = source["format"];
+	        this.output = source["output"];
+	        this.file = source["file"];
+	        this.max_size = source["max_size"];
+	        this.max_backups = source["max_backups"];
+	        this.max_age = source["max_age"];
+	        this.compress = source["compress"];
+	    }
+	}
+	export class TLSConfig {
+	    ca_file: string;
+	    insecure_skip_verify: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TLSConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ca_file = source["ca_file"];
+	        this.insecure_skip_verify = source["insecure_skip_verify"];
+	    }
+	}
+	export class ProfileConfig {
+	    name: string;
+	    display_name: string;
+	    base_url: string;
+	    timeout: number;
+	    tls: TLSConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.display_name = source["display_name"];
+	        this.base_url = source["base_url"];
+	        this.timeout = source["timeout"];
+	        this.tls = this.convertValues(source["tls"], TLSConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Config {
+	    client: ClientConfig;
+	    profiles: ProfileConfig[];
+	    active_profile: string;
+	    logging: LoggerConfig;
+	    metering: MeteringConfig;
+	    dunning: DunningConfig;
+	    bank: BankConfig;
+	    contracts: ContractsConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new Config(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.client = this.convertValues(source["client"], ClientConfig);
+	        this.profiles = this.convertValues(source["profiles"], ProfileConfig);
+	        this.active_profile = source["active_profile"];
+	        this.logging = this.convertValues(source["logging"], LoggerConfig);
+	        this.metering = this.convertValues(source["metering"], MeteringConfig);
+	        this.dunning = this.convertValues(source["dunning"], DunningConfig);
+	        this.bank = this.convertValues(source["bank"], BankConfig);
+	        this.contracts = this.convertValues(source["contracts"], ContractsConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	
+	
+	
+	
+
+}
+
+export namespace deposit {
+	
+	export class Line {
+	    // Go type: time
+	    date: any;
+	    description: string;
+	    amount: number;
+	    maintenance_history_id?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Line(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = this.convertValues(source["date"], null);
+	        this.description = source["description"];
+	        this.amount = source["amount"];
+	        this.maintenance_history_id = source["maintenance_history_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Statement {
+	    contract_id: number;
+	    contract_code: string;
+	    student_name: string;
+	    student_code: string;
+	    room_number: string;
+	    status: string;
+	    received: Line[];
+	    deductions: Line[];
+	    refunds: Line[];
+	    total_received: number;
+	    total_deductions: number;
+	    total_refunded: number;
+	    balance: number;
+	    // Go type: time
+	    settled_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Statement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.student_name = source["student_name"];
+	        this.student_code = source["student_code"];
+	        this.room_number = source["room_number"];
+	        this.status = source["status"];
+	        this.received = this.convertValues(source["received"], Line);
+	        this.deductions = this.convertValues(source["deductions"], Line);
+	        this.refunds = this.convertValues(source["refunds"], Line);
+	        this.total_received = source["total_received"];
+	        this.total_deductions = source["total_deductions"];
+	        this.total_refunded = source["total_refunded"];
+	        this.balance = source["balance"];
+	        this.settled_at = this.convertValues(source["settled_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace dunning {
+	
+	export class Action {
+	    id: string;
+	    kind: string;
+	    invoice_id: number;
+	    invoice_code: string;
+	    user_id: number;
+	    level?: string;
+	    days_overdue: number;
+	    amount?: number;
+	    fee_invoice_id?: number;
+	    previous_status?: string;
+	    new_status?: string;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    reversed_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Action(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.invoice_id = source["invoice_id"];
+	        this.invoice_code = source["invoice_code"];
+	        this.user_id = source["user_id"];
+	        this.level = source["level"];
+	        this.days_overdue = source["days_overdue"];
+	        this.amount = source["amount"];
+	        this.fee_invoice_id = source["fee_invoice_id"];
+	        this.previous_status = source["previous_status"];
+	        this.new_status = source["new_status"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.reversed_at = this.convertValues(source["reversed_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Report {
+	    // Go type: time
+	    ran_at: any;
+	    checked: number;
+	    overdue: number;
+	    actions: Action[];
+	    failures: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ran_at = this.convertValues(source["ran_at"], null);
+	        this.checked = source["checked"];
+	        this.overdue = source["overdue"];
+	        this.actions = this.convertValues(source["actions"], Action);
+	        this.failures = source["failures"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace expiry {
+	
+	export class Due {
+	    contract_id: number;
+	    contract_code: string;
+	    user_id: number;
+	    student_name: string;
+	    student_code: string;
+	    phone: string;
+	    email: string;
+	    room_id: number;
+	    room_number: string;
+	    // Go type: time
+	    end_date: any;
+	    days_left: number;
+	    window: number;
+	    expired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Due(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.student_code = source["student_code"];
+	        this.phone = source["phone"];
+	        this.email = source["email"];
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.end_date = this.convertValues(source["end_date"], null);
+	        this.days_left = source["days_left"];
+	        this.window = source["window"];
+	        this.expired = source["expired"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WindowGroup {
+	    days: number;
+	    contracts: Due[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.contracts = this.convertValues(source["contracts"], Due);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Renewals {
+	    // Go type: time
+	    checked_at: any;
+	    expired: Due[];
+	    windows: WindowGroup[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Renewals(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checked_at = this.convertValues(source["checked_at"], null);
+	        this.expired = this.convertValues(source["expired"], Due);
+	        this.windows = this.convertValues(source["windows"], WindowGroup);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace export {
+	
+	export class Filter {
+	    keyword: string;
+	    order: string;
+	    status: string;
+	    gender: string;
+	    status_account: string;
+	    role: string;
+	    has_room?: boolean;
+	    room_id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keyword = source["keyword"];
+	        this.order = source["order"];
+	        this.status = source["status"];
+	        this.gender = source["gender"];
+	        this.status_account = source["status_account"];
+	        this.role = source["role"];
+	        this.has_room = source["has_room"];
+	        this.room_id = source["room_id"];
+	    }
+	}
+
+}
+
+export namespace lease {
+	
+	export class Termination {
+	    contract_id: number;
+	    contract_code: string;
+	    student_name: string;
+	    // Go type: time
+	    end_date: any;
+	    // Go type: time
+	    original_end_date: any;
+	    notice_days: number;
+	    required_notice_days: number;
+	    penalty: number;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Termination(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract_id = source["contract_id"];
+	        this.contract_code = source["contract_code"];
+	        this.student_name = source["student_name"];
+	        this.end_date = this.convertValues(source["end_date"], null);
+	        this.original_end_date = this.convertValues(source["original_end_date"], null);
+	        this.notice_days = source["notice_days"];
+	        this.required_notice_days = source["required_notice_days"];
+	        this.penalty = source["penalty"];
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TerminationResult {
+	    termination?: Termination;
+	    contract: models.Contract;
+	    penalty_invoice?: models.Invoice;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.termination = this.convertValues(source["termination"], Termination);
+	        this.contract = this.convertValues(source["contract"], models.Contract);
+	        this.penalty_invoice = this.convertValues(source["penalty_invoice"], models.Invoice);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace metering {
+	
+	export class Share {
+	    user_id: number;
+	    full_name: string;
+	    days: number;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Share(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.full_name = source["full_name"];
+	        this.days = source["days"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class Failure {
+	    room_number: string;
+	    share: Share;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Failure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.room_number = source["room_number"];
+	        this.share = this.convertValues(source["share"], Share);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TierCharge {
+	    from: number;
+	    to: number;
+	    units: number;
+	    price: number;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TierCharge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.units = source["units"];
+	        this.price = source["price"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class Usage {
+	    previous: number;
+	    current: number;
+	    units: number;
+	    anomaly: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Usage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.previous = source["previous"];
+	        this.current = source["current"];
+	        this.units = source["units"];
+	        this.anomaly = source["anomaly"];
+	    }
+	}
+	export class RoomCharge {
+	    room_id: number;
+	    room_number: string;
+	    utility: string;
+	    usage: Usage;
+	    tiers: TierCharge[];
+	    amount: number;
+	    shares: Share[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomCharge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.room_id = source["room_id"];
+	        this.room_number = source["room_number"];
+	        this.utility = source["utility"];
+	        this.usage = this.convertValues(source["usage"], Usage);
+	        this.tiers = this.convertValues(source["tiers"], TierCharge);
+	        this.amount = source["amount"];
+	        this.shares = this.convertValues(source["shares"], Share);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Statement {
+	    billing_period: string;
+	    charges: RoomCharge[];
+	    anomalies: number;
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Statement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.billing_period = source["billing_period"];
+	        this.charges = this.convertValues(source["charges"], RoomCharge);
+	        this.anomalies = source["anomalies"];
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result {
+	    statement?: Statement;
+	    created: models.Invoice[];
+	    skipped: number;
+	    failed: Failure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statement = this.convertValues(source["statement"], Statement);
+	        this.created = this.convertValues(source["created"], models.Invoice);
+	        this.skipped = source["skipped"];
+	        this.failed = this.convertValues(source["failed"], Failure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+
+}
+
+export namespace models {
+	
+	export class Amenity {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Amenity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.name = source["name"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EmergencyContact {
+	    name: string;
+	    phone: string;
+	    relationship: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EmergencyContact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.phone = source["phone"];
+	        this.relationship = source["relationship"];
+	    }
+	}
+	export class MaintenanceHistory {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    room_id: number;
+	    // Go type: time
+	    maintenance_date: any;
+	    description: string;
+	    cost: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaintenanceHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.room_id = source["room_id"];
+	        this.maintenance_date = this.convertValues(source["maintenance_date"], null);
+	        this.description = source["description"];
+	        this.cost = source["cost"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoomAmenity {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    room_id: number;
+	    amenity_id: number;
+	    amenity: Amenity;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomAmenity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.room_id = source["room_id"];
+	        this.amenity_id = source["amenity_id"];
+	        this.amenity = this.convertValues(source["amenity"], Amenity);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoomCategory {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    name: string;
+	    description: string;
+	    capacity: number;
+	    price: number;
+	    acreage: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomCategory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.capacity = source["capacity"];
+	        this.price = source["price"];
+	        this.acreage = source["acreage"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Room {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    room_number: string;
+	    status: string;
+	    user_count: number;
+	    room_category_id: number;
+	    room_category: RoomCategory;
+	    room_amenities: RoomAmenity[];
+	    users: User[];
+	    maintenance_histories: MaintenanceHistory[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Room(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.room_number = source["room_number"];
+	        this.status = source["status"];
+	        this.user_count = source["user_count"];
+	        this.room_category_id = source["room_category_id"];
+	        this.room_category = this.convertValues(source["room_category"], RoomCategory);
+	        this.room_amenities = this.convertValues(source["room_amenities"], RoomAmenity);
+	        this.users = this.convertValues(source["users"], User);
+	        this.maintenance_histories = this.convertValues(source["maintenance_histories"], MaintenanceHistory);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class User {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    full_name: string;
+	    student_code: string;
+	    email: string;
+	    role: string;
+	    gender: string;
+	    status: string;
+	    status_account: string;
+	    phone: string;
+	    is_verify: boolean;
+	    // Go type: time
+	    birthday?: any;
+	    avatar?: string;
+	    room_id?: number;
+	    room?: Room;
+	    address?: string;
+	    major?: string;
+	    emergency_contact?: EmergencyContact;
+	
+	    static createFrom(source: any = {}) {
+	        return new User(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.full_name = source["full_name"];
+	        this.student_code = source["student_code"];
+	        this.email = source["email"];
+	        this.role = source["role"];
+	        this.gender = source["gender"];
+	        this.status = source["status"];
+	        this.status_account = source["status_account"];
+	        this.phone = source["phone"];
+	        this.is_verify = source["is_verify"];
+	        this.birthday = this.convertValues(source["birthday"], null);
+	        this.avatar = source["avatar"];
+	        this.room_id = source["room_id"];
+	        this.room = this.convertValues(source["room"], Room);
+	        this.address = source["address"];
+	        this.major = source["major"];
+	        this.emergency_contact = this.convertValues(source["emergency_contact"], EmergencyContact);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Contract {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    code: string;
+	    user_id: number;
+	    user: User;
+	    room_id: number;
+	    room: Room;
+	    // Go type: time
+	    start_date: any;
+	    // Go type: time
+	    end_date: any;
+	    price: number;
+	    status: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Contract(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.code = source["code"];
+	        this.user_id = source["user_id"];
+	        this.user = this.convertValues(source["user"], User);
+	        this.room_id = source["room_id"];
+	        this.room = this.convertValues(source["room"], Room);
+	        this.start_date = this.convertValues(source["start_date"], null);
+	        this.end_date = this.convertValues(source["end_date"], null);
+	        this.price = source["price"];
+	        this.status = source["status"];
+	        this.description = source["description"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Payment {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    invoice_id: number;
+	    invoice?: Invoice;
+	    user_id: number;
+	    amount: number;
+	    method: string;
+	    // Go type: time
+	    paid_at: any;
+	    reference: string;
+	    note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Payment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.invoice_id = source["invoice_id"];
+	        this.invoice = this.convertValues(source["invoice"], Invoice);
+	        this.user_id = source["user_id"];
+	        this.amount = source["amount"];
+	        this.method = source["method"];
+	        this.paid_at = this.convertValues(source["paid_at"], null);
+	        this.reference = source["reference"];
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Invoice {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    code: string;
+	    user_id: number;
+	    user: User;
+	    room_id?: number;
+	    room?: Room;
+	    contract_id?: number;
+	    type: string;
+	    description: string;
+	    billing_period: string;
+	    amount: number;
+	    paid_amount: number;
+	    status: string;
+	    // Go type: time
+	    issue_date: any;
+	    // Go type: time
+	    due_date: any;
+	    // Go type: time
+	    paid_at?: any;
+	    // Go type: time
+	    voided_at?: any;
+	    void_reason: string;
+	    payments: Payment[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Invoice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.code = source["code"];
+	        this.user_id = source["user_id"];
+	        this.user = this.convertValues(source["user"], User);
+	        this.room_id = source["room_id"];
+	        this.room = this.convertValues(source["room"], Room);
+	        this.contract_id = source["contract_id"];
+	        this.type = source["type"];
+	        this.description = source["description"];
+	        this.billing_period = source["billing_period"];
+	        this.amount = source["amount"];
+	        this.paid_amount = source["paid_amount"];
+	        this.status = source["status"];
+	        this.issue_date = this.convertValues(source["issue_date"], null);
+	        this.due_date = this.convertValues(source["due_date"], null);
+	        this.paid_at = this.convertValues(source["paid_at"], null);
+	        this.voided_at = this.convertValues(source["voided_at"], null);
+	        this.void_reason = source["void_reason"];
+	        this.payments = this.convertValues(source["payments"], Payment);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Login {
+	    access_token: string;
+	    refresh_token: string;
+	    user: User;
+	    is_verified: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Login(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.access_token = source["access_token"];
+	        this.refresh_token = source["refresh_token"];
+	        this.user = this.convertValues(source["user"], User);
+	        this.is_verified = source["is_verified"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MeterReading {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    room_id: number;
+	    utility: string;
+	    billing_period: string;
+	    reading: number;
+	    // Go type: time
+	    read_at: any;
+	    note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeterReading(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.room_id = source["room_id"];
+	        this.utility = source["utility"];
+	        this.billing_period = source["billing_period"];
+	        this.reading = source["reading"];
+	        this.read_at = this.convertValues(source["read_at"], null);
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class RoomTransfer {
+	    id: number;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	    user_id: number;
+	    user: User;
+	    from_room_id: number;
+	    from_room: Room;
+	    to_room_id: number;
+	    to_room: Room;
+	    from_contract_id: number;
+	    to_contract_id: number;
+	    swap_with_user_id?: number;
+	    // Go type: time
+	    move_date: any;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomTransfer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	        this.user_id = source["user_id"];
+	        this.user = this.convertValues(source["user"], User);
+	        this.from_room_id = source["from_room_id"];
+	        this.from_room = this.convertValues(source["from_room"], Room);
+	        this.to_room_id = source["to_room_id"];
+	        this.to_room = this.convertValues(source["to_room"], Room);
+	        this.from_contract_id = source["from_contract_id"];
+	        this.to_contract_id = source["to_contract_id"];
+	        this.swap_with_user_id = source["swap_with_user_id"];
+	        this.move_date = this.convertValues(source["move_date"], null);
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace outbox {
+	
+	export class Operation {
+	    id: string;
+	    request: client.QueuedRequest;
+	    status: string;
+	    attempts: number;
+	    last_error: string;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Operation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.request = this.convertValues(source["request"], client.QueuedRequest);
+	        this.status = source["status"];
+	        this.attempts = source["attempts"];
+	        this.last_error = source["last_error"];
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace reconcile {
+	
+	export class Match {
+	    invoice_id: number;
+	    invoice_code: string;
+	    contract_code: string;
+	    user_id: number;
+	    student_name: string;
+	    outstanding: number;
+	    amount: number;
+	    score: number;
+	    matched_by: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Match(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.invoice_id = source["invoice_id"];
+	        this.invoice_code = source["invoice_code"];
+	        this.contract_code = source["contract_code"];
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.outstanding = source["outstanding"];
+	        this.amount = source["amount"];
+	        this.score = source["score"];
+	        this.matched_by = source["matched_by"];
+	    }
+	}
+	export class Transaction {
+	    row: number;
+	    // Go type: time
+	    date: any;
+	    amount: number;
+	    memo: string;
+	    reference: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Transaction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.date = this.convertValues(source["date"], null);
+	        this.amount = source["amount"];
+	        this.memo = source["memo"];
+	        this.reference = source["reference"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Line {
+	    id: string;
+	    source: string;
+	    transaction: Transaction;
+	    status: string;
+	    match?: Match;
+	    candidates: Match[];
+	    reason: string;
+	    payment_id: number;
+	    last_error: string;
+	    // Go type: time
+	    imported_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Line(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.transaction = this.convertValues(source["transaction"], Transaction);
+	        this.status = source["status"];
+	        this.match = this.convertValues(source["match"], Match);
+	        this.candidates = this.convertValues(source["candidates"], Match);
+	        this.reason = source["reason"];
+	        this.payment_id = source["payment_id"];
+	        this.last_error = source["last_error"];
+	        this.imported_at = this.convertValues(source["imported_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportResult {
+	    source: string;
+	    parsed: number;
+	    duplicates: number;
+	    proposed: Line[];
+	    review: Line[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Response(source);
+	        return new ImportResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Status = source["Status"];
-	        this.StatusCode = source["StatusCode"];
-	        this.Proto = source["Proto"];
-	        this.ProtoMajor = source["ProtoMajor"];
-	        this.ProtoMinor = source["ProtoMinor"];
-	        this.Header = source["Header"];
-	        this.ContentLength = source["ContentLength"];
-	        this.TransferEncoding = source["TransferEncoding"];
-	        this.Close = source["Close"];
-	        this.Uncompressed = source["Uncompressed"];
-	        this.Trailer = source["Trailer"];
-	        this.ParsedBody = source["ParsedBody"];
+	        this.source = source["source"];
+	        this.parsed = source["parsed"];
+	        this.duplicates = source["duplicates"];
+	        this.proposed = this.convertValues(source["proposed"], Line);
+	        this.review = this.convertValues(source["review"], Line);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+
+}
+
+export namespace roster {
+	
+	export class Result {
+	    row: number;
+	    student_code: string;
+	    full_name: string;
+	    email: string;
+	    outcome: string;
+	    errors: string[];
+	    user_id: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.student_code = source["student_code"];
+	        this.full_name = source["full_name"];
+	        this.email = source["email"];
+	        this.outcome = source["outcome"];
+	        this.errors = source["errors"];
+	        this.user_id = source["user_id"];
+	    }
+	}
+	export class Report {
+	    source: string;
+	    mapping: Record<string, string>;
+	    total: number;
+	    ready: number;
+	    created: number;
+	    duplicates: number;
+	    invalid: number;
+	    failed: number;
+	    results: Result[];
+	    // Go type: time
+	    finished_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.mapping = source["mapping"];
+	        this.total = source["total"];
+	        this.ready = source["ready"];
+	        this.created = source["created"];
+	        this.duplicates = source["duplicates"];
+	        this.invalid = source["invalid"];
+	        this.failed = source["failed"];
+	        this.results = this.convertValues(source["results"], Result);
+	        this.finished_at = this.convertValues(source["finished_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace transfer {
+	
+	export class Proration {
+	    billing_period: string;
+	    old_days: number;
+	    old_amount: number;
+	    new_days: number;
+	    new_amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Proration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.billing_period = source["billing_period"];
+	        this.old_days = source["old_days"];
+	        this.old_amount = source["old_amount"];
+	        this.new_days = source["new_days"];
+	        this.new_amount = source["new_amount"];
+	    }
+	}
+	export class Leg {
+	    user_id: number;
+	    student_name: string;
+	    student_code: string;
+	    from_room_id: number;
+	    from_room_number: string;
+	    to_room_id: number;
+	    to_room_number: string;
+	    old_contract_id: number;
+	    old_contract_code: string;
+	    old_price: number;
+	    // Go type: time
+	    old_end_date: any;
+	    new_price: number;
+	    // Go type: time
+	    new_start_date: any;
+	    // Go type: time
+	    new_end_date: any;
+	    proration: Proration;
+	    final_invoice?: billing.Draft;
+	
+	    static createFrom(source: any = {}) {
+	        return new Leg(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.user_id = source["user_id"];
+	        this.student_name = source["student_name"];
+	        this.student_code = source["student_code"];
+	        this.from_room_id = source["from_room_id"];
+	        this.from_room_number = source["from_room_number"];
+	        this.to_room_id = source["to_room_id"];
+	        this.to_room_number = source["to_room_number"];
+	        this.old_contract_id = source["old_contract_id"];
+	        this.old_contract_code = source["old_contract_code"];
+	        this.old_price = source["old_price"];
+	        this.old_end_date = this.convertValues(source["old_end_date"], null);
+	        this.new_price = source["new_price"];
+	        this.new_start_date = this.convertValues(source["new_start_date"], null);
+	        this.new_end_date = this.convertValues(source["new_end_date"], null);
+	        this.proration = this.convertValues(source["proration"], Proration);
+	        this.final_invoice = this.convertValues(source["final_invoice"], billing.Draft);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Preview {
+	    swap: boolean;
+	    move_date: string;
+	    reason: string;
+	    legs: Leg[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Preview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.swap = source["swap"];
+	        this.move_date = source["move_date"];
+	        this.reason = source["reason"];
+	        this.legs = this.convertValues(source["legs"], Leg);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Result {
+	    swap: boolean;
+	    move_date: string;
+	    reason: string;
+	    legs: Leg[];
+	    warnings: string[];
+	    contracts: models.Contract[];
+	    transfers: models.RoomTransfer[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.swap = source["swap"];
+	        this.move_date = source["move_date"];
+	        this.reason = source["reason"];
+	        this.legs = this.convertValues(source["legs"], Leg);
+	        this.warnings = source["warnings"];
+	        this.contracts = this.convertValues(source["contracts"], models.Contract);
+	        this.transfers = this.convertValues(source["transfers"], models.RoomTransfer);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -0,0 +1,225 @@
+package allocation
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Issue is a reason an edited plan cannot be applied as it is
+type Issue struct {
+	UserID  int    `json:"user_id"`
+	RoomID  int    `json:"room_id"`
+	Message string `json:"message"`
+}
+
+// Outcome is what happened to one assignment when a plan was applied
+type Outcome struct {
+	UserID int    `json:"user_id"`
+	RoomID int    `json:"room_id"`
+	Added  bool   `json:"added"`
+	Queued bool   `json:"queued"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ApplyResult reports a batch apply. When Issues is not empty nothing was applied.
+type ApplyResult struct {
+	Issues   []Issue   `json:"issues"`
+	Outcomes []Outcome `json:"outcomes"`
+	Added    int       `json:"added"`
+	Failed   int       `json:"failed"`
+}
+
+// Allocator loads students and rooms from the API, proposes plans and applies them
+type Allocator struct {
+	api *api.API
+}
+
+func NewAllocator(api *api.API) *Allocator {
+	return &Allocator{api: api}
+}
+
+type snapshot struct {
+	students  []models.User
+	rooms     []models.Room
+	occupants []models.User
+}
+
+func (a *Allocator) load(ctx context.Context) (*snapshot, error) {
+	unhoused, housed := false, true
+
+	students, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.User], error) {
+		return a.api.User().GetListUsers(ctx, page, "", "", "", "", "", string(models.UserRoleStudent), &unhoused)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load unassigned students: %w", err)
+	}
+
+	occupants, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.User], error) {
+		return a.api.User().GetListUsers(ctx, page, "", "", "", "", "", "", &housed)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room occupants: %w", err)
+	}
+
+	rooms, err := api.FetchAll(ctx, a.api.Room().GetListRooms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rooms: %w", err)
+	}
+
+	categories, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.RoomCategory], error) {
+		return a.api.RoomCategory().GetListRoomCategories(ctx, strconv.Itoa(page))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room categories: %w", err)
+	}
+	WithCategories(rooms, categories)
+
+	return &snapshot{students: students, rooms: rooms, occupants: occupants}, nil
+}
+
+// WithCategories fills in the category of every room whose category came
+// without a capacity, as room lists often leave it out
+func WithCategories(rooms []models.Room, categories []models.RoomCategory) {
+	byID := make(map[int]models.RoomCategory, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+	for i, r := range rooms {
+		if c, ok := byID[r.RoomCategoryID]; ok && r.RoomCategory.Capacity <= 0 {
+			rooms[i].RoomCategory = c
+		}
+	}
+}
+
+// Propose solves an allocation for every student who has no room yet
+func (a *Allocator) Propose(ctx context.Context, preferences []Preference) (*Plan, error) {
+	snap, err := a.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Solve(snap.students, snap.rooms, snap.occupants, preferences), nil
+}
+
+// Check validates an edited plan against the current rooms and students
+func (a *Allocator) Check(ctx context.Context, assignments []Assignment) ([]Issue, error) {
+	snap, err := a.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return check(snap, assignments), nil
+}
+
+func check(snap *snapshot, assignments []Assignment) []Issue {
+	issues := []Issue{}
+
+	students := make(map[int]models.User, len(snap.students))
+	for _, u := range snap.students {
+		students[u.ID] = u
+	}
+	rooms := make(map[int]models.Room, len(snap.rooms))
+	for _, r := range snap.rooms {
+		rooms[r.ID] = r
+	}
+
+	occupied := make(map[int]int)
+	gender := make(map[int]models.Gender)
+	mixed := make(map[int]bool)
+	join := func(roomID int, g models.Gender) {
+		occupied[roomID]++
+		if gender[roomID] == "" {
+			gender[roomID] = g
+		} else if gender[roomID] != g {
+			mixed[roomID] = true
+		}
+	}
+	for _, u := range snap.occupants {
+		if u.RoomID != nil {
+			join(*u.RoomID, u.Gender)
+		}
+	}
+	for id, r := range rooms {
+		occupied[id] = max(occupied[id], r.UserCount)
+	}
+	before := make(map[int]bool, len(mixed))
+	for id := range mixed {
+		before[id] = true
+	}
+
+	seen := make(map[int]bool, len(assignments))
+	for _, as := range assignments {
+		u, ok := students[as.UserID]
+		switch {
+		case seen[as.UserID]:
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: "Sinh viên được xếp nhiều lần"})
+			continue
+		case !ok:
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: "Sinh viên đã có phòng hoặc không tồn tại"})
+			continue
+		}
+		seen[as.UserID] = true
+
+		r, ok := rooms[as.RoomID]
+		switch {
+		case !ok:
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: "Phòng không tồn tại"})
+			continue
+		case r.Status == models.RoomStatusMaintenance:
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: fmt.Sprintf("Phòng %s đang bảo trì", r.RoomNumber)})
+			continue
+		case r.RoomCategory.Capacity <= 0:
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: fmt.Sprintf("Phòng %s chưa có sức chứa", r.RoomNumber)})
+			continue
+		}
+
+		join(as.RoomID, u.Gender)
+		if occupied[as.RoomID] > r.RoomCategory.Capacity {
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: fmt.Sprintf("Phòng %s vượt quá sức chứa %d", r.RoomNumber, r.RoomCategory.Capacity)})
+		}
+		if mixed[as.RoomID] && !before[as.RoomID] {
+			issues = append(issues, Issue{UserID: as.UserID, RoomID: as.RoomID, Message: fmt.Sprintf("Phòng %s sẽ có cả nam và nữ", r.RoomNumber)})
+		}
+	}
+	return issues
+}
+
+// Apply checks assignments again and adds every student to their room one by
+// one. Nothing is applied when the check finds issues. progress is called
+// after each assignment.
+func (a *Allocator) Apply(ctx context.Context, assignments []Assignment, progress func(done, total int)) (*ApplyResult, error) {
+	snap, err := a.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyResult{Issues: check(snap, assignments), Outcomes: []Outcome{}}
+	if len(result.Issues) > 0 {
+		return result, nil
+	}
+
+	for i, as := range assignments {
+		outcome := Outcome{UserID: as.UserID, RoomID: as.RoomID}
+		if err := ctx.Err(); err != nil {
+			outcome.Error = err.Error()
+		} else if resp, err := a.api.User().AddStudentToRoom(ctx, as.RoomID, as.UserID); err != nil {
+			outcome.Error = err.Error()
+		} else {
+			outcome.Added = true
+			outcome.Queued = resp.Queued
+		}
+
+		if outcome.Added {
+			result.Added++
+		} else {
+			result.Failed++
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+
+		if progress != nil {
+			progress(i+1, len(assignments))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package allocation
+
+import (
+	"changeme/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	categories := []models.RoomCategory{{ID: 1, Name: "Phòng 4", Capacity: 4}, {ID: 2, Name: "Chưa cấu hình"}}
+	student := models.User{ID: 1, FullName: "21520001", StudentCode: "21520001", Gender: models.GenderFemale}
+
+	tests := []struct {
+		name string
+		// room is listed without its category, as the room list returns it
+		room models.Room
+		want string
+	}{
+		{"category filled in from the list", models.Room{ID: 10, RoomNumber: "A101", RoomCategoryID: 1}, ""},
+		{"category without a capacity", models.Room{ID: 10, RoomNumber: "A101", RoomCategoryID: 2}, "chưa có sức chứa"},
+		{"unknown category", models.Room{ID: 10, RoomNumber: "A101", RoomCategoryID: 3}, "chưa có sức chứa"},
+		{"full room", models.Room{ID: 10, RoomNumber: "A101", RoomCategoryID: 1, UserCount: 4}, "vượt quá sức chứa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rooms := []models.Room{tt.room}
+			WithCategories(rooms, categories)
+
+			issues := check(&snapshot{students: []models.User{student}, rooms: rooms}, []Assignment{{UserID: 1, RoomID: 10}})
+			switch {
+			case tt.want == "" && len(issues) > 0:
+				t.Errorf("check() = %v, want no issues", issues)
+			case tt.want != "" && (len(issues) != 1 || !strings.Contains(issues[0].Message, tt.want)):
+				t.Errorf("check() = %v, want one issue about %q", issues, tt.want)
+			}
+		})
+	}
+}
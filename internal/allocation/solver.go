@@ -0,0 +1,389 @@
+package allocation
+
+import (
+	"changeme/internal/models"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Preference is what staff know about a student beyond the user record
+type Preference struct {
+	UserID int `json:"user_id"`
+	// CategoryIDs lists acceptable room categories, most wanted first
+	CategoryIDs []int `json:"category_ids"`
+	// Roommates are user IDs the student asked to share a room with
+	Roommates []int `json:"roommates"`
+	// Year overrides the intake year read from the student code
+	Year string `json:"year"`
+}
+
+// Assignment places one student in one room
+type Assignment struct {
+	UserID       int           `json:"user_id"`
+	StudentCode  string        `json:"student_code"`
+	FullName     string        `json:"full_name"`
+	Gender       models.Gender `json:"gender"`
+	Major        string        `json:"major"`
+	Year         string        `json:"year"`
+	RoomID       int           `json:"room_id"`
+	RoomNumber   string        `json:"room_number"`
+	CategoryName string        `json:"category_name"`
+	// Group numbers students placed together because they asked to be
+	Group   int      `json:"group"`
+	Reasons []string `json:"reasons"`
+}
+
+// Unplaced is a student the solver found no room for
+type Unplaced struct {
+	UserID      int           `json:"user_id"`
+	StudentCode string        `json:"student_code"`
+	FullName    string        `json:"full_name"`
+	Gender      models.Gender `json:"gender"`
+	Reason      string        `json:"reason"`
+}
+
+// RoomLoad is a room's occupancy before and after the plan
+type RoomLoad struct {
+	RoomID       int           `json:"room_id"`
+	RoomNumber   string        `json:"room_number"`
+	CategoryID   int           `json:"category_id"`
+	CategoryName string        `json:"category_name"`
+	Capacity     int           `json:"capacity"`
+	Occupied     int           `json:"occupied"`
+	Planned      int           `json:"planned"`
+	Gender       models.Gender `json:"gender"`
+}
+
+// Plan is a proposed allocation for staff to review and edit before applying
+type Plan struct {
+	Assignments []Assignment `json:"assignments"`
+	Unplaced    []Unplaced   `json:"unplaced"`
+	Rooms       []RoomLoad   `json:"rooms"`
+	Warnings    []string     `json:"warnings"`
+}
+
+// Weights of the soft constraints; gender and capacity are never traded off
+const (
+	scorePreferredCategory = 100
+	scoreAcceptedCategory  = 40
+	scoreSameMajor         = 10
+	scoreSameYear          = 6
+	scoreFillsRoom         = 3
+	penaltyUnusedBed       = 1
+)
+
+type room struct {
+	load    RoomLoad
+	free    int
+	majors  map[string]int
+	years   map[string]int
+	members int
+}
+
+type student struct {
+	user       models.User
+	major      string
+	year       string
+	categories []int
+}
+
+// Solve assigns students to rooms with free beds. occupants are the users
+// already housed, used to learn each room's gender, majors and years. Rooms
+// under maintenance are never used.
+func Solve(students []models.User, rooms []models.Room, occupants []models.User, preferences []Preference) *Plan {
+	plan := &Plan{Assignments: []Assignment{}, Unplaced: []Unplaced{}, Warnings: []string{}}
+
+	prefs := make(map[int]Preference, len(preferences))
+	for _, p := range preferences {
+		prefs[p.UserID] = p
+	}
+
+	byRoom := make(map[int][]models.User)
+	for _, u := range occupants {
+		if u.RoomID != nil {
+			byRoom[*u.RoomID] = append(byRoom[*u.RoomID], u)
+		}
+	}
+
+	pool := make([]*room, 0, len(rooms))
+	for _, r := range rooms {
+		housed := byRoom[r.ID]
+		if len(housed) == 0 {
+			housed = r.Users
+		}
+		slot := &room{
+			load: RoomLoad{
+				RoomID:       r.ID,
+				RoomNumber:   r.RoomNumber,
+				CategoryID:   r.RoomCategoryID,
+				CategoryName: r.RoomCategory.Name,
+				Capacity:     r.RoomCategory.Capacity,
+				Occupied:     max(r.UserCount, len(housed)),
+			},
+			majors: map[string]int{},
+			years:  map[string]int{},
+		}
+		slot.free = slot.load.Capacity - slot.load.Occupied
+		for _, u := range housed {
+			slot.add(u.Gender, major(u), Year(u.StudentCode))
+		}
+		if len(slices.Compact(genders(housed))) > 1 {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("Phòng %s đang có cả nam và nữ, không xếp thêm", r.RoomNumber))
+			slot.free = 0
+		}
+		if slot.load.Capacity <= 0 {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("Phòng %s chưa có sức chứa, không xếp thêm", r.RoomNumber))
+		}
+		if r.Status == models.RoomStatusMaintenance || slot.load.Capacity <= 0 {
+			slot.free = 0
+		}
+		pool = append(pool, slot)
+	}
+	slices.SortFunc(pool, func(a, b *room) int { return cmp.Compare(a.load.RoomNumber, b.load.RoomNumber) })
+
+	unassigned := make(map[int]*student, len(students))
+	for _, u := range students {
+		s := &student{user: u, major: major(u), year: Year(u.StudentCode)}
+		if p, ok := prefs[u.ID]; ok {
+			s.categories = p.CategoryIDs
+			if p.Year != "" {
+				s.year = p.Year
+			}
+		}
+		unassigned[u.ID] = s
+	}
+
+	largest := 0
+	for _, slot := range pool {
+		largest = max(largest, slot.load.Capacity)
+	}
+
+	groups := group(unassigned, preferences, largest, plan)
+	for n, members := range groups {
+		place(members, n+1, pool, plan)
+	}
+
+	for _, slot := range pool {
+		plan.Rooms = append(plan.Rooms, slot.load)
+	}
+	return plan
+}
+
+// group joins students who asked to room together. Requests across genders
+// or to students who are not being allocated are reported and ignored.
+func group(students map[int]*student, preferences []Preference, largest int, plan *Plan) [][]*student {
+	parent := make(map[int]int, len(students))
+	for id := range students {
+		parent[id] = id
+	}
+	var find func(int) int
+	find = func(id int) int {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	for _, p := range preferences {
+		s, ok := students[p.UserID]
+		if !ok {
+			continue
+		}
+		for _, mateID := range p.Roommates {
+			mate, ok := students[mateID]
+			switch {
+			case mateID == p.UserID:
+			case !ok:
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s muốn ở cùng sinh viên #%d nhưng người này không nằm trong danh sách xếp phòng", s.user.FullName, mateID))
+			case mate.user.Gender != s.user.Gender:
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("Không thể xếp %s và %s cùng phòng vì khác giới tính", s.user.FullName, mate.user.FullName))
+			default:
+				parent[find(p.UserID)] = find(mateID)
+			}
+		}
+	}
+
+	byRoot := make(map[int][]*student)
+	for id, s := range students {
+		root := find(id)
+		byRoot[root] = append(byRoot[root], s)
+	}
+
+	var groups [][]*student
+	for _, members := range byRoot {
+		slices.SortFunc(members, func(a, b *student) int { return cmp.Compare(a.user.StudentCode, b.user.StudentCode) })
+		if largest > 0 && len(members) > largest {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("Nhóm %d sinh viên của %s lớn hơn phòng lớn nhất (%d chỗ) nên được tách ra", len(members), members[0].user.FullName, largest))
+			for len(members) > largest {
+				groups = append(groups, members[:largest])
+				members = members[largest:]
+			}
+			groups = append(groups, members)
+			continue
+		}
+		groups = append(groups, members)
+	}
+
+	// Big groups first since they are the hardest to fit, then keep students
+	// of one major and year next to each other so they fill the same rooms
+	slices.SortFunc(groups, func(a, b []*student) int {
+		return cmp.Or(
+			cmp.Compare(len(b), len(a)),
+			cmp.Compare(a[0].user.Gender, b[0].user.Gender),
+			cmp.Compare(a[0].major, b[0].major),
+			cmp.Compare(a[0].year, b[0].year),
+			cmp.Compare(a[0].user.StudentCode, b[0].user.StudentCode),
+		)
+	})
+	return groups
+}
+
+// place puts a group in the best room that fits all of it, or splits it up
+// when no room does
+func place(members []*student, groupID int, pool []*room, plan *Plan) {
+	gender := members[0].user.Gender
+
+	var best *room
+	bestScore := 0
+	for _, slot := range pool {
+		if slot.free < len(members) || (slot.load.Gender != "" && slot.load.Gender != gender) {
+			continue
+		}
+		score := slot.score(members)
+		if best == nil || score > bestScore {
+			best, bestScore = slot, score
+		}
+	}
+
+	if best == nil {
+		if len(members) > 1 {
+			names := make([]string, len(members))
+			for i, s := range members {
+				names[i] = s.user.FullName
+			}
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("Không còn phòng đủ chỗ cho cả nhóm %s nên xếp riêng từng người", strings.Join(names, ", ")))
+			for _, s := range members {
+				place([]*student{s}, 0, pool, plan)
+			}
+			return
+		}
+
+		s := members[0]
+		plan.Unplaced = append(plan.Unplaced, Unplaced{
+			UserID:      s.user.ID,
+			StudentCode: s.user.StudentCode,
+			FullName:    s.user.FullName,
+			Gender:      s.user.Gender,
+			Reason:      "Không còn phòng trống phù hợp giới tính",
+		})
+		return
+	}
+
+	if len(members) == 1 {
+		groupID = 0
+	}
+	for _, s := range members {
+		plan.Assignments = append(plan.Assignments, Assignment{
+			UserID:       s.user.ID,
+			StudentCode:  s.user.StudentCode,
+			FullName:     s.user.FullName,
+			Gender:       s.user.Gender,
+			Major:        s.major,
+			Year:         s.year,
+			RoomID:       best.load.RoomID,
+			RoomNumber:   best.load.RoomNumber,
+			CategoryName: best.load.CategoryName,
+			Group:        groupID,
+			Reasons:      best.reasons(s, len(members)),
+		})
+		best.add(gender, s.major, s.year)
+		best.free--
+		best.load.Planned++
+	}
+}
+
+func (r *room) score(members []*student) int {
+	score := 0
+	for _, s := range members {
+		if i := slices.Index(s.categories, r.load.CategoryID); i == 0 {
+			score += scorePreferredCategory
+		} else if i > 0 {
+			score += scoreAcceptedCategory
+		}
+		if s.major != "" {
+			score += scoreSameMajor * r.majors[s.major]
+		}
+		if s.year != "" {
+			score += scoreSameYear * r.years[s.year]
+		}
+	}
+	if r.members > 0 {
+		score += scoreFillsRoom
+	}
+	return score - penaltyUnusedBed*(r.free-len(members))
+}
+
+func (r *room) reasons(s *student, groupSize int) []string {
+	reasons := []string{}
+	if groupSize > 1 {
+		reasons = append(reasons, fmt.Sprintf("Ở cùng nhóm %d người theo yêu cầu", groupSize))
+	}
+	if i := slices.Index(s.categories, r.load.CategoryID); i == 0 {
+		reasons = append(reasons, "Đúng loại phòng mong muốn")
+	} else if i > 0 {
+		reasons = append(reasons, "Loại phòng chấp nhận được")
+	} else if len(s.categories) > 0 {
+		reasons = append(reasons, "Không còn chỗ ở loại phòng mong muốn")
+	}
+	if s.major != "" && r.majors[s.major] > 0 {
+		reasons = append(reasons, "Cùng ngành "+s.major)
+	}
+	if s.year != "" && r.years[s.year] > 0 {
+		reasons = append(reasons, "Cùng khóa "+s.year)
+	}
+	return reasons
+}
+
+func (r *room) add(gender models.Gender, major, year string) {
+	if r.load.Gender == "" {
+		r.load.Gender = gender
+	}
+	if major != "" {
+		r.majors[major]++
+	}
+	if year != "" {
+		r.years[year]++
+	}
+	r.members++
+}
+
+// Year guesses the intake year from the first two digits of a student code,
+// which most Vietnamese universities put there (e.g. 21520001, B21DCCN001)
+func Year(studentCode string) string {
+	runes := []rune(studentCode)
+	for i := 0; i+1 < len(runes); i++ {
+		if unicode.IsDigit(runes[i]) && unicode.IsDigit(runes[i+1]) {
+			return string(runes[i : i+2])
+		}
+	}
+	return ""
+}
+
+func major(u models.User) string {
+	if u.Major == nil {
+		return ""
+	}
+	return strings.TrimSpace(*u.Major)
+}
+
+func genders(users []models.User) []models.Gender {
+	g := make([]models.Gender, len(users))
+	for i, u := range users {
+		g[i] = u.Gender
+	}
+	slices.Sort(g)
+	return g
+}
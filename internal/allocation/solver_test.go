@@ -0,0 +1,122 @@
+package allocation
+
+import (
+	"changeme/internal/models"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	roomID := func(id int) *int { return &id }
+	user := func(id int, gender models.Gender, code string) models.User {
+		return models.User{ID: id, FullName: code, StudentCode: code, Gender: gender}
+	}
+	room := func(id int, number string, categoryID, capacity int, status models.RoomStatus) models.Room {
+		return models.Room{
+			ID:             id,
+			RoomNumber:     number,
+			Status:         status,
+			RoomCategoryID: categoryID,
+			RoomCategory:   models.RoomCategory{ID: categoryID, Capacity: capacity},
+		}
+	}
+	housed := func(u models.User, id int) models.User {
+		u.RoomID = roomID(id)
+		return u
+	}
+
+	tests := []struct {
+		name        string
+		students    []models.User
+		rooms       []models.Room
+		occupants   []models.User
+		preferences []Preference
+		// want maps each student to a room ID, or 0 when left unplaced
+		want         map[int]int
+		wantWarnings int
+	}{
+		{
+			name:      "genders are kept apart",
+			students:  []models.User{user(1, models.GenderFemale, "21520001"), user(2, models.GenderMale, "21520002")},
+			rooms:     []models.Room{room(10, "A101", 1, 2, models.RoomStatusOccupied), room(11, "A102", 1, 2, models.RoomStatusAvailable)},
+			occupants: []models.User{housed(user(9, models.GenderMale, "20520009"), 10)},
+			want:      map[int]int{1: 11, 2: 10},
+		},
+		{
+			name:         "rooms under maintenance and without capacity are not used",
+			students:     []models.User{user(1, models.GenderFemale, "21520001")},
+			rooms:        []models.Room{room(10, "A101", 1, 4, models.RoomStatusMaintenance), room(11, "A102", 2, 0, models.RoomStatusAvailable)},
+			want:         map[int]int{1: 0},
+			wantWarnings: 1,
+		},
+		{
+			name:     "full rooms are not used",
+			students: []models.User{user(1, models.GenderMale, "21520001")},
+			rooms:    []models.Room{room(10, "A101", 1, 1, models.RoomStatusOccupied)},
+			occupants: []models.User{
+				housed(user(9, models.GenderMale, "20520009"), 10),
+			},
+			want: map[int]int{1: 0},
+		},
+		{
+			name:        "roommates are placed together",
+			students:    []models.User{user(1, models.GenderMale, "21520001"), user(2, models.GenderMale, "21520002"), user(3, models.GenderMale, "21520003")},
+			rooms:       []models.Room{room(10, "A101", 1, 1, models.RoomStatusAvailable), room(11, "A102", 1, 2, models.RoomStatusAvailable)},
+			preferences: []Preference{{UserID: 1, Roommates: []int{3}}},
+			want:        map[int]int{1: 11, 3: 11, 2: 10},
+		},
+		{
+			name:        "preferred category wins",
+			students:    []models.User{user(1, models.GenderFemale, "21520001")},
+			rooms:       []models.Room{room(10, "A101", 1, 2, models.RoomStatusAvailable), room(11, "B201", 2, 4, models.RoomStatusAvailable)},
+			preferences: []Preference{{UserID: 1, CategoryIDs: []int{2}}},
+			want:        map[int]int{1: 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Solve(tt.students, tt.rooms, tt.occupants, tt.preferences)
+
+			got := make(map[int]int)
+			for _, a := range plan.Assignments {
+				got[a.UserID] = a.RoomID
+			}
+			for _, u := range plan.Unplaced {
+				got[u.UserID] = 0
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Solve() placed %v, want %v", got, tt.want)
+			}
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("Solve() placed %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			if len(plan.Warnings) != tt.wantWarnings {
+				t.Errorf("Solve() warned %v, want %d warnings", plan.Warnings, tt.wantWarnings)
+			}
+
+			for _, load := range plan.Rooms {
+				if load.Occupied+load.Planned > load.Capacity && load.Planned > 0 {
+					t.Errorf("room %s is planned over capacity: %d+%d of %d", load.RoomNumber, load.Occupied, load.Planned, load.Capacity)
+				}
+			}
+		})
+	}
+}
+
+func TestYear(t *testing.T) {
+	tests := map[string]string{
+		"21520001":   "21",
+		"B21DCCN001": "21",
+		"SV-1":       "",
+		"":           "",
+	}
+	for code, want := range tests {
+		if got := Year(code); got != want {
+			t.Errorf("Year(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
 	"fmt"
 )
 
@@ -15,33 +17,38 @@ func NewAmenitiesAPI(client *client.Client) *AmenitiesAPI {
 	}
 }
 
-func (c *AmenitiesAPI) GetAmenityDetails(amenityID string) (*client.Response, error) {
-	return c.client.R().
+func (c *AmenitiesAPI) GetAmenityDetails(ctx context.Context, amenityID string) (*Response[models.Amenity], error) {
+	return decode[models.Amenity](c.client.R().
+		SetContext(ctx).
 		SetPathParam("id", amenityID).
-		Get("/amenities/{id}")
+		Get("/amenities/{id}"))
 }
 
-func (c *AmenitiesAPI) GetListAmenities(page int) (*client.Response, error) {
-	return c.client.R().
+func (c *AmenitiesAPI) GetListAmenities(ctx context.Context, page int) (*Response[[]models.Amenity], error) {
+	return decode[[]models.Amenity](c.client.R().
+		SetContext(ctx).
 		SetQueryParam("page", fmt.Sprintf("%d", page)).
-		Get("/amenities")
+		Get("/amenities"))
 }
 
-func (c *AmenitiesAPI) CreateAmenity(amenityData map[string]interface{}) (*client.Response, error) {
-	return c.client.R().
+func (c *AmenitiesAPI) CreateAmenity(ctx context.Context, amenityData map[string]interface{}) (*Response[models.Amenity], error) {
+	return decode[models.Amenity](c.client.R().
+		SetContext(ctx).
 		SetBody(amenityData).
-		Post("/amenities")
+		Post("/amenities"))
 }
 
-func (c *AmenitiesAPI) DeleteAmenity(amenityID string) (*client.Response, error) {
-	return c.client.R().
+func (c *AmenitiesAPI) DeleteAmenity(ctx context.Context, amenityID string) (*Response[any], error) {
+	return decode[any](c.client.R().
+		SetContext(ctx).
 		SetPathParam("id", amenityID).
-		Delete("/amenities/{id}")
+		Delete("/amenities/{id}"))
 }
 
-func (c *AmenitiesAPI) UpdateAmenity(amenityID string, amenityData map[string]interface{}) (*client.Response, error) {
-	return c.client.R().
+func (c *AmenitiesAPI) UpdateAmenity(ctx context.Context, amenityID string, amenityData map[string]interface{}) (*Response[models.Amenity], error) {
+	return decode[models.Amenity](c.client.R().
+		SetContext(ctx).
 		SetPathParam("id", amenityID).
 		SetBody(amenityData).
-		Patch("/amenities/{id}")
+		Patch("/amenities/{id}"))
 }
@@ -2,11 +2,14 @@ package api
 
 import "changeme/internal/client"
 
-type Response struct {
-	Success bool          `json:"success"`
-	Message string        `json:"message"`
-	Data    []interface{} `json:"data"`
-}
+// Kinds of writes that are queued in the outbox while the server is unreachable
+const (
+	OperationCreateMaintenanceHistory = "create_maintenance_history"
+	OperationUpdateRoom               = "update_room"
+	OperationAddStudentToRoom         = "add_student_to_room"
+	OperationUpdateUserStatus         = "update_user_status"
+	OperationCreateMeterReading       = "create_meter_reading"
+)
 
 type API struct {
 	apiAuth               *AuthAPI
@@ -16,6 +19,11 @@ type API struct {
 	amenitiesAPI          *AmenitiesAPI
 	roomCategoryAPI       *RoomCategoryAPI
 	maintenanceHistoryAPI *MaintenanceHistoryAPI
+	invoiceAPI            *InvoiceAPI
+	paymentAPI            *PaymentAPI
+	meterReadingAPI       *MeterReadingAPI
+	depositAPI            *DepositAPI
+	roomTransferAPI       *RoomTransferAPI
 }
 
 func NewAPI(client *client.Client) *API {
@@ -27,6 +35,11 @@ func NewAPI(client *client.Client) *API {
 		amenitiesAPI:          NewAmenitiesAPI(client),
 		roomCategoryAPI:       NewRoomCategoryAPI(client),
 		maintenanceHistoryAPI: NewMaintenanceHistoryAPI(client),
+		invoiceAPI:            NewInvoiceAPI(client),
+		paymentAPI:            NewPaymentAPI(client),
+		meterReadingAPI:       NewMeterReadingAPI(client),
+		depositAPI:            NewDepositAPI(client),
+		roomTransferAPI:       NewRoomTransferAPI(client),
 	}
 }
 
@@ -57,3 +70,23 @@ func (a *API) RoomCategory() *RoomCategoryAPI {
 func (a *API) MaintenanceHistory() *MaintenanceHistoryAPI {
 	return a.maintenanceHistoryAPI
 }
+
+func (a *API) Invoice() *InvoiceAPI {
+	return a.invoiceAPI
+}
+
+func (a *API) Payment() *PaymentAPI {
+	return a.paymentAPI
+}
+
+func (a *API) MeterReading() *MeterReadingAPI {
+	return a.meterReadingAPI
+}
+
+func (a *API) Deposit() *DepositAPI {
+	return a.depositAPI
+}
+
+func (a *API) RoomTransfer() *RoomTransferAPI {
+	return a.roomTransferAPI
+}
@@ -2,6 +2,11 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 )
 
 type AuthAPI struct {
@@ -14,44 +19,56 @@ func NewAuthAPI(client *client.Client) *AuthAPI {
 	}
 }
 
-func (a *AuthAPI) Login(email, password string) (*client.Response, error) {
+func (a *AuthAPI) Login(ctx context.Context, email, password string) (*Response[models.Login], error) {
 	body := map[string]string{
 		"email":    email,
 		"password": password,
 		"type":     "manager",
 	}
 
-	resp, err := a.client.R().
+	return decode[models.Login](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/login")
-
-	if err != nil {
-		return nil, err
-	}
+		Post("/auth/login"))
+}
 
-	if resp.IsError() {
-		return resp, nil
+func (a *AuthAPI) RefreshToken(ctx context.Context, refreshToken string) (*Response[models.AuthToken], error) {
+	body := map[string]string{
+		"refresh_token": refreshToken,
 	}
 
-	return resp, nil
+	return decode[models.AuthToken](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
+		SetBody(body).
+		Post("/auth/refresh-token"))
 }
 
-func (a *AuthAPI) Logout() (*client.Response, error) {
-	resp, err := a.client.R().
-		Post("/auth/logout")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
+// TokenRefresher adapts RefreshToken to the client's token source. A rejected
+// refresh token ends the session; network and server errors do not.
+func (a *AuthAPI) TokenRefresher() client.RefreshFunc {
+	return func(ctx context.Context, refreshToken string) (*client.Token, error) {
+		resp, err := a.RefreshToken(ctx, refreshToken)
+		if err != nil {
+			var apiErr *Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+				return nil, fmt.Errorf("%w: %v", client.ErrSessionExpired, err)
+			}
+			return nil, err
+		}
+
+		return client.NewToken(resp.Data.AccessToken, resp.Data.RefreshToken), nil
 	}
+}
 
-	return resp, nil
+func (a *AuthAPI) Logout(ctx context.Context) (*Response[any], error) {
+	return decode[any](a.client.R().
+		SetContext(ctx).
+		Post("/auth/logout"))
 }
 
-func (a *AuthAPI) Register(email, password, full_name, phone string) (*client.Response, error) {
+func (a *AuthAPI) Register(ctx context.Context, email, password, full_name, phone string) (*Response[models.User], error) {
 	body := map[string]string{
 		"email":     email,
 		"password":  password,
@@ -60,111 +77,61 @@ func (a *AuthAPI) Register(email, password, full_name, phone string) (*client.Re
 		"role":      "staff",
 	}
 
-	resp, err := a.client.R().
+	return decode[models.User](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/register")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+		Post("/auth/register"))
 }
 
-func (a *AuthAPI) GetMe() (*client.Response, error) {
-	resp, err := a.client.R().
-		Get("/auth/me")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+func (a *AuthAPI) GetMe(ctx context.Context) (*Response[models.User], error) {
+	return decode[models.User](a.client.R().
+		SetContext(ctx).
+		Get("/auth/me"))
 }
 
-func (a *AuthAPI) VerifyAccount(token, email string) (*client.Response, error) {
+func (a *AuthAPI) VerifyAccount(ctx context.Context, token, email string) (*Response[any], error) {
 	body := map[string]string{
 		"token": token,
 		"email": email,
 	}
 
-	resp, err := a.client.R().
+	return decode[any](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/verify-account")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+		Post("/auth/verify-account"))
 }
 
-func (a *AuthAPI) ResendVerifyAccount(email string) (*client.Response, error) {
+func (a *AuthAPI) ResendVerifyAccount(ctx context.Context, email string) (*Response[any], error) {
 	body := map[string]string{
 		"email": email,
 	}
 
-	resp, err := a.client.R().
+	return decode[any](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/resend-verify-account")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+		Post("/auth/resend-verify-account"))
 }
 
-func (a *AuthAPI) SendForgotPasswordEmail(email string) (*client.Response, error) {
+func (a *AuthAPI) SendForgotPasswordEmail(ctx context.Context, email string) (*Response[any], error) {
 	body := map[string]string{
 		"email": email,
 		"type":  "manager",
 	}
 
-	resp, err := a.client.R().
+	return decode[any](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/forgot-password")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+		Post("/auth/forgot-password"))
 }
 
-func (a *AuthAPI) ResetPassword(body map[string]interface{}) (*client.Response, error) {
-
-	resp, err := a.client.R().
+func (a *AuthAPI) ResetPassword(ctx context.Context, body map[string]interface{}) (*Response[any], error) {
+	return decode[any](a.client.R().
+		SetContext(ctx).
+		SkipAuth().
 		SetBody(body).
-		Post("/auth/reset-password")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+		Post("/auth/reset-password"))
 }
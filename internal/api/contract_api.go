@@ -2,6 +2,8 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
 	"fmt"
 )
 
@@ -15,25 +17,81 @@ func NewContractAPI(client *client.Client) *ContractAPI {
 	}
 }
 
-func (c *ContractAPI) GetContractDetails(contractID int) (*client.Response, error) {
-	return c.client.R().
+func (c *ContractAPI) GetContractDetails(ctx context.Context, contractID int) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetPathParam("id", fmt.Sprintf("%d", contractID)).
-		Get("/contracts/{id}")
+		Get("/contracts/{id}"))
 }
 
-func (c *ContractAPI) GetListContracts(page int, keyword *string) (*client.Response, error) {
+func (c *ContractAPI) GetListContracts(ctx context.Context, page int, keyword *string) (*Response[[]models.Contract], error) {
 	req := c.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetQueryParam("page", fmt.Sprintf("%d", page))
 
 	if keyword != nil {
 		req.SetQueryParam("keyword", *keyword)
 	}
 
-	return req.Get("/contracts")
+	return decode[[]models.Contract](req.Get("/contracts"))
 }
 
-func (c *ContractAPI) CreateContract(contractData map[string]interface{}) (*client.Response, error) {
-	return c.client.R().
+func (c *ContractAPI) CreateContract(ctx context.Context, contractData map[string]interface{}) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
 		SetBody(contractData).
-		Post("/contracts")
+		Post("/contracts"))
+}
+
+func (c *ContractAPI) UpdateContractStatus(ctx context.Context, contractID int, status models.ContractStatus) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(map[string]string{
+			"status": string(status),
+		}).
+		Put("/contracts/{id}/status"))
+}
+
+// UpdateContract changes the given fields of a contract, e.g. end_date and status
+func (c *ContractAPI) UpdateContract(ctx context.Context, contractID int, contractData map[string]interface{}) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(contractData).
+		Patch("/contracts/{id}"))
+}
+
+// RenewContract opens the next period of a contract for the same student and
+// room. With carry_over the deposit moves to the new contract.
+func (c *ContractAPI) RenewContract(ctx context.Context, contractID int, renewalData map[string]interface{}) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(renewalData).
+		Post("/contracts/{id}/renew"))
+}
+
+// TerminateContract ends a contract early on end_date, recording the reason and any penalty charged
+func (c *ContractAPI) TerminateContract(ctx context.Context, contractID int, terminationData map[string]interface{}) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(terminationData).
+		Post("/contracts/{id}/terminate"))
+}
+
+// CancelContract voids a contract that has not taken effect
+func (c *ContractAPI) CancelContract(ctx context.Context, contractID int, reason string) (*Response[models.Contract], error) {
+	return decode[models.Contract](c.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(map[string]string{
+			"reason": reason,
+		}).
+		Post("/contracts/{id}/cancel"))
 }
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+)
+
+type DepositAPI struct {
+	client *client.Client
+}
+
+func NewDepositAPI(client *client.Client) *DepositAPI {
+	return &DepositAPI{
+		client: client,
+	}
+}
+
+// GetContractDeposit returns the deposit ledger of a contract; it is a 404 until a deposit is recorded
+func (d *DepositAPI) GetContractDeposit(ctx context.Context, contractID int) (*Response[models.Deposit], error) {
+	return decode[models.Deposit](d.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		Get("/contracts/{id}/deposit"))
+}
+
+// AddDepositEntry records money received, a deduction or a refund on a contract's deposit
+func (d *DepositAPI) AddDepositEntry(ctx context.Context, contractID int, entryData map[string]interface{}) (*Response[models.Deposit], error) {
+	return decode[models.Deposit](d.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(entryData).
+		Post("/contracts/{id}/deposit/entries"))
+}
+
+func (d *DepositAPI) SettleDeposit(ctx context.Context, contractID int) (*Response[models.Deposit], error) {
+	return decode[models.Deposit](d.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		Post("/contracts/{id}/deposit/settle"))
+}
+
+// TransferDeposit moves a held deposit with its entries to another contract of the same student
+func (d *DepositAPI) TransferDeposit(ctx context.Context, contractID int, toContractID int) (*Response[models.Deposit], error) {
+	return decode[models.Deposit](d.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", contractID)).
+		SetBody(map[string]interface{}{
+			"contract_id": toContractID,
+		}).
+		Post("/contracts/{id}/deposit/transfer"))
+}
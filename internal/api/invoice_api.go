@@ -0,0 +1,118 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+)
+
+// InvoiceQuery filters the invoice list. Zero values are not sent; dates use YYYY-MM-DD.
+type InvoiceQuery struct {
+	Page     int    `json:"page"`
+	Keyword  string `json:"keyword"`
+	Status   string `json:"status"`
+	Type     string `json:"type"`
+	UserID   int    `json:"user_id"`
+	Period   string `json:"billing_period"`
+	FromDate string `json:"from_date"`
+	ToDate   string `json:"to_date"`
+}
+
+type InvoiceAPI struct {
+	client *client.Client
+}
+
+func NewInvoiceAPI(client *client.Client) *InvoiceAPI {
+	return &InvoiceAPI{
+		client: client,
+	}
+}
+
+func (i *InvoiceAPI) GetInvoiceDetails(ctx context.Context, invoiceID int) (*Response[models.Invoice], error) {
+	return decode[models.Invoice](i.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetPathParam("id", fmt.Sprintf("%d", invoiceID)).
+		Get("/invoices/{id}"))
+}
+
+func (i *InvoiceAPI) GetListInvoices(ctx context.Context, query InvoiceQuery) (*Response[[]models.Invoice], error) {
+	req := i.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetQueryParam("page", fmt.Sprintf("%d", max(query.Page, 1)))
+
+	if query.Keyword != "" {
+		req.SetQueryParam("keyword", query.Keyword)
+	}
+	if query.Status != "" {
+		req.SetQueryParam("status", query.Status)
+	}
+	if query.Type != "" {
+		req.SetQueryParam("type", query.Type)
+	}
+	if query.UserID != 0 {
+		req.SetQueryParam("user_id", fmt.Sprintf("%d", query.UserID))
+	}
+	if query.Period != "" {
+		req.SetQueryParam("billing_period", query.Period)
+	}
+	if query.FromDate != "" {
+		req.SetQueryParam("from_date", query.FromDate)
+	}
+	if query.ToDate != "" {
+		req.SetQueryParam("to_date", query.ToDate)
+	}
+
+	return decode[[]models.Invoice](req.Get("/invoices"))
+}
+
+func (i *InvoiceAPI) CreateInvoice(ctx context.Context, invoiceData map[string]interface{}) (*Response[models.Invoice], error) {
+	return decode[models.Invoice](i.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetBody(invoiceData).
+		Post("/invoices"))
+}
+
+func (i *InvoiceAPI) VoidInvoice(ctx context.Context, invoiceID int, reason string) (*Response[models.Invoice], error) {
+	return decode[models.Invoice](i.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetPathParam("id", fmt.Sprintf("%d", invoiceID)).
+		SetBody(map[string]string{
+			"reason": reason,
+		}).
+		Post("/invoices/{id}/void"))
+}
+
+func (i *InvoiceAPI) UpdateInvoiceStatus(ctx context.Context, invoiceID int, status models.InvoiceStatus) (*Response[models.Invoice], error) {
+	return decode[models.Invoice](i.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", invoiceID)).
+		SetBody(map[string]string{
+			"status": string(status),
+		}).
+		Put("/invoices/{id}/status"))
+}
+
+// SendInvoiceReminder asks the server to notify the student about an overdue invoice
+func (i *InvoiceAPI) SendInvoiceReminder(ctx context.Context, invoiceID int, level string) (*Response[any], error) {
+	return decode[any](i.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetPathParam("id", fmt.Sprintf("%d", invoiceID)).
+		SetBody(map[string]string{
+			"level": level,
+		}).
+		Post("/invoices/{id}/reminders"))
+}
+
+// GetInvoiceReminders lists the notices already sent for an invoice, from any machine
+func (i *InvoiceAPI) GetInvoiceReminders(ctx context.Context, invoiceID int) (*Response[[]models.InvoiceReminder], error) {
+	return decode[[]models.InvoiceReminder](i.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", invoiceID)).
+		Get("/invoices/{id}/reminders"))
+}
@@ -1,6 +1,10 @@
 package api
 
-import "changeme/internal/client"
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+)
 
 type MaintenanceHistoryAPI struct {
 	client *client.Client
@@ -12,35 +16,41 @@ func NewMaintenanceHistoryAPI(client *client.Client) *MaintenanceHistoryAPI {
 	}
 }
 
-func (m *MaintenanceHistoryAPI) GetMaintenanceHistoryDetails(historyID string) (*client.Response, error) {
-	return m.client.R().
+func (m *MaintenanceHistoryAPI) GetMaintenanceHistoryDetails(ctx context.Context, historyID string) (*Response[models.MaintenanceHistory], error) {
+	return decode[models.MaintenanceHistory](m.client.R().
+		SetContext(ctx).
 		SetPathParam("id", historyID).
-		Get("/maintenance-histories/{id}")
+		Get("/maintenance-histories/{id}"))
 }
 
-func (m *MaintenanceHistoryAPI) GetListMaintenanceHistories(page string, roomID string) (*client.Response, error) {
+func (m *MaintenanceHistoryAPI) GetListMaintenanceHistories(ctx context.Context, page string, roomID string) (*Response[[]models.MaintenanceHistory], error) {
 	req := m.client.R().
+		SetContext(ctx).
 		SetQueryParam("page", page).
 		SetQueryParam("room_id", roomID)
 
-	return req.Get("/maintenance-histories")
+	return decode[[]models.MaintenanceHistory](req.Get("/maintenance-histories"))
 }
 
-func (m *MaintenanceHistoryAPI) CreateMaintenanceHistory(historyData map[string]interface{}) (*client.Response, error) {
-	return m.client.R().
+func (m *MaintenanceHistoryAPI) CreateMaintenanceHistory(ctx context.Context, historyData map[string]interface{}) (*Response[models.MaintenanceHistory], error) {
+	return decode[models.MaintenanceHistory](m.client.R().
+		SetContext(ctx).
+		SetQueueable(OperationCreateMaintenanceHistory).
 		SetBody(historyData).
-		Post("/maintenance-histories")
+		Post("/maintenance-histories"))
 }
 
-func (m *MaintenanceHistoryAPI) DeleteMaintenanceHistory(historyID string) (*client.Response, error) {
-	return m.client.R().
+func (m *MaintenanceHistoryAPI) DeleteMaintenanceHistory(ctx context.Context, historyID string) (*Response[any], error) {
+	return decode[any](m.client.R().
+		SetContext(ctx).
 		SetPathParam("id", historyID).
-		Delete("/maintenance-histories/{id}")
+		Delete("/maintenance-histories/{id}"))
 }
 
-func (m *MaintenanceHistoryAPI) UpdateMaintenanceHistory(historyID string, historyData map[string]interface{}) (*client.Response, error) {
-	return m.client.R().
+func (m *MaintenanceHistoryAPI) UpdateMaintenanceHistory(ctx context.Context, historyID string, historyData map[string]interface{}) (*Response[models.MaintenanceHistory], error) {
+	return decode[models.MaintenanceHistory](m.client.R().
+		SetContext(ctx).
 		SetPathParam("id", historyID).
 		SetBody(historyData).
-		Patch("/maintenance-histories/{id}")
+		Patch("/maintenance-histories/{id}"))
 }
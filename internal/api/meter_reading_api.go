@@ -0,0 +1,51 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+)
+
+type MeterReadingAPI struct {
+	client *client.Client
+}
+
+func NewMeterReadingAPI(client *client.Client) *MeterReadingAPI {
+	return &MeterReadingAPI{
+		client: client,
+	}
+}
+
+// GetListMeterReadings lists readings, optionally narrowed to a room and a billing period (YYYY-MM)
+func (m *MeterReadingAPI) GetListMeterReadings(ctx context.Context, page int, roomID *int, period string) (*Response[[]models.MeterReading], error) {
+	req := m.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetQueryParam("page", fmt.Sprintf("%d", page))
+
+	if roomID != nil {
+		req.SetQueryParam("room_id", fmt.Sprintf("%d", *roomID))
+	}
+	if period != "" {
+		req.SetQueryParam("billing_period", period)
+	}
+
+	return decode[[]models.MeterReading](req.Get("/meter-readings"))
+}
+
+func (m *MeterReadingAPI) CreateMeterReading(ctx context.Context, readingData map[string]interface{}) (*Response[models.MeterReading], error) {
+	return decode[models.MeterReading](m.client.R().
+		SetContext(ctx).
+		SetQueueable(OperationCreateMeterReading).
+		SetBody(readingData).
+		Post("/meter-readings"))
+}
+
+func (m *MeterReadingAPI) UpdateMeterReading(ctx context.Context, readingID int, readingData map[string]interface{}) (*Response[models.MeterReading], error) {
+	return decode[models.MeterReading](m.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", readingID)).
+		SetBody(readingData).
+		Patch("/meter-readings/{id}"))
+}
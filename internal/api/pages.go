@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// maxPages stops FetchAll from looping forever on a server that ignores the page parameter
+const maxPages = 1000
+
+// ErrStale is returned by FetchAll when a page was served from the offline
+// cache, and by Live. Pages cached at different times cannot be combined into
+// one list, and the engines that read whole lists write money and occupancy
+// back from them.
+var ErrStale = errors.New("only an offline copy is available")
+
+// FetchAll walks a paginated list endpoint from page 1 until an empty page or
+// until Total items have been collected. It fails with ErrStale when the
+// server is unreachable and a page comes from the cache.
+func FetchAll[T any](ctx context.Context, fetch func(ctx context.Context, page int) (*Response[[]T], error)) ([]T, error) {
+	var all []T
+	for page := 1; page <= maxPages; page++ {
+		resp, err := fetch(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		if resp.Stale {
+			return nil, fmt.Errorf("page %d: %w", page, ErrStale)
+		}
+		if len(resp.Data) == 0 {
+			return all, nil
+		}
+
+		all = append(all, resp.Data...)
+		if resp.Total > 0 && len(all) >= resp.Total {
+			return all, nil
+		}
+	}
+	return nil, fmt.Errorf("list has more than %d pages", maxPages)
+}
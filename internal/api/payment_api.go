@@ -0,0 +1,45 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+)
+
+type PaymentAPI struct {
+	client *client.Client
+}
+
+func NewPaymentAPI(client *client.Client) *PaymentAPI {
+	return &PaymentAPI{
+		client: client,
+	}
+}
+
+func (p *PaymentAPI) GetPaymentDetails(ctx context.Context, paymentID int) (*Response[models.Payment], error) {
+	return decode[models.Payment](p.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", paymentID)).
+		Get("/payments/{id}"))
+}
+
+// GetStudentPayments returns a student's payment history, newest first
+func (p *PaymentAPI) GetStudentPayments(ctx context.Context, userID int, page int) (*Response[[]models.Payment], error) {
+	return decode[[]models.Payment](p.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetQueryParam("user_id", fmt.Sprintf("%d", userID)).
+		SetQueryParam("page", fmt.Sprintf("%d", page)).
+		Get("/payments"))
+}
+
+// RecordPayment records a full or partial payment against an invoice; the
+// server derives the invoice status from the amount paid so far
+func (p *PaymentAPI) RecordPayment(ctx context.Context, paymentData map[string]interface{}) (*Response[models.Payment], error) {
+	return decode[models.Payment](p.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetBody(paymentData).
+		Post("/payments"))
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// requiredTag marks a model field whose JSON key must be present in every
+// payload. Other fields may be missing, so the backend can add or omit
+// optional fields without breaking decoding, while a renamed key the UI
+// depends on still fails loudly.
+const requiredTag = "api"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// checkRequired walks raw alongside t and returns an error naming the first
+// required key that is missing. Nested objects are only checked when present.
+func checkRequired(raw json.RawMessage, t reflect.Type, path string) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil
+		}
+		for i, item := range items {
+			if err := checkRequired(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Struct && t != timeType:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+
+			value, ok := object[name]
+			if !ok {
+				if field.Tag.Get(requiredTag) == "required" {
+					return fmt.Errorf("missing required field %q", path+"."+name)
+				}
+				continue
+			}
+			if err := checkRequired(value, field.Type, path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Response is the envelope every backend endpoint wraps its payload in.
+// Total is only populated by paginated list endpoints. The remaining fields
+// are set locally: Stale and CachedAt when the payload comes from the offline
+// cache, Queued and OperationID when a write was stored in the outbox.
+type Response[T any] struct {
+	Success     bool       `json:"success"`
+	Message     string     `json:"message"`
+	Data        T          `json:"data"`
+	Total       int        `json:"total"`
+	Stale       bool       `json:"stale,omitempty"`
+	CachedAt    *time.Time `json:"cached_at,omitempty"`
+	Queued      bool       `json:"queued,omitempty"`
+	OperationID string     `json:"operation_id,omitempty"`
+}
+
+// Error is returned when the backend answers with a non-2xx status.
+type Error struct {
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("api: request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("api: %s (status %d)", e.Message, e.StatusCode)
+}
+
+// DecodeError is returned when a successful response does not match the
+// expected model, e.g. after a backend field rename.
+type DecodeError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("api: failed to decode response (status %d): %v", e.StatusCode, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decode turns a raw client response into a typed envelope. Unknown fields are
+// ignored, but a missing required field is an error so that a renamed key
+// surfaces instead of leaving empty values.
+func decode[T any](resp *client.Response, err error) (*Response[T], error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, newError(resp)
+	}
+
+	if resp.Queued {
+		return &Response[T]{
+			Success:     true,
+			Message:     "queued until the server is reachable",
+			Queued:      true,
+			OperationID: resp.QueuedID,
+		}, nil
+	}
+
+	var out Response[T]
+	if err := json.Unmarshal(resp.RawBody(), &out); err != nil {
+		return nil, &DecodeError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(resp.RawBody(), &envelope); err != nil {
+		return nil, &DecodeError{StatusCode: resp.StatusCode, Err: err}
+	}
+	if err := checkRequired(envelope.Data, reflect.TypeFor[T](), "data"); err != nil {
+		return nil, &DecodeError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	out.Stale = resp.Stale
+	if !resp.CachedAt.IsZero() {
+		out.CachedAt = &resp.CachedAt
+	}
+
+	return &out, nil
+}
+
+// Live rejects a response served from the offline cache with ErrStale. It
+// wraps reads that money or occupancy decisions are made from.
+func Live[T any](resp *Response[T], err error) (*Response[T], error) {
+	if err == nil && resp.Stale {
+		return nil, ErrStale
+	}
+	return resp, err
+}
+
+func newError(resp *client.Response) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.RawBody(), &body); err == nil && body.Message != "" {
+		apiErr.Message = body.Message
+	} else {
+		apiErr.Message = resp.Status
+	}
+
+	return apiErr
+}
+
+// FormatError shapes an error returned by a Wails binding into the object the
+// frontend promise rejects with. Backend errors keep the server message and
+// status code so screens can show the message as-is.
+func FormatError(err error) any {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+// IsNotFound reports whether err is a 404 from the backend
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
 	"fmt"
 )
 
@@ -15,33 +17,42 @@ func NewRoomAPI(client *client.Client) *RoomAPI {
 	}
 }
 
-func (r *RoomAPI) GetRoomDetails(roomID int) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomAPI) GetRoomDetails(ctx context.Context, roomID int) (*Response[models.Room], error) {
+	return decode[models.Room](r.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetPathParam("id", fmt.Sprintf("%d", roomID)).
-		Get("/rooms/{id}")
+		Get("/rooms/{id}"))
 }
 
-func (r *RoomAPI) GetListRooms(page int) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomAPI) GetListRooms(ctx context.Context, page int) (*Response[[]models.Room], error) {
+	return decode[[]models.Room](r.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetQueryParam("page", fmt.Sprintf("%d", page)).
-		Get("/rooms")
+		Get("/rooms"))
 }
 
-func (r *RoomAPI) CreateRoom(roomData map[string]interface{}) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomAPI) CreateRoom(ctx context.Context, roomData map[string]interface{}) (*Response[models.Room], error) {
+	return decode[models.Room](r.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
 		SetBody(roomData).
-		Post("/rooms")
+		Post("/rooms"))
 }
 
-func (r *RoomAPI) DeleteRoom(roomID int) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomAPI) DeleteRoom(ctx context.Context, roomID int) (*Response[any], error) {
+	return decode[any](r.client.R().
+		SetContext(ctx).
 		SetPathParam("id", fmt.Sprintf("%d", roomID)).
-		Delete("/rooms/{id}")
+		Delete("/rooms/{id}"))
 }
 
-func (r *RoomAPI) UpdateRoom(roomID int, roomData map[string]interface{}) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomAPI) UpdateRoom(ctx context.Context, roomID int, roomData map[string]interface{}) (*Response[models.Room], error) {
+	return decode[models.Room](r.client.R().
+		SetContext(ctx).
+		SetQueueable(OperationUpdateRoom).
 		SetPathParam("id", fmt.Sprintf("%d", roomID)).
 		SetBody(roomData).
-		Patch("/rooms/{id}")
+		Patch("/rooms/{id}"))
 }
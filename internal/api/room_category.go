@@ -2,6 +2,8 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
 )
 
 type RoomCategoryAPI struct {
@@ -14,20 +16,23 @@ func NewRoomCategoryAPI(client *client.Client) *RoomCategoryAPI {
 	}
 }
 
-func (r *RoomCategoryAPI) GetRoomCategoryDetails(categoryID string) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomCategoryAPI) GetRoomCategoryDetails(ctx context.Context, categoryID string) (*Response[models.RoomCategory], error) {
+	return decode[models.RoomCategory](r.client.R().
+		SetContext(ctx).
 		SetPathParam("id", categoryID).
-		Get("/room-categories/{id}")
+		Get("/room-categories/{id}"))
 }
 
-func (r *RoomCategoryAPI) GetListRoomCategories(page string) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomCategoryAPI) GetListRoomCategories(ctx context.Context, page string) (*Response[[]models.RoomCategory], error) {
+	return decode[[]models.RoomCategory](r.client.R().
+		SetContext(ctx).
 		SetQueryParam("page", (page)).
-		Get("/room-categories")
+		Get("/room-categories"))
 }
 
-func (r *RoomCategoryAPI) CreateRoomCategory(categoryData map[string]interface{}) (*client.Response, error) {
-	return r.client.R().
+func (r *RoomCategoryAPI) CreateRoomCategory(ctx context.Context, categoryData map[string]interface{}) (*Response[models.RoomCategory], error) {
+	return decode[models.RoomCategory](r.client.R().
+		SetContext(ctx).
 		SetBody(categoryData).
-		Post("/room-categories")
+		Post("/room-categories"))
 }
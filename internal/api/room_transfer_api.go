@@ -0,0 +1,49 @@
+package api
+
+import (
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+)
+
+type RoomTransferAPI struct {
+	client *client.Client
+}
+
+func NewRoomTransferAPI(client *client.Client) *RoomTransferAPI {
+	return &RoomTransferAPI{
+		client: client,
+	}
+}
+
+// GetListRoomTransfers lists room moves, newest first, optionally for one student
+func (r *RoomTransferAPI) GetListRoomTransfers(ctx context.Context, page int, userID *int) (*Response[[]models.RoomTransfer], error) {
+	req := r.client.R().
+		SetContext(ctx).
+		SetCacheable().
+		SetQueryParam("page", fmt.Sprintf("%d", page))
+
+	if userID != nil {
+		req.SetQueryParam("user_id", fmt.Sprintf("%d", *userID))
+	}
+
+	return decode[[]models.RoomTransfer](req.Get("/room-transfers"))
+}
+
+func (r *RoomTransferAPI) RecordRoomTransfer(ctx context.Context, transferData map[string]interface{}) (*Response[models.RoomTransfer], error) {
+	return decode[models.RoomTransfer](r.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetBody(transferData).
+		Post("/room-transfers"))
+}
+
+// DeleteRoomTransfer removes a transfer record, used when the rest of the
+// transfer it records could not be completed
+func (r *RoomTransferAPI) DeleteRoomTransfer(ctx context.Context, transferID int) (*Response[any], error) {
+	return decode[any](r.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", transferID)).
+		Delete("/room-transfers/{id}"))
+}
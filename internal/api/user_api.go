@@ -2,6 +2,8 @@ package api
 
 import (
 	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
 	"fmt"
 )
 
@@ -15,26 +17,19 @@ func NewUserAPI(client *client.Client) *UserAPI {
 	}
 }
 
-func (u *UserAPI) GetUserDetails(userID string) (*client.Response, error) {
-
+func (u *UserAPI) GetUserDetails(ctx context.Context, userID string) (*Response[models.User], error) {
 	req := u.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetPathParam("userID", userID)
 
-	resp, err := req.Get("/users/{userID}")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+	return decode[models.User](req.Get("/users/{userID}"))
 }
 
-func (u *UserAPI) GetListUsers(page int, keyword string, order string, status string, gender string, statusAccount string, role string, hasRoom *bool) (*client.Response, error) {
+func (u *UserAPI) GetListUsers(ctx context.Context, page int, keyword string, order string, status string, gender string, statusAccount string, role string, hasRoom *bool) (*Response[[]models.User], error) {
 	req := u.client.R().
+		SetContext(ctx).
+		SetCacheable().
 		SetQueryParam("page", fmt.Sprintf("%d", page))
 
 	req.SetQueryParam("keyword", keyword)
@@ -47,41 +42,51 @@ func (u *UserAPI) GetListUsers(page int, keyword string, order string, status st
 		req.SetQueryParam("has_room", fmt.Sprintf("%t", *hasRoom))
 	}
 
-	resp, err := req.Get("/users")
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return resp, nil
-	}
-
-	return resp, nil
+	return decode[[]models.User](req.Get("/users"))
 }
 
-func (u *UserAPI) UpdateUserStatus(userID string, statusAccount string) (*client.Response, error) {
+func (u *UserAPI) UpdateUserStatus(ctx context.Context, userID string, statusAccount string) (*Response[any], error) {
 	req := u.client.R().
+		SetContext(ctx).
+		SetQueueable(OperationUpdateUserStatus).
 		SetPathParam("id", userID).
 		SetBody(map[string]string{
 			"status_account": statusAccount,
 		})
 
-	resp, err := req.Put("/users/{id}/status-account")
-
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return decode[any](req.Put("/users/{id}/status-account"))
 }
 
-func (r *UserAPI) AddStudentToRoom(roomID int, userID int) (*client.Response, error) {
-	return r.client.R().
+func (r *UserAPI) AddStudentToRoom(ctx context.Context, roomID int, userID int) (*Response[any], error) {
+	return decode[any](r.client.R().
+		SetContext(ctx).
+		SetQueueable(OperationAddStudentToRoom).
 		SetPathParam("id", fmt.Sprintf("%d", roomID)).
 		SetBody(map[string]interface{}{
 			"user_id": userID,
 			"room_id": roomID,
 		}).
-		Post("/users/room")
+		Post("/users/room"))
+}
+
+// CreateUser creates an account on behalf of staff, e.g. when importing a roster
+func (u *UserAPI) CreateUser(ctx context.Context, userData map[string]interface{}) (*Response[models.User], error) {
+	return decode[models.User](u.client.R().
+		SetContext(ctx).
+		SetIdempotencyKey().
+		SetBody(userData).
+		Post("/users"))
+}
+
+// UpdateStudentRoom moves a student to roomID, or out of any room when it is
+// nil. Unlike AddStudentToRoom it is never queued, so workflows that roll
+// back on failure know the outcome immediately.
+func (u *UserAPI) UpdateStudentRoom(ctx context.Context, userID int, roomID *int) (*Response[models.User], error) {
+	return decode[models.User](u.client.R().
+		SetContext(ctx).
+		SetPathParam("id", fmt.Sprintf("%d", userID)).
+		SetBody(map[string]interface{}{
+			"room_id": roomID,
+		}).
+		Put("/users/{id}/room"))
 }
@@ -0,0 +1,335 @@
+package audit
+
+import (
+	"changeme/internal/allocation"
+	"changeme/internal/models"
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Kind is the sort of inconsistency a finding describes
+type Kind string
+
+const (
+	KindUserCountMismatch    Kind = "user_count_mismatch"
+	KindOverCapacity         Kind = "over_capacity"
+	KindAvailableButFull     Kind = "available_but_full"
+	KindNoActiveContract     Kind = "no_active_contract"
+	KindContractRoomMismatch Kind = "contract_room_mismatch"
+	KindMissingCapacity      Kind = "missing_capacity"
+)
+
+// Action is what a fix changes
+type Action string
+
+const (
+	// ActionSetUserCount sets a room's user_count to the students living in it
+	ActionSetUserCount Action = "set_user_count"
+	// ActionSetRoomStatus changes a room's status
+	ActionSetRoomStatus Action = "set_room_status"
+	// ActionUnassign clears a student's room_id
+	ActionUnassign Action = "unassign_student"
+	// ActionMoveStudent sets a student's room_id without touching contracts
+	ActionMoveStudent Action = "move_student"
+	// ActionTransfer moves a student with the transfer workflow, contracts included
+	ActionTransfer Action = "transfer_student"
+	// ActionSetContractRoom points a contract at another room
+	ActionSetContractRoom Action = "set_contract_room"
+)
+
+// Fix is one change that resolves a finding
+type Fix struct {
+	Action      Action            `json:"action"`
+	Description string            `json:"description"`
+	RoomID      int               `json:"room_id,omitempty"`
+	UserID      int               `json:"user_id,omitempty"`
+	ContractID  int               `json:"contract_id,omitempty"`
+	ToRoomID    int               `json:"to_room_id,omitempty"`
+	UserCount   int               `json:"user_count,omitempty"`
+	Status      models.RoomStatus `json:"status,omitempty"`
+}
+
+// Finding is one inconsistency. Fixes are alternatives, the first one is
+// suggested; it is empty when nothing can be fixed safely without a person.
+type Finding struct {
+	Kind         Kind   `json:"kind"`
+	Message      string `json:"message"`
+	RoomID       int    `json:"room_id,omitempty"`
+	RoomNumber   string `json:"room_number,omitempty"`
+	UserID       int    `json:"user_id,omitempty"`
+	StudentName  string `json:"student_name,omitempty"`
+	ContractID   int    `json:"contract_id,omitempty"`
+	ContractCode string `json:"contract_code,omitempty"`
+	Fixes        []Fix  `json:"fixes"`
+}
+
+// Report is the result of one audit
+type Report struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Rooms     int       `json:"rooms"`
+	Users     int       `json:"users"`
+	Contracts int       `json:"contracts"`
+	Findings  []Finding `json:"findings"`
+}
+
+// Check compares rooms, the room_id of every user and active contracts.
+// The users' room_id is taken as the truth for who lives where.
+func Check(rooms []models.Room, users []models.User, contracts []models.Contract, now time.Time) *Report {
+	report := &Report{CheckedAt: now, Rooms: len(rooms), Users: len(users), Contracts: len(contracts), Findings: []Finding{}}
+
+	roomsByID := make(map[int]models.Room, len(rooms))
+	for _, room := range rooms {
+		roomsByID[room.ID] = room
+	}
+	usersByID := make(map[int]models.User, len(users))
+	housed := make(map[int][]models.User)
+	for _, user := range users {
+		usersByID[user.ID] = user
+		if user.RoomID != nil {
+			housed[*user.RoomID] = append(housed[*user.RoomID], user)
+		}
+	}
+	active := make(map[int][]models.Contract)
+	for _, contract := range contracts {
+		if contract.Status == models.ContractStatusActive {
+			active[contract.UserID] = append(active[contract.UserID], contract)
+		}
+	}
+
+	sorted := slices.Clone(rooms)
+	slices.SortFunc(sorted, func(a, b models.Room) int { return cmp.Compare(a.RoomNumber, b.RoomNumber) })
+
+	var overflow []models.User
+	overflowRoom := make(map[int]models.Room)
+	for _, room := range sorted {
+		occupants := housed[room.ID]
+		count, capacity := len(occupants), room.RoomCategory.Capacity
+
+		if room.UserCount != count {
+			report.add(Finding{
+				Kind:       KindUserCountMismatch,
+				Message:    fmt.Sprintf("Phòng %s ghi %d sinh viên nhưng có %d sinh viên xếp vào phòng", room.RoomNumber, room.UserCount, count),
+				RoomID:     room.ID,
+				RoomNumber: room.RoomNumber,
+				Fixes: []Fix{{
+					Action:      ActionSetUserCount,
+					Description: fmt.Sprintf("Cập nhật số sinh viên của phòng %s thành %d", room.RoomNumber, count),
+					RoomID:      room.ID,
+					UserCount:   count,
+				}},
+			})
+		}
+
+		switch {
+		case capacity <= 0 && room.RoomCategory.ID == 0:
+			// The room's category is unknown, so there is no capacity to
+			// check against
+
+		case capacity <= 0:
+			// Without a capacity nothing can be said about overcrowding; the
+			// room category has to be fixed by a person
+			report.add(Finding{
+				Kind:       KindMissingCapacity,
+				Message:    fmt.Sprintf("Loại phòng của phòng %s chưa có sức chứa nên không kiểm tra được số sinh viên", room.RoomNumber),
+				RoomID:     room.ID,
+				RoomNumber: room.RoomNumber,
+				Fixes:      []Fix{},
+			})
+
+		case count > capacity:
+			// Students without a contract for the room go first, then the
+			// ones whose contract started last
+			extra := slices.Clone(occupants)
+			slices.SortFunc(extra, func(a, b models.User) int {
+				ca, oka := contractFor(active[a.ID], room.ID)
+				cb, okb := contractFor(active[b.ID], room.ID)
+				if oka != okb {
+					if oka {
+						return 1
+					}
+					return -1
+				}
+				return cmp.Or(cb.StartDate.Compare(ca.StartDate), cmp.Compare(b.ID, a.ID))
+			})
+			for _, user := range extra[:count-capacity] {
+				overflow = append(overflow, user)
+				overflowRoom[user.ID] = room
+			}
+
+		case count >= capacity && room.Status == models.RoomStatusAvailable:
+			report.add(Finding{
+				Kind:       KindAvailableButFull,
+				Message:    fmt.Sprintf("Phòng %s đã đủ %d/%d sinh viên nhưng vẫn ở trạng thái còn trống", room.RoomNumber, count, capacity),
+				RoomID:     room.ID,
+				RoomNumber: room.RoomNumber,
+				Fixes: []Fix{{
+					Action:      ActionSetRoomStatus,
+					Description: fmt.Sprintf("Chuyển phòng %s sang trạng thái đã đầy", room.RoomNumber),
+					RoomID:      room.ID,
+					Status:      models.RoomStatusOccupied,
+				}},
+			})
+		}
+	}
+	report.overCapacity(overflow, overflowRoom, rooms, users, active)
+
+	sortedUsers := slices.Clone(users)
+	slices.SortFunc(sortedUsers, func(a, b models.User) int { return cmp.Compare(a.StudentCode, b.StudentCode) })
+	for _, user := range sortedUsers {
+		contracts := active[user.ID]
+		if user.RoomID == nil {
+			for _, contract := range contracts {
+				report.add(mismatch(user, contract, nil, roomsByID[contract.RoomID]))
+			}
+			continue
+		}
+
+		room := roomsByID[*user.RoomID]
+		if len(contracts) == 0 {
+			if user.Role != models.UserRoleStudent {
+				continue
+			}
+			report.add(Finding{
+				Kind:        KindNoActiveContract,
+				Message:     fmt.Sprintf("%s đang ở phòng %s nhưng không có hợp đồng còn hiệu lực", user.FullName, room.RoomNumber),
+				RoomID:      room.ID,
+				RoomNumber:  room.RoomNumber,
+				UserID:      user.ID,
+				StudentName: user.FullName,
+				Fixes: []Fix{{
+					Action:      ActionUnassign,
+					Description: fmt.Sprintf("Đưa %s ra khỏi phòng %s", user.FullName, room.RoomNumber),
+					UserID:      user.ID,
+					RoomID:      room.ID,
+				}},
+			})
+			continue
+		}
+
+		if _, ok := contractFor(contracts, *user.RoomID); ok {
+			continue
+		}
+		for _, contract := range contracts {
+			report.add(mismatch(user, contract, &room, roomsByID[contract.RoomID]))
+		}
+	}
+
+	return report
+}
+
+// overCapacity reports the students that do not fit their room and suggests
+// a room with a free bed for each, found with the allocation solver
+func (r *Report) overCapacity(overflow []models.User, from map[int]models.Room, rooms []models.Room, users []models.User, active map[int][]models.Contract) {
+	if len(overflow) == 0 {
+		return
+	}
+
+	preferences := make([]allocation.Preference, len(overflow))
+	for i, user := range overflow {
+		preferences[i] = allocation.Preference{UserID: user.ID, CategoryIDs: []int{from[user.ID].RoomCategoryID}}
+	}
+	plan := allocation.Solve(overflow, rooms, users, preferences)
+	target := make(map[int]allocation.Assignment, len(plan.Assignments))
+	for _, assignment := range plan.Assignments {
+		target[assignment.UserID] = assignment
+	}
+
+	for _, user := range overflow {
+		room := from[user.ID]
+		finding := Finding{
+			Kind:        KindOverCapacity,
+			Message:     fmt.Sprintf("Phòng %s có %d chỗ nhưng đang xếp nhiều hơn; %s là sinh viên vượt quá sức chứa", room.RoomNumber, room.RoomCategory.Capacity, user.FullName),
+			RoomID:      room.ID,
+			RoomNumber:  room.RoomNumber,
+			UserID:      user.ID,
+			StudentName: user.FullName,
+			Fixes:       []Fix{},
+		}
+
+		contract, hasContract := contractFor(active[user.ID], room.ID)
+		if hasContract {
+			finding.ContractID, finding.ContractCode = contract.ID, contract.Code
+		}
+		if to, ok := target[user.ID]; ok {
+			action, how := ActionTransfer, "kèm chuyển hợp đồng"
+			if !hasContract {
+				action, how = ActionMoveStudent, "không có hợp đồng cần chuyển"
+			}
+			finding.Fixes = append(finding.Fixes, Fix{
+				Action:      action,
+				Description: fmt.Sprintf("Chuyển %s từ phòng %s sang phòng %s (%s)", user.FullName, room.RoomNumber, to.RoomNumber, how),
+				UserID:      user.ID,
+				RoomID:      room.ID,
+				ToRoomID:    to.RoomID,
+			})
+		}
+		if !hasContract {
+			finding.Fixes = append(finding.Fixes, Fix{
+				Action:      ActionUnassign,
+				Description: fmt.Sprintf("Đưa %s ra khỏi phòng %s", user.FullName, room.RoomNumber),
+				UserID:      user.ID,
+				RoomID:      room.ID,
+			})
+		}
+		if len(finding.Fixes) == 0 {
+			finding.Message += "; không còn phòng trống phù hợp để chuyển"
+		}
+		r.add(finding)
+	}
+}
+
+// mismatch reports an active contract for another room than the student's.
+// The student's room is assumed right unless they have none.
+func mismatch(user models.User, contract models.Contract, room *models.Room, contractRoom models.Room) Finding {
+	finding := Finding{
+		Kind:         KindContractRoomMismatch,
+		UserID:       user.ID,
+		StudentName:  user.FullName,
+		ContractID:   contract.ID,
+		ContractCode: contract.Code,
+	}
+	moveIn := Fix{
+		Action:      ActionMoveStudent,
+		Description: fmt.Sprintf("Xếp %s vào phòng %s theo hợp đồng", user.FullName, contractRoom.RoomNumber),
+		UserID:      user.ID,
+		ToRoomID:    contract.RoomID,
+	}
+
+	if room == nil {
+		finding.Message = fmt.Sprintf("Hợp đồng %s của %s ghi phòng %s nhưng sinh viên chưa được xếp phòng", contract.Code, user.FullName, contractRoom.RoomNumber)
+		finding.RoomID, finding.RoomNumber = contractRoom.ID, contractRoom.RoomNumber
+		finding.Fixes = []Fix{moveIn}
+		return finding
+	}
+
+	finding.Message = fmt.Sprintf("Hợp đồng %s của %s ghi phòng %s nhưng sinh viên đang ở phòng %s", contract.Code, user.FullName, contractRoom.RoomNumber, room.RoomNumber)
+	finding.RoomID, finding.RoomNumber = room.ID, room.RoomNumber
+	moveIn.RoomID = room.ID
+	finding.Fixes = []Fix{
+		{
+			Action:      ActionSetContractRoom,
+			Description: fmt.Sprintf("Sửa hợp đồng %s sang phòng %s", contract.Code, room.RoomNumber),
+			ContractID:  contract.ID,
+			UserID:      user.ID,
+			RoomID:      contract.RoomID,
+			ToRoomID:    room.ID,
+		},
+		moveIn,
+	}
+	return finding
+}
+
+func contractFor(contracts []models.Contract, roomID int) (models.Contract, bool) {
+	for _, contract := range contracts {
+		if contract.RoomID == roomID {
+			return contract, true
+		}
+	}
+	return models.Contract{}, false
+}
+
+func (r *Report) add(finding Finding) {
+	r.Findings = append(r.Findings, finding)
+}
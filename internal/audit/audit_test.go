@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	room := func(id int, number string, capacity, userCount int, status models.RoomStatus) models.Room {
+		return models.Room{
+			ID:             id,
+			RoomNumber:     number,
+			Status:         status,
+			UserCount:      userCount,
+			RoomCategoryID: 1,
+			RoomCategory:   models.RoomCategory{ID: 1, Capacity: capacity},
+		}
+	}
+	user := func(id, roomID int, role models.UserRole) models.User {
+		u := models.User{ID: id, Role: role, Gender: models.GenderMale, StudentCode: fmt.Sprintf("215200%02d", id)}
+		if roomID != 0 {
+			u.RoomID = &roomID
+		}
+		return u
+	}
+	student := func(id, roomID int) models.User { return user(id, roomID, models.UserRoleStudent) }
+	contract := func(id, userID, roomID int, from string) models.Contract {
+		start, _ := time.Parse(time.DateOnly, from)
+		return models.Contract{ID: id, UserID: userID, RoomID: roomID, StartDate: start, EndDate: start.AddDate(1, 0, 0), Status: models.ContractStatusActive}
+	}
+
+	tests := []struct {
+		name      string
+		rooms     []models.Room
+		users     []models.User
+		contracts []models.Contract
+		want      []Kind
+		// wantFixes is the first fix of each finding, "" when it has none
+		wantFixes []Action
+	}{
+		{
+			name:      "consistent",
+			rooms:     []models.Room{room(10, "A101", 2, 1, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{},
+		},
+		{
+			name:      "user count out of date",
+			rooms:     []models.Room{room(10, "A101", 4, 3, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{KindUserCountMismatch},
+			wantFixes: []Action{ActionSetUserCount},
+		},
+		{
+			name:      "available but full",
+			rooms:     []models.Room{room(10, "A101", 1, 1, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{KindAvailableButFull},
+			wantFixes: []Action{ActionSetRoomStatus},
+		},
+		{
+			name:      "room without a capacity",
+			rooms:     []models.Room{room(10, "A101", 0, 1, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{KindMissingCapacity},
+			wantFixes: []Action{""},
+		},
+		{
+			name:      "room of an unknown category",
+			rooms:     []models.Room{{ID: 10, RoomNumber: "A101", Status: models.RoomStatusAvailable, UserCount: 1, RoomCategoryID: 9}},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{},
+		},
+		{
+			name:  "over capacity moves the latest contract to a free bed",
+			rooms: []models.Room{room(10, "A101", 1, 2, models.RoomStatusOccupied), room(11, "A102", 2, 0, models.RoomStatusAvailable)},
+			users: []models.User{student(1, 10), student(2, 10)},
+			contracts: []models.Contract{
+				contract(1, 1, 10, "2025-01-01"),
+				contract(2, 2, 10, "2025-03-01"),
+			},
+			want:      []Kind{KindOverCapacity},
+			wantFixes: []Action{ActionTransfer},
+		},
+		{
+			name:  "over capacity without a free bed",
+			rooms: []models.Room{room(10, "A101", 1, 2, models.RoomStatusOccupied)},
+			users: []models.User{student(1, 10), student(2, 10)},
+			contracts: []models.Contract{
+				contract(1, 1, 10, "2025-01-01"),
+				contract(2, 2, 10, "2025-03-01"),
+			},
+			want:      []Kind{KindOverCapacity},
+			wantFixes: []Action{""},
+		},
+		{
+			name:      "students need a contract, staff do not",
+			rooms:     []models.Room{room(10, "A101", 4, 2, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10), user(2, 10, models.UserRoleStaff)},
+			want:      []Kind{KindNoActiveContract},
+			wantFixes: []Action{ActionUnassign},
+		},
+		{
+			name:      "contract for another room",
+			rooms:     []models.Room{room(10, "A101", 4, 1, models.RoomStatusAvailable), room(11, "A102", 4, 0, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 10)},
+			contracts: []models.Contract{contract(1, 1, 11, "2025-01-01")},
+			want:      []Kind{KindContractRoomMismatch},
+			wantFixes: []Action{ActionSetContractRoom},
+		},
+		{
+			name:      "contract without a room",
+			rooms:     []models.Room{room(10, "A101", 4, 0, models.RoomStatusAvailable)},
+			users:     []models.User{student(1, 0)},
+			contracts: []models.Contract{contract(1, 1, 10, "2025-01-01")},
+			want:      []Kind{KindContractRoomMismatch},
+			wantFixes: []Action{ActionMoveStudent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Check(tt.rooms, tt.users, tt.contracts, now)
+
+			kinds := []Kind{}
+			fixes := []Action{}
+			for _, finding := range report.Findings {
+				kinds = append(kinds, finding.Kind)
+				if len(finding.Fixes) == 0 {
+					fixes = append(fixes, "")
+				} else {
+					fixes = append(fixes, finding.Fixes[0].Action)
+				}
+			}
+			if !slices.Equal(kinds, tt.want) {
+				t.Fatalf("Check() found %v, want %v", kinds, tt.want)
+			}
+			if tt.wantFixes != nil && !slices.Equal(fixes, tt.wantFixes) {
+				t.Errorf("Check() suggested %v, want %v", fixes, tt.wantFixes)
+			}
+		})
+	}
+}
+
+func TestRunResolvesCategories(t *testing.T) {
+	roomID := 10
+	respond := func(data any, total int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data, "total": total})
+		}
+	}
+	mux := http.NewServeMux()
+	// The room list leaves the category out
+	mux.HandleFunc("GET /rooms", respond([]models.Room{{ID: 10, RoomNumber: "A101", Status: models.RoomStatusOccupied, UserCount: 3, RoomCategoryID: 1}}, 1))
+	mux.HandleFunc("GET /room-categories", respond([]models.RoomCategory{{ID: 1, Name: "Phòng 2", Capacity: 2, Price: 1}}, 1))
+	mux.HandleFunc("GET /users", respond([]models.User{
+		{ID: 1, FullName: "SV1", Role: models.UserRoleStudent, RoomID: &roomID},
+		{ID: 2, FullName: "SV2", Role: models.UserRoleStudent, RoomID: &roomID},
+		{ID: 3, FullName: "SV3", Role: models.UserRoleStudent, RoomID: &roomID},
+	}, 3))
+	mux.HandleFunc("GET /contracts", respond([]models.Contract{
+		{ID: 1, Code: "C1", UserID: 1, RoomID: 10, Status: models.ContractStatusActive},
+		{ID: 2, Code: "C2", UserID: 2, RoomID: 10, Status: models.ContractStatusActive},
+		{ID: 3, Code: "C3", UserID: 3, RoomID: 10, Status: models.ContractStatusActive},
+	}, 3))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	report, err := NewAuditor(api.NewAPI(client.New().SetBaseURL(server.URL))).Run(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	kinds := []Kind{}
+	for _, finding := range report.Findings {
+		kinds = append(kinds, finding.Kind)
+	}
+	if !slices.Equal(kinds, []Kind{KindOverCapacity}) {
+		t.Errorf("Run() found %v, want %v", kinds, []Kind{KindOverCapacity})
+	}
+}
@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"changeme/internal/allocation"
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"changeme/internal/transfer"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Outcome is what happened to one fix. Queued is set instead of Applied when
+// the server was unreachable and the write waits in the offline queue.
+type Outcome struct {
+	Fix     Fix    `json:"fix"`
+	Applied bool   `json:"applied"`
+	Queued  bool   `json:"queued"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Auditor loads rooms, users and contracts from the API and applies fixes
+type Auditor struct {
+	api *api.API
+}
+
+func NewAuditor(api *api.API) *Auditor {
+	return &Auditor{api: api}
+}
+
+// Run pages through every room, user and contract and checks them. Rooms
+// get their category from the category list before they are checked.
+func (a *Auditor) Run(ctx context.Context, now time.Time) (*Report, error) {
+	rooms, err := api.FetchAll(ctx, a.api.Room().GetListRooms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rooms: %w", err)
+	}
+
+	categories, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.RoomCategory], error) {
+		return a.api.RoomCategory().GetListRoomCategories(ctx, strconv.Itoa(page))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room categories: %w", err)
+	}
+	allocation.WithCategories(rooms, categories)
+
+	users, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.User], error) {
+		return a.api.User().GetListUsers(ctx, page, "", "", "", "", "", "", nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return a.api.Contract().GetListContracts(ctx, page, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contracts: %w", err)
+	}
+
+	return Check(rooms, users, contracts, now), nil
+}
+
+// Apply carries out fixes in order. A failed fix does not stop the others;
+// run the audit again afterwards to see what is left.
+func (a *Auditor) Apply(ctx context.Context, fixes []Fix, now time.Time) []Outcome {
+	outcomes := make([]Outcome, len(fixes))
+	for i, fix := range fixes {
+		outcomes[i].Fix = fix
+		if err := ctx.Err(); err != nil {
+			outcomes[i].Error = err.Error()
+			continue
+		}
+		queued, err := a.apply(ctx, fix, now)
+		if err != nil {
+			outcomes[i].Error = err.Error()
+			continue
+		}
+		outcomes[i].Applied = !queued
+		outcomes[i].Queued = queued
+	}
+	return outcomes
+}
+
+// apply carries out one fix and reports whether it was only queued
+func (a *Auditor) apply(ctx context.Context, fix Fix, now time.Time) (bool, error) {
+	var err error
+	switch fix.Action {
+	case ActionSetUserCount, ActionSetRoomStatus:
+		data := map[string]interface{}{"user_count": fix.UserCount}
+		if fix.Action == ActionSetRoomStatus {
+			data = map[string]interface{}{"status": fix.Status}
+		}
+		resp, err := a.api.Room().UpdateRoom(ctx, fix.RoomID, data)
+		if err != nil {
+			return false, err
+		}
+		return resp.Queued, nil
+	case ActionUnassign:
+		_, err = a.api.User().UpdateStudentRoom(ctx, fix.UserID, nil)
+	case ActionMoveStudent:
+		_, err = a.api.User().UpdateStudentRoom(ctx, fix.UserID, &fix.ToRoomID)
+	case ActionTransfer:
+		_, err = transfer.NewMover(a.api).Execute(ctx, transfer.Request{
+			UserID:   fix.UserID,
+			ToRoomID: fix.ToRoomID,
+			MoveDate: now,
+			Reason:   "Điều chỉnh phòng vượt quá sức chứa",
+		})
+	case ActionSetContractRoom:
+		_, err = a.api.Contract().UpdateContract(ctx, fix.ContractID, map[string]interface{}{
+			"room_id": fix.ToRoomID,
+		})
+	default:
+		err = fmt.Errorf("unknown fix %q", fix.Action)
+	}
+	return false, err
+}
@@ -0,0 +1,119 @@
+package billing
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Failure is a draft the server rejected
+type Failure struct {
+	Draft Draft  `json:"draft"`
+	Error string `json:"error"`
+}
+
+// Result reports what Generate created
+type Result struct {
+	Plan    *Plan            `json:"plan"`
+	Created []models.Invoice `json:"created"`
+	Failed  []Failure        `json:"failed"`
+}
+
+// Engine turns active contracts into the monthly room fee invoices
+type Engine struct {
+	api  *api.API
+	opts Options
+}
+
+func NewEngine(api *api.API, opts Options) *Engine {
+	return &Engine{
+		api:  api,
+		opts: opts,
+	}
+}
+
+// Preview fetches contracts and the period's existing invoices and plans the
+// period without creating anything
+func (e *Engine) Preview(ctx context.Context, p Period) (*Plan, error) {
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return e.api.Contract().GetListContracts(ctx, page, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	existing, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return e.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{
+			Page:   page,
+			Type:   string(models.InvoiceTypeRoomFee),
+			Period: p.String(),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	return Build(p, contracts, existing, e.opts), nil
+}
+
+// Generate plans the period and creates its invoices. A failed draft does
+// not stop the others; rerunning the period only retries what is missing.
+func (e *Engine) Generate(ctx context.Context, p Period) (*Result, error) {
+	plan, err := e.Preview(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Plan:    plan,
+		Created: []models.Invoice{},
+		Failed:  []Failure{},
+	}
+	for _, draft := range plan.Drafts {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		resp, err := e.api.Invoice().CreateInvoice(ctx, draft.Payload())
+		if err != nil {
+			result.Failed = append(result.Failed, Failure{Draft: draft, Error: err.Error()})
+			continue
+		}
+		result.Created = append(result.Created, resp.Data)
+	}
+
+	return result, nil
+}
+
+// Closing plans the last room fee invoice of a contract that ends early on
+// end, covering the month end falls in up to end. Closed contracts are not
+// billed by the monthly run, so workflows that close one call this. It
+// returns nil when that month is already invoiced or not covered.
+func (e *Engine) Closing(ctx context.Context, contract models.Contract, end time.Time) (*Draft, error) {
+	p := PeriodOf(end)
+	existing, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return e.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{
+			Page:   page,
+			Type:   string(models.InvoiceTypeRoomFee),
+			UserID: contract.UserID,
+			Period: p.String(),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	closing := contract
+	closing.EndDate = Date(end)
+	closing.Status = models.ContractStatusActive
+
+	plan := Build(p, []models.Contract{closing}, existing, e.opts)
+	if len(plan.Drafts) == 0 {
+		return nil, nil
+	}
+	draft := plan.Drafts[0]
+	draft.Description += fmt.Sprintf(" (đến ngày %s)", closing.EndDate.Format("02/01/2006"))
+	return &draft, nil
+}
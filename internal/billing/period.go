@@ -0,0 +1,91 @@
+package billing
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Period is a calendar month of billing
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+// ParsePeriod parses a period in the form YYYY-MM
+func ParsePeriod(s string) (Period, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid billing period %q, expected YYYY-MM", s)
+	}
+	return Period{Year: t.Year(), Month: t.Month()}, nil
+}
+
+// PeriodOf returns the period containing t
+func PeriodOf(t time.Time) Period {
+	return Period{Year: t.Year(), Month: t.Month()}
+}
+
+func (p Period) String() string {
+	return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month))
+}
+
+// Start returns the first day of the period
+func (p Period) Start() time.Time {
+	return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the period
+func (p Period) End() time.Time {
+	return p.Start().AddDate(0, 1, -1)
+}
+
+// Days returns the number of days in the period
+func (p Period) Days() int {
+	return p.End().Day()
+}
+
+// Next returns the following period
+func (p Period) Next() Period {
+	return PeriodOf(p.Start().AddDate(0, 1, 0))
+}
+
+// Prev returns the preceding period
+func (p Period) Prev() Period {
+	return PeriodOf(p.Start().AddDate(0, -1, 0))
+}
+
+// Date drops the clock from t, keeping the calendar date in t's own location
+func Date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Overlap returns the days of the period covered by the inclusive range from..to
+func (p Period) Overlap(from, to time.Time) (start, end time.Time, days int) {
+	start, end = Date(from), Date(to)
+	if start.Before(p.Start()) {
+		start = p.Start()
+	}
+	if end.After(p.End()) {
+		end = p.End()
+	}
+	if end.Before(start) {
+		return start, end, 0
+	}
+	return start, end, int(end.Sub(start).Hours()/24) + 1
+}
+
+// Prorate charges a monthly price for the days of the period covered by from..to
+func Prorate(monthlyPrice float64, p Period, from, to time.Time) (amount float64, days int) {
+	_, _, days = p.Overlap(from, to)
+	if days == p.Days() {
+		return monthlyPrice, days
+	}
+	return Round(monthlyPrice * float64(days) / float64(p.Days())), days
+}
+
+// Round rounds an amount to whole dong
+func Round(amount float64) float64 {
+	return math.Round(amount)
+}
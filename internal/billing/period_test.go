@@ -0,0 +1,75 @@
+package billing
+
+import (
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      float64
+		period     Period
+		from, to   string
+		wantAmount float64
+		wantDays   int
+	}{
+		{"whole month", 3_000_000, Period{2025, time.September}, "2025-01-01", "2025-12-31", 3_000_000, 30},
+		{"exact month bounds", 3_000_000, Period{2025, time.September}, "2025-09-01", "2025-09-30", 3_000_000, 30},
+		{"moves in mid-month", 3_000_000, Period{2025, time.September}, "2025-09-16", "2026-06-30", 1_500_000, 15},
+		{"leaves mid-month", 3_100_000, Period{2025, time.October}, "2025-01-01", "2025-10-10", 1_000_000, 10},
+		{"single day", 2_800_000, Period{2026, time.February}, "2026-02-14", "2026-02-14", 100_000, 1},
+		{"leap February", 2_900_000, Period{2028, time.February}, "2028-02-01", "2028-02-29", 2_900_000, 29},
+		{"rounds to whole dong", 1_000_000, Period{2025, time.September}, "2025-09-01", "2025-09-01", 33_333, 1},
+		{"ends before the period", 3_000_000, Period{2025, time.September}, "2025-01-01", "2025-08-31", 0, 0},
+		{"starts after the period", 3_000_000, Period{2025, time.September}, "2025-10-01", "2026-06-30", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, days := Prorate(tt.price, tt.period, day(tt.from), day(tt.to))
+			if amount != tt.wantAmount || days != tt.wantDays {
+				t.Errorf("Prorate() = %v, %d; want %v, %d", amount, days, tt.wantAmount, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantDays   int
+		wantPrev   string
+		wantNext   string
+		wantString string
+	}{
+		{"2025-01", 31, "2024-12", "2025-02", "2025-01"},
+		{"2025-02", 28, "2025-01", "2025-03", "2025-02"},
+		{"2024-02", 29, "2024-01", "2024-03", "2024-02"},
+		{"2025-12", 31, "2025-11", "2026-01", "2025-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			p, err := ParsePeriod(tt.in)
+			if err != nil {
+				t.Fatalf("ParsePeriod() error = %v", err)
+			}
+			if p.String() != tt.wantString || p.Days() != tt.wantDays || p.Prev().String() != tt.wantPrev || p.Next().String() != tt.wantNext {
+				t.Errorf("got %s, %d days, prev %s, next %s", p, p.Days(), p.Prev(), p.Next())
+			}
+		})
+	}
+
+	if _, err := ParsePeriod("2025-13"); err == nil {
+		t.Error("ParsePeriod(2025-13) succeeded, want an error")
+	}
+}
@@ -0,0 +1,169 @@
+package billing
+
+import (
+	"changeme/internal/models"
+	"fmt"
+	"time"
+)
+
+// DefaultDueDays is how long after issue a generated invoice falls due
+const DefaultDueDays = 10
+
+// Skip reasons reported in a plan
+const (
+	SkipNotActive       = "contract is not active"
+	SkipOutsidePeriod   = "contract does not cover the period"
+	SkipAlreadyInvoiced = "invoice already exists for the period"
+	SkipNoPrice         = "contract has no price"
+)
+
+// Draft is an invoice the engine would create for one contract
+type Draft struct {
+	ContractID   int       `json:"contract_id"`
+	ContractCode string    `json:"contract_code"`
+	UserID       int       `json:"user_id"`
+	StudentName  string    `json:"student_name"`
+	RoomID       int       `json:"room_id"`
+	RoomNumber   string    `json:"room_number"`
+	Code         string    `json:"code"`
+	Period       string    `json:"billing_period"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Days         int       `json:"days"`
+	PeriodDays   int       `json:"period_days"`
+	Prorated     bool      `json:"prorated"`
+	MonthlyPrice float64   `json:"monthly_price"`
+	Amount       float64   `json:"amount"`
+	Description  string    `json:"description"`
+	IssueDate    time.Time `json:"issue_date"`
+	DueDate      time.Time `json:"due_date"`
+}
+
+// Payload returns the body for InvoiceAPI.CreateInvoice
+func (d *Draft) Payload() map[string]interface{} {
+	return map[string]interface{}{
+		"code":           d.Code,
+		"user_id":        d.UserID,
+		"room_id":        d.RoomID,
+		"contract_id":    d.ContractID,
+		"type":           models.InvoiceTypeRoomFee,
+		"description":    d.Description,
+		"amount":         d.Amount,
+		"billing_period": d.Period,
+		"issue_date":     d.IssueDate.Format(time.DateOnly),
+		"due_date":       d.DueDate.Format(time.DateOnly),
+	}
+}
+
+// Skip records a contract the engine did not bill and why
+type Skip struct {
+	ContractID   int    `json:"contract_id"`
+	ContractCode string `json:"contract_code"`
+	Reason       string `json:"reason"`
+}
+
+// Plan is the set of invoices for one period, before anything is created
+type Plan struct {
+	Period  string  `json:"billing_period"`
+	Drafts  []Draft `json:"drafts"`
+	Skipped []Skip  `json:"skipped"`
+	Total   float64 `json:"total"`
+}
+
+// Options tunes how drafts are dated
+type Options struct {
+	// IssueDate defaults to the first day of the period
+	IssueDate time.Time
+	// DueDays defaults to DefaultDueDays
+	DueDays int
+}
+
+// InvoiceCode returns the invoice number used for a contract's period. It is
+// deterministic so reruns of the same period can be recognised.
+func InvoiceCode(contractCode string, p Period) string {
+	return fmt.Sprintf("INV-%s-%04d%02d", contractCode, p.Year, int(p.Month))
+}
+
+// Build plans the room fee invoices of a period. Contract end dates are
+// inclusive; a contract starting or ending inside the period is billed for
+// the days it covers. Existing invoices that are not void suppress a draft
+// for the same contract and period.
+func Build(p Period, contracts []models.Contract, existing []models.Invoice, opts Options) *Plan {
+	issueDate := opts.IssueDate
+	if issueDate.IsZero() {
+		issueDate = p.Start()
+	}
+	dueDays := opts.DueDays
+	if dueDays <= 0 {
+		dueDays = DefaultDueDays
+	}
+
+	invoiced := make(map[int]bool)
+	codes := make(map[string]bool)
+	for _, invoice := range existing {
+		if invoice.Status == models.InvoiceStatusVoid {
+			continue
+		}
+		if invoice.ContractID != nil && invoice.BillingPeriod == p.String() {
+			invoiced[*invoice.ContractID] = true
+		}
+		codes[invoice.Code] = true
+	}
+
+	plan := &Plan{
+		Period:  p.String(),
+		Drafts:  []Draft{},
+		Skipped: []Skip{},
+	}
+	for _, contract := range contracts {
+		skip := func(reason string) {
+			plan.Skipped = append(plan.Skipped, Skip{
+				ContractID:   contract.ID,
+				ContractCode: contract.Code,
+				Reason:       reason,
+			})
+		}
+
+		code := InvoiceCode(contract.Code, p)
+		from, to, days := p.Overlap(contract.StartDate, contract.EndDate)
+		switch {
+		case contract.Status != models.ContractStatusActive:
+			skip(SkipNotActive)
+			continue
+		case days == 0:
+			skip(SkipOutsidePeriod)
+			continue
+		case contract.Price <= 0:
+			skip(SkipNoPrice)
+			continue
+		case invoiced[contract.ID] || codes[code]:
+			skip(SkipAlreadyInvoiced)
+			continue
+		}
+
+		amount, _ := Prorate(contract.Price, p, from, to)
+		plan.Drafts = append(plan.Drafts, Draft{
+			ContractID:   contract.ID,
+			ContractCode: contract.Code,
+			UserID:       contract.UserID,
+			StudentName:  contract.User.FullName,
+			RoomID:       contract.RoomID,
+			RoomNumber:   contract.Room.RoomNumber,
+			Code:         code,
+			Period:       p.String(),
+			From:         from,
+			To:           to,
+			Days:         days,
+			PeriodDays:   p.Days(),
+			Prorated:     days < p.Days(),
+			MonthlyPrice: contract.Price,
+			Amount:       amount,
+			Description:  fmt.Sprintf("Phí thuê tháng %d/%d", int(p.Month), p.Year),
+			IssueDate:    issueDate,
+			DueDate:      issueDate.AddDate(0, 0, dueDays),
+		})
+		plan.Total += amount
+	}
+
+	return plan
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"changeme/internal/client"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var responsesBucket = []byte("responses")
+
+// Store is an on-disk response cache backed by bbolt. It implements client.Cache.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the cache database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(responsesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Get(key string) (*client.CacheEntry, bool) {
+	var entry *client.CacheEntry
+
+	s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(responsesBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		decoded := &client.CacheEntry{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			return err
+		}
+		entry = decoded
+		return nil
+	})
+
+	return entry, entry != nil
+}
+
+func (s *Store) Put(key string, entry *client.CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(responsesBucket).Put([]byte(key), data)
+	})
+}
+
+// Clear drops every cached response
+func (s *Store) Clear() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(responsesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(responsesBucket)
+		return err
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
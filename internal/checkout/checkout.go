@@ -0,0 +1,223 @@
+package checkout
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/deposit"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Condition is what the inspection found for one amenity
+type Condition string
+
+const (
+	ConditionGood    Condition = "good"
+	ConditionDamaged Condition = "damaged"
+	ConditionMissing Condition = "missing"
+)
+
+var (
+	// ErrNoRoom is returned when checking out a student who is not in a room
+	ErrNoRoom = errors.New("the student is not in a room")
+	// ErrNoActiveContract is returned when the student's room has no active contract
+	ErrNoActiveContract = errors.New("the student has no active contract for their room")
+	// ErrOutstanding is returned when invoices are left unpaid and the
+	// check-out was not asked to collect them
+	ErrOutstanding = errors.New("the student has unpaid invoices")
+)
+
+// Item is one line of the inspection checklist
+type Item struct {
+	RoomAmenityID int       `json:"room_amenity_id"`
+	AmenityID     int       `json:"amenity_id"`
+	Name          string    `json:"name"`
+	Condition     Condition `json:"condition"`
+	Note          string    `json:"note"`
+	Cost          float64   `json:"cost"`
+}
+
+// Checklist is everything staff need to walk through a check-out
+type Checklist struct {
+	UserID       int    `json:"user_id"`
+	StudentName  string `json:"student_name"`
+	StudentCode  string `json:"student_code"`
+	RoomID       int    `json:"room_id"`
+	RoomNumber   string `json:"room_number"`
+	ContractID   int    `json:"contract_id"`
+	ContractCode string `json:"contract_code"`
+	Date         string `json:"date"`
+	Items        []Item `json:"items"`
+	// FinalInvoice is the rent of the check-out month up to the check-out
+	// date, when that month has not been invoiced yet
+	FinalInvoice     *billing.Draft     `json:"final_invoice"`
+	Outstanding      []models.Invoice   `json:"outstanding"`
+	OutstandingTotal float64            `json:"outstanding_total"`
+	Deposit          *deposit.Statement `json:"deposit"`
+
+	contract models.Contract
+}
+
+// Request is a completed inspection and how to settle the student's account
+type Request struct {
+	UserID int       `json:"user_id"`
+	Date   time.Time `json:"date"`
+	Items  []Item    `json:"items"`
+	// DeductDamages charges the cost of damaged and missing items to the deposit
+	DeductDamages bool `json:"deduct_damages"`
+	// PayOutstanding records payments with PaymentMethod for every unpaid
+	// invoice, including the final one and any deposit shortfall
+	PayOutstanding bool                 `json:"pay_outstanding"`
+	PaymentMethod  models.PaymentMethod `json:"payment_method"`
+}
+
+// Result reports a check-out. Once the first change is made failures are
+// reported in Error and the steps done so far stay recorded here.
+// ReleaseQueued is set instead of RoomReleased when marking the room
+// available waits in the offline queue.
+type Result struct {
+	Checklist     *Checklist                  `json:"checklist"`
+	FinalInvoice  *models.Invoice             `json:"final_invoice"`
+	Damages       []models.MaintenanceHistory `json:"damages"`
+	Deposit       *deposit.Statement          `json:"deposit"`
+	Payments      []models.Payment            `json:"payments"`
+	Contract      *models.Contract            `json:"contract"`
+	RoomReleased  bool                        `json:"room_released"`
+	ReleaseQueued bool                        `json:"release_queued"`
+	Completed     bool                        `json:"completed"`
+	Error         string                      `json:"error,omitempty"`
+}
+
+// Desk runs student check-outs
+type Desk struct {
+	api *api.API
+}
+
+func NewDesk(api *api.API) *Desk {
+	return &Desk{api: api}
+}
+
+// Checklist prepares a check-out on date: one item per amenity of the room,
+// the invoices still to pay and the deposit to settle
+func (d *Desk) Checklist(ctx context.Context, userID int, date time.Time) (*Checklist, error) {
+	date = billing.Date(date)
+
+	user, err := d.api.User().GetUserDetails(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load student %d: %w", userID, err)
+	}
+	if user.Data.RoomID == nil {
+		return nil, fmt.Errorf("%s: %w", user.Data.FullName, ErrNoRoom)
+	}
+
+	room, err := d.api.Room().GetRoomDetails(ctx, *user.Data.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room %d: %w", *user.Data.RoomID, err)
+	}
+
+	contract, err := d.contract(ctx, user.Data, room.Data)
+	if err != nil {
+		return nil, err
+	}
+	if date.Before(billing.Date(contract.StartDate)) {
+		return nil, fmt.Errorf("check-out date %s is before contract %s starts", date.Format(time.DateOnly), contract.Code)
+	}
+
+	list := &Checklist{
+		UserID:       user.Data.ID,
+		StudentName:  user.Data.FullName,
+		StudentCode:  user.Data.StudentCode,
+		RoomID:       room.Data.ID,
+		RoomNumber:   room.Data.RoomNumber,
+		ContractID:   contract.ID,
+		ContractCode: contract.Code,
+		Date:         date.Format(time.DateOnly),
+		Items:        []Item{},
+		Outstanding:  []models.Invoice{},
+		contract:     contract,
+	}
+	for _, ra := range room.Data.RoomAmenities {
+		list.Items = append(list.Items, Item{
+			RoomAmenityID: ra.ID,
+			AmenityID:     ra.AmenityID,
+			Name:          ra.Amenity.Name,
+			Condition:     ConditionGood,
+		})
+	}
+
+	if date.Before(billing.Date(contract.EndDate)) {
+		list.FinalInvoice, err = billing.NewEngine(d.api, billing.Options{IssueDate: date}).Closing(ctx, contract, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan the last invoice of %s: %w", contract.Code, err)
+		}
+	}
+
+	if list.Outstanding, err = d.outstanding(ctx, user.Data.ID); err != nil {
+		return nil, err
+	}
+	for _, invoice := range list.Outstanding {
+		list.OutstandingTotal += invoice.Outstanding()
+	}
+
+	statement, err := deposit.NewLedger(d.api).Statement(ctx, contract.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the deposit of %s: %w", contract.Code, err)
+	}
+	if len(statement.Received) > 0 {
+		list.Deposit = statement
+	}
+
+	return list, nil
+}
+
+// contract finds the contract a check-out from room ends
+func (d *Desk) contract(ctx context.Context, user models.User, room models.Room) (models.Contract, error) {
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return d.api.Contract().GetListContracts(ctx, page, &user.StudentCode)
+	})
+	if err != nil {
+		return models.Contract{}, fmt.Errorf("failed to load contracts of %s: %w", user.FullName, err)
+	}
+
+	// A student who is still in the room but whose contract has already
+	// ended is a check-out that stopped halfway, so the latest ended
+	// contract is picked up again to finish it
+	var ended *models.Contract
+	for _, c := range contracts {
+		if c.UserID != user.ID || c.RoomID != room.ID {
+			continue
+		}
+		switch {
+		case c.Status == models.ContractStatusActive:
+			return c, nil
+		case c.Status == models.ContractStatusInactive && (ended == nil || c.EndDate.After(ended.EndDate)):
+			ended = &c
+		}
+	}
+	if ended != nil {
+		return *ended, nil
+	}
+	return models.Contract{}, fmt.Errorf("%s, room %s: %w", user.FullName, room.RoomNumber, ErrNoActiveContract)
+}
+
+// outstanding lists a student's invoices that still have something to pay
+func (d *Desk) outstanding(ctx context.Context, userID int) ([]models.Invoice, error) {
+	invoices, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return d.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{Page: page, UserID: userID})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invoices: %w", err)
+	}
+
+	unpaid := []models.Invoice{}
+	for _, invoice := range invoices {
+		if invoice.Status != models.InvoiceStatusVoid && invoice.Status != models.InvoiceStatusPaid && invoice.Outstanding() > 0 {
+			unpaid = append(unpaid, invoice)
+		}
+	}
+	return unpaid, nil
+}
@@ -0,0 +1,253 @@
+package checkout
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/deposit"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Complete checks a student out: damages are recorded as maintenance
+// history, the last month is invoiced, the deposit settled, unpaid invoices
+// collected, the contract ended and the student removed from the room. The
+// room becomes available again when nobody is left in it. A check-out that
+// stopped halfway is finished by completing it again, even after the
+// contract has ended; damage, deposit deductions and the shortfall invoice
+// are not recorded twice.
+func (d *Desk) Complete(ctx context.Context, req Request) (*Result, error) {
+	list, err := d.Checklist(ctx, req.UserID, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	inspected := make(map[int]Item, len(req.Items))
+	for _, item := range req.Items {
+		inspected[item.RoomAmenityID] = item
+	}
+	var damaged []Item
+	for i, item := range list.Items {
+		found, ok := inspected[item.RoomAmenityID]
+		if !ok {
+			return nil, fmt.Errorf("%s has not been inspected", item.Name)
+		}
+		item.Condition, item.Note, item.Cost = found.Condition, found.Note, found.Cost
+		list.Items[i] = item
+
+		switch item.Condition {
+		case ConditionGood:
+		case ConditionDamaged, ConditionMissing:
+			if item.Cost < 0 {
+				return nil, fmt.Errorf("the cost of %s cannot be negative", item.Name)
+			}
+			damaged = append(damaged, item)
+		default:
+			return nil, fmt.Errorf("unknown condition %q for %s", item.Condition, item.Name)
+		}
+	}
+
+	if !req.PayOutstanding && (len(list.Outstanding) > 0 || list.FinalInvoice != nil) {
+		return nil, ErrOutstanding
+	}
+	if req.PayOutstanding && req.PaymentMethod != models.PaymentMethodCash && req.PaymentMethod != models.PaymentMethodBankTransfer {
+		return nil, fmt.Errorf("unknown payment method %q", req.PaymentMethod)
+	}
+
+	result := &Result{
+		Checklist: list,
+		Damages:   []models.MaintenanceHistory{},
+		Payments:  []models.Payment{},
+	}
+	if err := d.complete(ctx, req, list, damaged, result); err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Completed = true
+	return result, nil
+}
+
+func (d *Desk) complete(ctx context.Context, req Request, list *Checklist, damaged []Item, result *Result) error {
+	date := list.Date
+	ledger := deposit.NewLedger(d.api)
+
+	if list.FinalInvoice != nil {
+		resp, err := d.api.Invoice().CreateInvoice(ctx, list.FinalInvoice.Payload())
+		if err != nil {
+			return fmt.Errorf("failed to invoice the last month: %w", err)
+		}
+		result.FinalInvoice = &resp.Data
+	}
+
+	// A check-out that failed halfway is finished by running it again, so
+	// damage recorded by the earlier attempt is found by its key and reused
+	recorded, err := d.damages(ctx, list.RoomID)
+	if err != nil {
+		return err
+	}
+	var histories []int
+	for _, item := range damaged {
+		key := damageKey(list.ContractCode, item.RoomAmenityID)
+		history, ok := recorded[key]
+		if !ok {
+			description := fmt.Sprintf("%s %s: %s khi %s trả phòng", key, item.Name, conditionLabels[item.Condition], list.StudentName)
+			if item.Note != "" {
+				description += " – " + item.Note
+			}
+			resp, err := d.api.MaintenanceHistory().CreateMaintenanceHistory(ctx, map[string]interface{}{
+				"room_id":          list.RoomID,
+				"maintenance_date": date,
+				"description":      description,
+				"cost":             item.Cost,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record damage to %s: %w", item.Name, err)
+			}
+			if resp.Queued {
+				return fmt.Errorf("damage to %s was queued while the server is unreachable; finish the check-out once it is back", item.Name)
+			}
+			history = resp.Data
+		}
+		result.Damages = append(result.Damages, history)
+		if history.Cost > 0 {
+			histories = append(histories, history.ID)
+		}
+	}
+
+	if list.Deposit != nil && list.Deposit.Status != models.DepositStatusSettled {
+		statement := list.Deposit
+		if req.DeductDamages {
+			for _, id := range histories {
+				if deducted(statement, id) {
+					continue
+				}
+				var err error
+				if statement, err = ledger.Deduct(ctx, list.ContractID, id, 0, ""); err != nil {
+					return fmt.Errorf("failed to deduct damages from the deposit: %w", err)
+				}
+			}
+		}
+		if statement.Balance > 0 {
+			var err error
+			if statement, err = ledger.Refund(ctx, list.ContractID, statement.Balance, "Hoàn cọc khi trả phòng"); err != nil {
+				return fmt.Errorf("failed to refund the deposit: %w", err)
+			}
+		}
+		settled, err := ledger.Settle(ctx, list.ContractID)
+		if err != nil {
+			return fmt.Errorf("failed to settle the deposit: %w", err)
+		}
+		result.Deposit = settled
+	}
+
+	// Reloaded so the final invoice and a deposit shortfall are included
+	unpaid, err := d.outstanding(ctx, list.UserID)
+	if err != nil {
+		return err
+	}
+	if len(unpaid) > 0 && !req.PayOutstanding {
+		return ErrOutstanding
+	}
+	for _, invoice := range unpaid {
+		resp, err := d.api.Payment().RecordPayment(ctx, map[string]interface{}{
+			"invoice_id": invoice.ID,
+			"amount":     invoice.Outstanding(),
+			"method":     req.PaymentMethod,
+			"paid_at":    date,
+			"note":       "Thanh toán khi trả phòng",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record payment of %s: %w", invoice.Code, err)
+		}
+		result.Payments = append(result.Payments, resp.Data)
+	}
+
+	if err := ledger.EnsureSettled(ctx, list.ContractID); err != nil {
+		return err
+	}
+	result.Contract = &list.contract
+	if list.contract.Status == models.ContractStatusActive {
+		contract, err := d.api.Contract().UpdateContract(ctx, list.ContractID, map[string]interface{}{
+			"end_date": date,
+			"status":   models.ContractStatusInactive,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to end contract %s: %w", list.ContractCode, err)
+		}
+		result.Contract = &contract.Data
+	}
+
+	if _, err := d.api.User().UpdateStudentRoom(ctx, list.UserID, nil); err != nil {
+		return fmt.Errorf("failed to remove %s from room %s: %w", list.StudentName, list.RoomNumber, err)
+	}
+
+	released, queued, err := d.release(ctx, list.RoomID)
+	if err != nil {
+		return err
+	}
+	result.RoomReleased = released && !queued
+	result.ReleaseQueued = queued
+	return nil
+}
+
+// damageKey prefixes the description of the damage found on one amenity at
+// one check-out, so a repeated attempt recognises what it already recorded
+func damageKey(contractCode string, roomAmenityID int) string {
+	return fmt.Sprintf("[%s/%d]", contractCode, roomAmenityID)
+}
+
+// damages returns the room's maintenance history recorded by check-outs, by damage key
+func (d *Desk) damages(ctx context.Context, roomID int) (map[string]models.MaintenanceHistory, error) {
+	histories, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.MaintenanceHistory], error) {
+		return d.api.MaintenanceHistory().GetListMaintenanceHistories(ctx, strconv.Itoa(page), strconv.Itoa(roomID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the maintenance history of the room: %w", err)
+	}
+
+	recorded := make(map[string]models.MaintenanceHistory)
+	for _, history := range histories {
+		if key, _, ok := strings.Cut(history.Description, " "); ok && strings.HasPrefix(key, "[") {
+			recorded[key] = history
+		}
+	}
+	return recorded, nil
+}
+
+// deducted reports whether a maintenance history was already charged to the deposit
+func deducted(statement *deposit.Statement, historyID int) bool {
+	for _, line := range statement.Deductions {
+		if line.MaintenanceHistoryID != nil && *line.MaintenanceHistoryID == historyID {
+			return true
+		}
+	}
+	return false
+}
+
+var conditionLabels = map[Condition]string{
+	ConditionGood:    "còn tốt",
+	ConditionDamaged: "hư hỏng",
+	ConditionMissing: "bị mất",
+}
+
+// release marks a room available once its last student has left and
+// reports whether that write was only queued. Rooms under maintenance keep
+// their status.
+func (d *Desk) release(ctx context.Context, roomID int) (bool, bool, error) {
+	room, err := d.api.Room().GetRoomDetails(ctx, roomID)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to reload room %d: %w", roomID, err)
+	}
+	if room.Data.UserCount > 0 || room.Data.Status != models.RoomStatusOccupied {
+		return false, false, nil
+	}
+
+	resp, err := d.api.Room().UpdateRoom(ctx, roomID, map[string]interface{}{
+		"status": models.RoomStatusAvailable,
+	})
+	if err != nil {
+		return false, false, fmt.Errorf("failed to mark room %s available: %w", room.Data.RoomNumber, err)
+	}
+	return true, resp.Queued, nil
+}
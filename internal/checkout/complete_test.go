@@ -0,0 +1,214 @@
+package checkout
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// desk is a fake server with student 1 in room 10 on contract 100, whose
+// deposit of 1,000,000 was charged 1,500,000 for damage. The failOn-th call
+// to the fail route answers 500.
+type desk struct {
+	mu       sync.Mutex
+	roomID   *int
+	contract models.Contract
+	deposit  models.Deposit
+	invoices []models.Invoice
+	calls    map[string]int
+	fail     string
+	failOn   int
+}
+
+func newDesk(t *testing.T, fail string, failOn int) (*desk, *api.API) {
+	t.Helper()
+
+	roomID := 10
+	d := &desk{
+		roomID: &roomID,
+		contract: models.Contract{
+			ID:        100,
+			Code:      "C100",
+			UserID:    1,
+			RoomID:    10,
+			StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+			EndDate:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local),
+			Price:     1_500_000,
+			Status:    models.ContractStatusActive,
+		},
+		deposit: models.Deposit{ID: 1, ContractID: 100, Status: models.DepositStatusHeld, Entries: []models.DepositEntry{
+			{ID: 1, Type: models.DepositEntryReceived, Amount: 1_000_000},
+			{ID: 2, Type: models.DepositEntryDeduction, Amount: 1_500_000},
+		}},
+		calls:  map[string]int{},
+		fail:   fail,
+		failOn: failOn,
+	}
+
+	mux := http.NewServeMux()
+	handle := func(pattern string, handler func(r *http.Request, body map[string]any) any) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+
+			d.calls[pattern]++
+			if pattern == d.fail && d.calls[pattern] == d.failOn {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+
+			data := handler(r, body)
+			total := 1
+			if list := reflect.ValueOf(data); list.Kind() == reflect.Slice {
+				total = list.Len()
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data, "total": total})
+		})
+	}
+
+	handle("GET /users/1", func(r *http.Request, body map[string]any) any {
+		return models.User{ID: 1, FullName: "SV1", StudentCode: "21520001", Role: models.UserRoleStudent, RoomID: d.roomID}
+	})
+	handle("GET /rooms/10", func(r *http.Request, body map[string]any) any {
+		room := models.Room{ID: 10, RoomNumber: "A101", Status: models.RoomStatusOccupied, RoomCategoryID: 1}
+		if d.roomID != nil {
+			room.UserCount = 1
+		}
+		return room
+	})
+	handle("PATCH /rooms/10", func(r *http.Request, body map[string]any) any {
+		return models.Room{ID: 10, RoomNumber: "A101", Status: models.RoomStatusAvailable, RoomCategoryID: 1}
+	})
+	handle("PUT /users/1/room", func(r *http.Request, body map[string]any) any {
+		d.roomID = nil
+		return models.User{ID: 1, FullName: "SV1", Role: models.UserRoleStudent}
+	})
+	handle("GET /contracts", func(r *http.Request, body map[string]any) any { return []models.Contract{d.contract} })
+	handle("GET /contracts/100", func(r *http.Request, body map[string]any) any { return d.contract })
+	handle("PATCH /contracts/100", func(r *http.Request, body map[string]any) any {
+		d.contract.EndDate, _ = time.ParseInLocation(time.DateOnly, body["end_date"].(string), time.Local)
+		d.contract.Status = models.ContractStatus(body["status"].(string))
+		return d.contract
+	})
+	handle("GET /contracts/100/deposit", func(r *http.Request, body map[string]any) any { return d.deposit })
+	handle("POST /contracts/100/deposit/settle", func(r *http.Request, body map[string]any) any {
+		d.deposit.Status = models.DepositStatusSettled
+		return d.deposit
+	})
+	handle("GET /maintenance-histories", func(r *http.Request, body map[string]any) any { return []models.MaintenanceHistory{} })
+	handle("GET /invoices", func(r *http.Request, body map[string]any) any {
+		query := r.URL.Query()
+		invoices := []models.Invoice{}
+		for _, invoice := range d.invoices {
+			if kind := query.Get("type"); kind != "" && string(invoice.Type) != kind {
+				continue
+			}
+			if period := query.Get("billing_period"); period != "" && invoice.BillingPeriod != period {
+				continue
+			}
+			invoices = append(invoices, invoice)
+		}
+		return invoices
+	})
+	handle("POST /invoices", func(r *http.Request, body map[string]any) any {
+		contractID := int(body["contract_id"].(float64))
+		invoice := models.Invoice{
+			ID:         len(d.invoices) + 1,
+			Code:       body["code"].(string),
+			UserID:     int(body["user_id"].(float64)),
+			ContractID: &contractID,
+			Type:       models.InvoiceType(body["type"].(string)),
+			Amount:     body["amount"].(float64),
+			Status:     models.InvoiceStatusPending,
+		}
+		if period, ok := body["billing_period"].(string); ok {
+			invoice.BillingPeriod = period
+		}
+		d.invoices = append(d.invoices, invoice)
+		return invoice
+	})
+	handle("POST /payments", func(r *http.Request, body map[string]any) any {
+		id := int(body["invoice_id"].(float64))
+		d.invoices[id-1].PaidAmount = d.invoices[id-1].Amount
+		d.invoices[id-1].Status = models.InvoiceStatusPaid
+		return models.Payment{ID: id, InvoiceID: id, Amount: body["amount"].(float64), PaidAt: time.Now()}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return d, api.NewAPI(client.New().SetBaseURL(server.URL).SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+}
+
+func TestCompleteAgain(t *testing.T) {
+	tests := []struct {
+		name   string
+		fail   string
+		failOn int
+	}{
+		{"completed at once", "", 0},
+		{"deposit not settled after invoicing the shortfall", "POST /contracts/100/deposit/settle", 1},
+		{"student not removed after the contract ended", "PUT /users/1/room", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, a := newDesk(t, tt.fail, tt.failOn)
+			req := Request{
+				UserID:         1,
+				Date:           time.Date(2026, 3, 15, 0, 0, 0, 0, time.Local),
+				PayOutstanding: true,
+				PaymentMethod:  models.PaymentMethodCash,
+			}
+
+			result, err := NewDesk(a).Complete(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if result.Completed != (tt.fail == "") {
+				t.Fatalf("Complete() completed = %v, error %q", result.Completed, result.Error)
+			}
+			if !result.Completed {
+				if result, err = NewDesk(a).Complete(context.Background(), req); err != nil || !result.Completed {
+					t.Fatalf("Complete() again = %+v, %v", result, err)
+				}
+			}
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			codes := map[string]int{}
+			for _, invoice := range d.invoices {
+				codes[invoice.Code]++
+				if invoice.Status != models.InvoiceStatusPaid {
+					t.Errorf("invoice %s is %s, want paid", invoice.Code, invoice.Status)
+				}
+			}
+			if len(d.invoices) != 2 || codes["DEP-C100"] != 1 {
+				t.Errorf("invoices = %v, want the last month and one shortfall", codes)
+			}
+			if d.roomID != nil || d.contract.Status != models.ContractStatusInactive || d.deposit.Status != models.DepositStatusSettled {
+				t.Errorf("room %v, contract %s, deposit %s; want no room, inactive, settled", d.roomID, d.contract.Status, d.deposit.Status)
+			}
+			if got := d.calls["PATCH /contracts/100"]; got != 1 {
+				t.Errorf("contract ended %d times, want once", got)
+			}
+			if !result.RoomReleased {
+				t.Error("room was not released")
+			}
+			if ended := d.contract.EndDate.Format(time.DateOnly); ended != "2026-03-15" {
+				t.Errorf("contract ends %s, want 2026-03-15", ended)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CacheEntry is a successful GET response kept for offline use
+type CacheEntry struct {
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header"`
+	Body       []byte              `json:"body"`
+	StoredAt   time.Time           `json:"stored_at"`
+}
+
+// Cache stores the last successful response per scope and request URL
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Put(key string, entry *CacheEntry) error
+}
+
+// SetCache sets the cache used by requests marked with SetCacheable. Nil disables caching.
+func (c *Client) SetCache(cache Cache) *Client {
+	c.cache = cache
+	return c
+}
+
+// SetCacheScope namespaces cached responses, normally by the signed-in
+// user's ID, so that one user is never served another user's responses.
+// Requests bypass the cache while the scope is empty.
+func (c *Client) SetCacheScope(scope string) *Client {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+	c.cacheScope = scope
+	return c
+}
+
+// CacheScope returns the scope set with SetCacheScope
+func (c *Client) CacheScope() string {
+	c.settingsMu.RLock()
+	defer c.settingsMu.RUnlock()
+	return c.cacheScope
+}
+
+// SetCacheable stores this GET request's response for offline use and
+// revalidates it with If-None-Match on later calls
+func (r *RequestBuilder) SetCacheable() *RequestBuilder {
+	r.cacheable = true
+	return r
+}
+
+type allowStaleKey struct{}
+
+// AllowStale marks reads made with ctx as accepting a stale cached body when
+// the server is unreachable or failing. Without it a cacheable read still
+// revalidates with If-None-Match but fails like any other request, so that
+// code deciding on money or occupancy never acts on an old copy unknowingly.
+func AllowStale(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowStaleKey{}, true)
+}
+
+func staleAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(allowStaleKey{}).(bool)
+	return allowed
+}
+
+// doCachedRequest serves a GET through the cache: a 304 returns the cached
+// body, and when the server is unreachable the cached body is returned as
+// stale if the request context allows it
+func (c *Client) doCachedRequest(scope, urlStr string, r *RequestBuilder) (*Response, error) {
+	fullURL, err := c.buildURL(urlStr, r)
+	if err != nil {
+		return nil, err
+	}
+	key := scope + " " + fullURL
+
+	entry, cached := c.cache.Get(key)
+	if cached {
+		if etag := firstHeader(entry.Header, "Etag"); etag != "" {
+			r.headers["If-None-Match"] = etag
+		}
+	}
+
+	fallback := cached && staleAllowed(r.ctx)
+	resp, err := c.doAuthorizedRequest(http.MethodGet, urlStr, r)
+	switch {
+	case err != nil:
+		if fallback && isNetworkError(err) {
+			return entry.response(true), nil
+		}
+		return nil, err
+	case resp.StatusCode == http.StatusNotModified && cached:
+		entry.StoredAt = time.Now()
+		c.cache.Put(key, entry)
+		return entry.response(false), nil
+	case isRetryableStatus(resp.StatusCode) && fallback:
+		return entry.response(true), nil
+	case resp.StatusCode == http.StatusOK:
+		c.cache.Put(key, &CacheEntry{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       resp.RawBody(),
+			StoredAt:   time.Now(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (e *CacheEntry) response(stale bool) *Response {
+	resp := newResponse(&http.Response{
+		Status:     fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode: e.StatusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     e.Header,
+	}, e.Body)
+	resp.Stale = stale
+	resp.CachedAt = e.StoredAt
+
+	return resp
+}
+
+func firstHeader(header map[string][]string, key string) string {
+	if values := http.Header(header).Values(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
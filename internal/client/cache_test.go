@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryCache is an in-memory Cache
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+}
+
+func (m *memoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *entry
+	return &copied, true
+}
+
+func (m *memoryCache) Put(key string, entry *CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+	return nil
+}
+
+func TestCachedRequest(t *testing.T) {
+	const body = `{"success":true,"data":{"id":1}}`
+	timedOut := func(err error) bool { return errors.Is(err, context.DeadlineExceeded) }
+
+	tests := []struct {
+		name string
+		// mode is how the server answers the second request
+		mode       string
+		allowStale bool
+		scope      string
+		wantStatus int
+		wantStale  bool
+		wantErr    func(error) bool
+	}{
+		{"not modified", "etag", false, "7", http.StatusOK, false, nil},
+		{"changed", "fresh", false, "7", http.StatusOK, false, nil},
+		{"gateway error", "unavailable", false, "7", http.StatusServiceUnavailable, false, nil},
+		{"gateway error, stale allowed", "unavailable", true, "7", http.StatusOK, true, nil},
+		{"unreachable", "down", false, "7", 0, false, isNetworkError},
+		{"unreachable, stale allowed", "down", true, "7", http.StatusOK, true, nil},
+		{"timed out, stale allowed", "slow", true, "7", 0, false, timedOut},
+		{"signed out", "unavailable", true, "", http.StatusServiceUnavailable, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mode atomic.Value
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch mode.Load() {
+				case "etag":
+					if r.Header.Get("If-None-Match") != `"v1"` {
+						t.Errorf("revalidated with If-None-Match %q, want \"v1\"", r.Header.Get("If-None-Match"))
+					}
+					w.WriteHeader(http.StatusNotModified)
+					return
+				case "unavailable":
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				case "down":
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				case "slow":
+					time.Sleep(100 * time.Millisecond)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Etag", `"v1"`)
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			cache := &memoryCache{entries: map[string]*CacheEntry{}}
+			c := New().SetBaseURL(server.URL).SetCache(cache).SetCacheScope(tt.scope).
+				SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+			if _, err := c.R().SetCacheable().Get("/rooms/1"); err != nil {
+				t.Fatalf("first Get() error = %v", err)
+			}
+
+			mode.Store(tt.mode)
+			ctx := context.Background()
+			if tt.allowStale {
+				ctx = AllowStale(ctx)
+			}
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			resp, err := c.R().SetContext(ctx).SetCacheable().Get("/rooms/1")
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("Get() error = %v, stale %v", err, resp != nil && resp.Stale)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus || resp.Stale != tt.wantStale {
+				t.Errorf("Get() = %d, stale %v; want %d, stale %v", resp.StatusCode, resp.Stale, tt.wantStatus, tt.wantStale)
+			}
+			if resp.StatusCode == http.StatusOK && resp.String() != body {
+				t.Errorf("Get() body = %s, want the cached body", resp.String())
+			}
+		})
+	}
+}
+
+func TestCancelledRequestIsNotANetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := New().SetBaseURL(server.URL).R().SetContext(ctx).Get("/rooms")
+	if !errors.Is(err, context.Canceled) || isNetworkError(err) {
+		t.Errorf("Get() error = %v, want context.Canceled", err)
+	}
+}
@@ -3,12 +3,16 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Response wraps the standard http.Response with additional functionality to mimic resty.Response
@@ -27,6 +31,15 @@ type Response struct {
 
 	body       []byte
 	ParsedBody interface{} `json:"ParsedBody"` // Parsed JSON body for JavaScript consumption
+
+	// Stale is set when the body was served from the cache because the server
+	// was unreachable and the request context allowed it, see AllowStale
+	Stale bool `json:"Stale"`
+	// CachedAt is when a cached body was stored; zero for live responses
+	CachedAt time.Time `json:"CachedAt"`
+	// Queued is set when the request was stored in the outbox instead of being sent
+	Queued   bool   `json:"Queued"`
+	QueuedID string `json:"QueuedID"`
 }
 
 // RawBody returns the response body as bytes
@@ -47,8 +60,17 @@ func (r *Response) IsError() bool {
 // Client wraps the standard http.Client with additional functionality to mimic resty.Client
 type Client struct {
 	httpClient *http.Client
+	settingsMu sync.RWMutex
 	baseURL    string
+	timeout    time.Duration
+	retry      RetryPolicy
+	cache      Cache
+	cacheScope string
+	outbox     Outbox
+	logger     *slog.Logger
+	headersMu  sync.RWMutex
 	headers    map[string]string
+	tokens     tokenSource
 }
 
 // New creates a new HTTP client wrapper that mimics resty.Client
@@ -56,34 +78,109 @@ func New() *Client {
 	return &Client{
 		httpClient: &http.Client{},
 		headers:    make(map[string]string),
+		retry:      DefaultRetryPolicy(),
 	}
 }
 
 // SetBaseURL sets the base URL for all requests
 func (c *Client) SetBaseURL(baseURL string) *Client {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
 	c.baseURL = strings.TrimSuffix(baseURL, "/")
 	return c
 }
 
+// BaseURL returns the base URL requests are sent to
+func (c *Client) BaseURL() string {
+	c.settingsMu.RLock()
+	defer c.settingsMu.RUnlock()
+	return c.baseURL
+}
+
+// SetTLSConfig sets the TLS settings used to connect to the server
+func (c *Client) SetTLSConfig(config *tls.Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	c.httpClient.Transport = transport
+	return c
+}
+
+// SetTimeout sets the default timeout applied to every request attempt. Zero disables it.
+func (c *Client) SetTimeout(timeout time.Duration) *Client {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+	c.timeout = timeout
+	return c
+}
+
 // SetHeader sets a default header for all requests
 func (c *Client) SetHeader(key, value string) *Client {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
 	c.headers[key] = value
 	return c
 }
 
+// SetToken sets the token pair used to authorize requests
+func (c *Client) SetToken(accessToken, refreshToken string) *Client {
+	c.tokens.set(NewToken(accessToken, refreshToken))
+	return c
+}
+
+// ClearToken drops the current token pair
+func (c *Client) ClearToken() *Client {
+	c.tokens.set(nil)
+	return c
+}
+
+// Token returns a copy of the current token pair, or nil when signed out
+func (c *Client) Token() *Token {
+	return c.tokens.current()
+}
+
+// SetRefreshFunc sets the function used to renew an expired access token
+func (c *Client) SetRefreshFunc(fn RefreshFunc) *Client {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	c.tokens.refresh = fn
+	return c
+}
+
+// OnTokenRefreshed registers a callback fired after the token pair has been renewed.
+// It runs while the refresh lock is held and must not call back into the client's token methods.
+func (c *Client) OnTokenRefreshed(fn func(Token)) *Client {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	c.tokens.onRefreshed = fn
+	return c
+}
+
+// OnSessionExpired registers a callback fired when the token can no longer be refreshed
+func (c *Client) OnSessionExpired(fn func()) *Client {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	c.tokens.onExpired = fn
+	return c
+}
+
 // Request builder for chaining - mimics resty.Request
 type RequestBuilder struct {
 	client      *Client
+	ctx         context.Context
 	body        interface{}
 	headers     map[string]string
 	queryParams map[string]string
 	pathParams  map[string]string
+	skipAuth    bool
+	cacheable   bool
+	queueKind   string
 }
 
 // R creates a new request builder that mimics resty.Client.R()
 func (c *Client) R() *RequestBuilder {
 	return &RequestBuilder{
 		client:      c,
+		ctx:         context.Background(),
 		headers:     make(map[string]string),
 		queryParams: make(map[string]string),
 		pathParams:  make(map[string]string),
@@ -96,6 +193,18 @@ func (r *RequestBuilder) SetHeader(key, value string) *RequestBuilder {
 	return r
 }
 
+// SetContext sets the context the request is bound to; cancelling it aborts the request
+func (r *RequestBuilder) SetContext(ctx context.Context) *RequestBuilder {
+	r.ctx = ctx
+	return r
+}
+
+// SkipAuth sends this request without the Authorization header, e.g. for the token refresh call
+func (r *RequestBuilder) SkipAuth() *RequestBuilder {
+	r.skipAuth = true
+	return r
+}
+
 // SetBody sets the request body
 func (r *RequestBuilder) SetBody(body interface{}) *RequestBuilder {
 	r.body = body
@@ -116,91 +225,187 @@ func (r *RequestBuilder) SetPathParam(key, value string) *RequestBuilder {
 
 // Get executes a GET request
 func (r *RequestBuilder) Get(url string) (*Response, error) {
-	return r.client.doRequest(http.MethodGet, url, r.body, r.queryParams, r.pathParams, r.headers)
+	return r.client.doRequest(http.MethodGet, url, r)
 }
 
 // Post executes a POST request
 func (r *RequestBuilder) Post(url string) (*Response, error) {
-	return r.client.doRequest(http.MethodPost, url, r.body, r.queryParams, r.pathParams, r.headers)
+	return r.client.doRequest(http.MethodPost, url, r)
 }
 
 // Put executes a PUT request
 func (r *RequestBuilder) Put(url string) (*Response, error) {
-	return r.client.doRequest(http.MethodPut, url, r.body, r.queryParams, r.pathParams, r.headers)
+	return r.client.doRequest(http.MethodPut, url, r)
 }
 
 // Patch executes a PATCH request
 func (r *RequestBuilder) Patch(url string) (*Response, error) {
-	return r.client.doRequest(http.MethodPatch, url, r.body, r.queryParams, r.pathParams, r.headers)
+	return r.client.doRequest(http.MethodPatch, url, r)
 }
 
 // Delete executes a DELETE request
 func (r *RequestBuilder) Delete(url string) (*Response, error) {
-	return r.client.doRequest(http.MethodDelete, url, r.body, r.queryParams, r.pathParams, r.headers)
+	return r.client.doRequest(http.MethodDelete, url, r)
+}
+
+// doRequest performs the actual HTTP request, going through the response cache
+// for cacheable GET requests and the outbox for queueable writes
+func (c *Client) doRequest(method, urlStr string, r *RequestBuilder) (*Response, error) {
+	if method == http.MethodGet && r.cacheable && c.cache != nil {
+		if scope := c.CacheScope(); scope != "" {
+			return c.doCachedRequest(scope, urlStr, r)
+		}
+	}
+
+	if r.queueKind == "" || c.outbox == nil {
+		return c.doAuthorizedRequest(method, urlStr, r)
+	}
+
+	if method == http.MethodPost && r.headers[IdempotencyKeyHeader] == "" {
+		r.SetIdempotencyKey()
+	}
+
+	// Sending now while older writes wait in the outbox could let one of
+	// them overwrite this one when it is replayed
+	pending, err := c.outbox.Pending()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	if pending {
+		return c.enqueue(method, urlStr, r)
+	}
+
+	resp, err := c.doAuthorizedRequest(method, urlStr, r)
+	if notSent(err) {
+		return c.enqueue(method, urlStr, r)
+	}
+
+	return resp, err
+}
+
+// doAuthorizedRequest sends the request with the current access token,
+// refreshing it and retrying once if the server rejects it
+func (c *Client) doAuthorizedRequest(method, urlStr string, r *RequestBuilder) (*Response, error) {
+	var accessToken string
+	if !r.skipAuth {
+		token, err := c.tokens.accessToken(r.ctx)
+		if err != nil {
+			return nil, err
+		}
+		accessToken = token
+	}
+
+	resp, err := c.sendWithRetry(method, urlStr, r, accessToken)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || accessToken == "" {
+		return resp, err
+	}
+
+	accessToken, err = c.tokens.invalidate(r.ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sendWithRetry(method, urlStr, r, accessToken)
 }
 
-// doRequest performs the actual HTTP request
-func (c *Client) doRequest(method, urlStr string, body interface{}, queryParams, pathParams, headers map[string]string) (*Response, error) {
+// buildURL resolves the request URL against the base URL and applies path and query parameters
+func (c *Client) buildURL(urlStr string, r *RequestBuilder) (string, error) {
 	// Build full URL
 	fullURL := urlStr
 	if !strings.HasPrefix(urlStr, "http") {
-		fullURL = c.baseURL + urlStr
+		fullURL = c.BaseURL() + urlStr
 	}
 
 	// Replace path parameters
-	for key, value := range pathParams {
+	for key, value := range r.pathParams {
 		fullURL = strings.ReplaceAll(fullURL, "{"+key+"}", value)
 	}
 
 	// Parse URL and add query parameters
 	parsedURL, err := url.Parse(fullURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
+		return "", fmt.Errorf("invalid URL: %w", err)
 	}
 
-	if queryParams != nil {
+	if r.queryParams != nil {
 		q := parsedURL.Query()
-		for key, value := range queryParams {
+		for key, value := range r.queryParams {
 			q.Add(key, value)
 		}
 		parsedURL.RawQuery = q.Encode()
 	}
 
+	return parsedURL.String(), nil
+}
+
+// send builds and executes a single HTTP request
+func (c *Client) send(method, urlStr string, r *RequestBuilder, accessToken string) (*Response, error) {
+	fullURL, err := c.buildURL(urlStr, r)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare request body
-	var bodyReader io.Reader
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+	var (
+		bodyReader io.Reader
+		bodyBytes  []byte
+	)
+	if r.body != nil {
+		bodyBytes, err = json.Marshal(r.body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal body: %w", err)
 		}
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
+	c.settingsMu.RLock()
+	timeout := c.timeout
+	c.settingsMu.RUnlock()
+
+	ctx := r.ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Create request
-	req, err := http.NewRequestWithContext(context.Background(), method, parsedURL.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	if body != nil {
+	if r.body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	// Set default headers
+	c.headersMu.RLock()
 	for key, value := range c.headers {
 		req.Header.Set(key, value)
 	}
+	c.headersMu.RUnlock()
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
 
 	// Set request-specific headers
-	for key, value := range headers {
+	for key, value := range r.headers {
 		req.Header.Set(key, value)
 	}
 
 	// Execute request
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, bodyBytes, resp, err, time.Since(start))
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		if ctx.Err() != nil {
+			// Cancelled or timed out: the server may still have received it
+			return nil, err
+		}
+		return nil, &NetworkError{Err: err}
 	}
 	defer resp.Body.Close()
 
@@ -210,6 +415,11 @@ func (c *Client) doRequest(method, urlStr string, body interface{}, queryParams,
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	return newResponse(resp, respBody), nil
+}
+
+// newResponse wraps an http.Response and its already read body
+func newResponse(resp *http.Response, respBody []byte) *Response {
 	// Parse JSON body if content-type is JSON
 	var parsedBody interface{}
 	contentType := resp.Header.Get("Content-Type")
@@ -236,5 +446,5 @@ func (c *Client) doRequest(method, urlStr string, body interface{}, queryParams,
 		Trailer:          resp.Trailer,
 		body:             respBody,
 		ParsedBody:       parsedBody,
-	}, nil
+	}
 }
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"changeme/internal/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SetLogger sets the logger every request is reported to. Nil disables request logging.
+func (c *Client) SetLogger(logger *slog.Logger) *Client {
+	c.logger = logger
+	return c
+}
+
+// logRequest reports one request attempt. Headers and bodies are only logged
+// at debug level, with credentials redacted.
+func (c *Client) logRequest(req *http.Request, body []byte, resp *http.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("latency", latency),
+	}
+	if req.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("query", logger.RedactQuery(req.URL.RawQuery)))
+	}
+
+	ctx := req.Context()
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header)))
+		if len(body) > 0 {
+			attrs = append(attrs, slog.String("body", redactBody(body)))
+		}
+	}
+
+	switch {
+	case err != nil:
+		c.logger.WarnContext(ctx, "http request failed", append(attrs, slog.String("error", err.Error()))...)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		c.logger.WarnContext(ctx, "http request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	default:
+		c.logger.InfoContext(ctx, "http request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	}
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for key, values := range header {
+		if logger.Sensitive(key) {
+			out[key] = logger.Redacted
+			continue
+		}
+		out[key] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+func redactBody(body []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(logger.RedactValue(decoded))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// QueuedRequest is a mutating request saved while the server was unreachable
+type QueuedRequest struct {
+	Kind      string            `json:"kind"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Body      json.RawMessage   `json:"body"`
+	Headers   map[string]string `json:"headers"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Outbox durably stores requests for later replay and returns the queued
+// operation's ID. Pending reports whether earlier requests still wait to be
+// replayed; new writes queue behind them so they cannot overtake them.
+type Outbox interface {
+	Enqueue(req *QueuedRequest) (string, error)
+	Pending() (bool, error)
+}
+
+// SetOutbox sets the outbox used by requests marked with SetQueueable. Nil disables queueing.
+func (c *Client) SetOutbox(outbox Outbox) *Client {
+	c.outbox = outbox
+	return c
+}
+
+// SetQueueable queues this request in the outbox instead of failing when the
+// server cannot be connected to, and behind earlier queued requests while
+// there are any. POST requests get an idempotency key so a replay cannot
+// apply them twice.
+func (r *RequestBuilder) SetQueueable(kind string) *RequestBuilder {
+	r.queueKind = kind
+	return r
+}
+
+// Replay sends a previously queued request. It is never queued again.
+func (c *Client) Replay(ctx context.Context, req *QueuedRequest) (*Response, error) {
+	r := c.R().SetContext(ctx)
+	for key, value := range req.Headers {
+		r.SetHeader(key, value)
+	}
+	if len(req.Body) > 0 {
+		r.SetBody(req.Body)
+	}
+
+	return c.doAuthorizedRequest(req.Method, req.URL, r)
+}
+
+// enqueue stores the request in the outbox and answers with a synthetic 202
+func (c *Client) enqueue(method, urlStr string, r *RequestBuilder) (*Response, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fullURL, err := c.buildURL(urlStr, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var body json.RawMessage
+	if r.body != nil {
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal body: %w", err)
+		}
+	}
+
+	id, err := c.outbox.Enqueue(&QueuedRequest{
+		Kind:      r.queueKind,
+		Method:    method,
+		URL:       fullURL,
+		Body:      body,
+		Headers:   r.headers,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue request: %w", err)
+	}
+
+	return &Response{
+		Status:     fmt.Sprintf("%d %s", http.StatusAccepted, http.StatusText(http.StatusAccepted)),
+		StatusCode: http.StatusAccepted,
+		Header:     map[string][]string{},
+		Queued:     true,
+		QueuedID:   id,
+	}, nil
+}
+
+func isNetworkError(err error) bool {
+	var netErr *NetworkError
+	return errors.As(err, &netErr)
+}
+
+// notSent reports whether err means the request never reached the server:
+// the host could not be resolved or connected to. Anything later, like a
+// dropped connection, may have been applied and must not be queued again.
+func notSent(err error) bool {
+	if !isNetworkError(err) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
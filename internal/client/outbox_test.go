@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryOutbox is an in-memory Outbox
+type memoryOutbox struct {
+	mu       sync.Mutex
+	pending  bool
+	requests []*QueuedRequest
+}
+
+func (m *memoryOutbox) Enqueue(req *QueuedRequest) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, req)
+	return strconv.Itoa(len(m.requests)), nil
+}
+
+func (m *memoryOutbox) Pending() (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending, nil
+}
+
+func TestQueueableRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		// mode is how the server answers; "closed" shuts it down before the request
+		mode       string
+		pending    bool
+		cancelled  bool
+		wantQueued bool
+		wantSent   int32
+		wantErr    func(error) bool
+	}{
+		{"sent when online", "ok", false, false, false, 1, nil},
+		{"queued when the server cannot be reached", "closed", false, false, true, 0, nil},
+		{"queued behind pending writes", "ok", true, false, true, 0, nil},
+		{"not queued after a dropped connection", "drop", false, false, false, 1, isNetworkError},
+		{"not queued after a timeout", "slow", false, false, false, 1, func(err error) bool { return errors.Is(err, context.DeadlineExceeded) }},
+		{"not queued when cancelled", "closed", true, true, false, 0, func(err error) bool { return errors.Is(err, context.Canceled) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent.Add(1)
+				switch tt.mode {
+				case "drop":
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				case "slow":
+					time.Sleep(100 * time.Millisecond)
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+			if tt.mode == "closed" {
+				server.Close()
+			}
+
+			outbox := &memoryOutbox{pending: tt.pending}
+			c := New().SetBaseURL(server.URL).SetOutbox(outbox).SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			resp, err := c.R().SetContext(ctx).SetQueueable("create_invoice").SetBody(map[string]int{"amount": 1}).Post("/invoices")
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("Post() error = %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("Post() error = %v", err)
+			} else if resp.Queued != tt.wantQueued {
+				t.Errorf("Post() queued = %v, want %v", resp.Queued, tt.wantQueued)
+			}
+
+			if queued := len(outbox.requests) > 0; queued != tt.wantQueued {
+				t.Errorf("outbox holds %d requests, want queued %v", len(outbox.requests), tt.wantQueued)
+			}
+			if tt.wantQueued && outbox.requests[0].Headers[IdempotencyKeyHeader] == "" {
+				t.Error("queued POST has no idempotency key")
+			}
+			if got := sent.Load(); got != tt.wantSent {
+				t.Errorf("server received %d requests, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader marks a non-idempotent request as safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how transient failures are retried
+type RetryPolicy struct {
+	// MaxAttempts caps the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and the Retry-After the client is willing to wait for
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by New
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// NetworkError is returned when the request failed in transport, e.g. the
+// server could not be reached or dropped the connection. Cancellations and
+// timeouts are returned as the context error instead.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return "request failed: " + e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// SetRetryPolicy sets the retry policy. A MaxAttempts of 1 disables retries.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
+
+// SetIdempotencyKey attaches a generated Idempotency-Key so the request can be
+// retried without the server applying it twice
+func (r *RequestBuilder) SetIdempotencyKey() *RequestBuilder {
+	r.headers[IdempotencyKeyHeader] = uuid.NewString()
+	return r
+}
+
+// sendWithRetry executes the request, retrying network errors and gateway
+// failures for requests that are safe to repeat
+func (c *Client) sendWithRetry(method, urlStr string, r *RequestBuilder, accessToken string) (*Response, error) {
+	attempts := max(c.retry.MaxAttempts, 1)
+	if !r.retryable(method) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(method, urlStr, r, accessToken)
+		if attempt >= attempts || r.ctx.Err() != nil {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			// The caller's context is still live here, so a deadline is the
+			// per-attempt timeout and the request is safe to send again
+			if !isNetworkError(err) && !errors.Is(err, context.DeadlineExceeded) {
+				return resp, err
+			}
+			delay = c.retry.backoff(attempt)
+		case isRetryableStatus(resp.StatusCode):
+			delay = c.retry.backoff(attempt)
+			if after, ok := retryAfter(resp); ok {
+				if after > c.retry.MaxDelay {
+					return resp, nil
+				}
+				delay = after
+			}
+		default:
+			return resp, nil
+		}
+
+		if err := sleep(r.ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *RequestBuilder) retryable(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.headers[IdempotencyKeyHeader] != ""
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns a full-jitter exponential delay for the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// retryAfter parses the Retry-After header, either delay-seconds or an HTTP date
+func retryAfter(resp *Response) (time.Duration, bool) {
+	values := resp.Header["Retry-After"]
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(values[0]); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(values[0]); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
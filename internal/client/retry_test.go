@@ -0,0 +1,148 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+func TestRetry(t *testing.T) {
+	// drop closes the connection without an answer
+	drop := func(w http.ResponseWriter) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		idempotent bool
+		// answers are replayed in order, one per attempt; -1 drops the connection
+		answers      []int
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int
+	}{
+		{"get recovers from a gateway error", http.MethodGet, false, []int{503, 502, 200}, "", 200, 3},
+		{"get gives up after max attempts", http.MethodGet, false, []int{504, 504, 504, 200}, "", 504, 3},
+		{"get recovers from a dropped connection", http.MethodGet, false, []int{-1, 200}, "", 200, 2},
+		{"server errors are not retried", http.MethodGet, false, []int{500, 200}, "", 500, 1},
+		{"client errors are not retried", http.MethodPut, false, []int{409, 200}, "", 409, 1},
+		{"post without a key is sent once", http.MethodPost, false, []int{503, 200}, "", 503, 1},
+		{"post with a key is retried", http.MethodPost, true, []int{503, 201}, "", 201, 2},
+		{"retry-after within the limit", http.MethodGet, false, []int{503, 200}, "0", 200, 2},
+		{"retry-after beyond the limit", http.MethodGet, false, []int{503, 200}, "120", 503, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				attempts int
+				keys     = map[string]bool{}
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				answer := tt.answers[attempts]
+				attempts++
+				keys[r.Header.Get(IdempotencyKeyHeader)] = true
+				mu.Unlock()
+
+				if answer < 0 {
+					drop(w)
+					return
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(answer)
+			}))
+			defer server.Close()
+
+			c := New().SetBaseURL(server.URL).SetRetryPolicy(testRetryPolicy)
+			r := c.R()
+			if tt.idempotent {
+				r.SetIdempotencyKey()
+			}
+
+			var resp *Response
+			var err error
+			switch tt.method {
+			case http.MethodGet:
+				resp, err = r.Get("/rooms")
+			case http.MethodPut:
+				resp, err = r.Put("/rooms/1")
+			case http.MethodPost:
+				resp, err = r.Post("/rooms")
+			}
+			if err != nil {
+				t.Fatalf("%s error = %v", tt.method, err)
+			}
+			if resp.StatusCode != tt.wantStatus || attempts != tt.wantAttempts {
+				t.Errorf("%s = %d after %d attempts, want %d after %d", tt.method, resp.StatusCode, attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if tt.idempotent && len(keys) != 1 {
+				t.Errorf("attempts sent %d idempotency keys, want the same key every time", len(keys))
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{64, time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			if got := policy.backoff(tt.attempt); got <= 0 || got > tt.ceiling {
+				t.Fatalf("backoff(%d) = %v, want within (0, %v]", tt.attempt, got, tt.ceiling)
+			}
+		}
+	}
+
+	if got := (RetryPolicy{}).backoff(1); got != 0 {
+		t.Errorf("backoff() without delays = %v, want 0", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", "3", 3 * time.Second, true},
+		{"zero", "0", 0, true},
+		{"date in the past", "Mon, 01 Jan 2024 00:00:00 GMT", 0, true},
+		{"negative", "-1", 0, false},
+		{"garbage", "soon", 0, false},
+		{"missing", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{Header: map[string][]string{}}
+			if tt.value != "" {
+				resp.Header["Retry-After"] = []string{tt.value}
+			}
+			if got, ok := retryAfter(resp); got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
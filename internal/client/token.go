@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expirySkew is how long before the JWT exp claim a token is refreshed proactively
+const expirySkew = 30 * time.Second
+
+// ErrSessionExpired is returned when the access token can no longer be renewed
+var ErrSessionExpired = errors.New("session expired")
+
+// Token holds the access/refresh token pair issued by the auth endpoints
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// NewToken builds a Token and reads its expiry from the access token's exp claim
+func NewToken(accessToken, refreshToken string) *Token {
+	return &Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expiry:       jwtExpiry(accessToken),
+	}
+}
+
+func (t *Token) expiresWithin(d time.Duration) bool {
+	return !t.Expiry.IsZero() && time.Now().Add(d).After(t.Expiry)
+}
+
+// RefreshFunc exchanges a refresh token for a new token pair. It must return an
+// error wrapping ErrSessionExpired when the server rejects the refresh token;
+// any other error is treated as transient and the session is kept.
+type RefreshFunc func(ctx context.Context, refreshToken string) (*Token, error)
+
+// tokenSource serializes token refreshes so that concurrent requests wait for a
+// single refresh call instead of each issuing their own
+type tokenSource struct {
+	mu          sync.Mutex
+	token       *Token
+	refresh     RefreshFunc
+	onRefreshed func(Token)
+	onExpired   func()
+}
+
+func (ts *tokenSource) set(token *Token) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.token = token
+}
+
+func (ts *tokenSource) current() *Token {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token == nil {
+		return nil
+	}
+	token := *ts.token
+	return &token
+}
+
+// accessToken returns the token to send, refreshing it first if it is about to expire
+func (ts *tokenSource) accessToken(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == nil {
+		return "", nil
+	}
+
+	if ts.token.expiresWithin(expirySkew) {
+		if err := ts.refreshLocked(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return ts.token.AccessToken, nil
+}
+
+// invalidate is called after a 401 for a request sent with the given token. If
+// another request already refreshed it, the current token is returned as is.
+func (ts *tokenSource) invalidate(ctx context.Context, used string) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == nil {
+		return "", ErrSessionExpired
+	}
+
+	if ts.token.AccessToken != used {
+		return ts.token.AccessToken, nil
+	}
+
+	if err := ts.refreshLocked(ctx); err != nil {
+		return "", err
+	}
+
+	return ts.token.AccessToken, nil
+}
+
+func (ts *tokenSource) refreshLocked(ctx context.Context) error {
+	if ts.refresh == nil || ts.token.RefreshToken == "" {
+		ts.expireLocked()
+		return ErrSessionExpired
+	}
+
+	token, err := ts.refresh(ctx, ts.token.RefreshToken)
+	if err != nil {
+		if errors.Is(err, ErrSessionExpired) {
+			ts.expireLocked()
+			return err
+		}
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = ts.token.RefreshToken
+	}
+	ts.token = token
+	if ts.onRefreshed != nil {
+		ts.onRefreshed(*token)
+	}
+	return nil
+}
+
+func (ts *tokenSource) expireLocked() {
+	ts.token = nil
+	if ts.onExpired != nil {
+		go ts.onExpired()
+	}
+}
+
+// jwtExpiry reads the exp claim of a JWT without verifying its signature
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwt builds an unsigned token carrying only the exp claim
+func jwt(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".sig"
+}
+
+// authServer answers 200 only for the given access token and 401 otherwise
+func authServer(t *testing.T, accessToken string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRefreshSingleFlight(t *testing.T) {
+	fresh := jwt(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name   string
+		access string
+	}{
+		// The server rejects the token and every request has to retry with a new one
+		{"rejected token", "revoked-token"},
+		// The exp claim has passed, so the token is renewed before sending
+		{"expired token", jwt(time.Now().Add(-time.Minute))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := authServer(t, fresh)
+
+			var refreshes atomic.Int32
+			var refreshed atomic.Value
+			c := New().SetBaseURL(server.URL).
+				SetToken(tt.access, "refresh-1").
+				SetRefreshFunc(func(ctx context.Context, refreshToken string) (*Token, error) {
+					refreshes.Add(1)
+					if refreshToken != "refresh-1" {
+						t.Errorf("refreshed with %q, want refresh-1", refreshToken)
+					}
+					time.Sleep(20 * time.Millisecond)
+					// The server may keep the refresh token; the old one is reused then
+					return NewToken(fresh, ""), nil
+				}).
+				OnTokenRefreshed(func(token Token) { refreshed.Store(token) })
+
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, err := c.R().Get("/me")
+					if err != nil || resp.StatusCode != http.StatusOK {
+						t.Errorf("Get() = %v, %v; want 200", resp, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := refreshes.Load(); got != 1 {
+				t.Errorf("refreshed %d times, want once", got)
+			}
+			if token := c.Token(); token == nil || token.AccessToken != fresh || token.RefreshToken != "refresh-1" {
+				t.Errorf("Token() = %+v, want the new access token with the old refresh token", token)
+			}
+			if token, _ := refreshed.Load().(Token); token.AccessToken != fresh {
+				t.Errorf("OnTokenRefreshed got %+v, want the new token", token)
+			}
+		})
+	}
+}
+
+func TestRefreshFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		refreshErr  error
+		wantExpired bool
+	}{
+		{"rejected refresh token", fmt.Errorf("%w: invalid refresh token", ErrSessionExpired), true},
+		{"server unreachable", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := authServer(t, "never")
+
+			expired := make(chan struct{}, 1)
+			c := New().SetBaseURL(server.URL).
+				SetToken("stale", "refresh-1").
+				SetRefreshFunc(func(ctx context.Context, refreshToken string) (*Token, error) {
+					return nil, tt.refreshErr
+				}).
+				OnSessionExpired(func() { expired <- struct{}{} })
+
+			_, err := c.R().Get("/me")
+			if err == nil {
+				t.Fatal("Get() succeeded, want the refresh error")
+			}
+			if errors.Is(err, ErrSessionExpired) != tt.wantExpired {
+				t.Errorf("Get() error = %v, want session expired: %v", err, tt.wantExpired)
+			}
+
+			if tt.wantExpired {
+				select {
+				case <-expired:
+				case <-time.After(time.Second):
+					t.Error("OnSessionExpired was not called")
+				}
+				if c.Token() != nil {
+					t.Error("Token() kept the expired session")
+				}
+				return
+			}
+			if c.Token() == nil {
+				t.Error("Token() dropped the session after a transient failure")
+			}
+		})
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Unix(1767225600, 0)
+
+	tests := []struct {
+		name  string
+		token string
+		want  time.Time
+	}{
+		{"exp claim", jwt(exp), exp},
+		{"not a jwt", "opaque-token", time.Time{}},
+		{"bad payload", "a.!!!.c", time.Time{}},
+		{"no exp claim", "a." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1"}`)) + ".c", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jwtExpiry(tt.token); !got.Equal(tt.want) {
+				t.Errorf("jwtExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
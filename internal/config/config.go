@@ -1,33 +1,344 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 
 	"gopkg.in/yaml.v2"
 )
 
+// AppName is the directory name used for per-user application data
+const AppName = "desktop-app-golang"
+
+const (
+	// EnvConfigPath points at an explicit config file, like the --config flag
+	EnvConfigPath = "DORM_CONFIG"
+	// EnvPrefix prefixes per-field overrides, e.g. DORM_CLIENT_BASE_URL
+	EnvPrefix = "DORM_"
+)
+
+// DefaultProfile names the profile built from the client block when no profiles are configured
+const DefaultProfile = "default"
+
 type (
 	ClientConfig struct {
-		BaseURL string `yaml:"base_url"`
-		Timeout int    `yaml:"timeout"`
+		BaseURL string `yaml:"base_url" json:"base_url"`
+		Timeout int    `yaml:"timeout" json:"timeout"`
+	}
+	TLSConfig struct {
+		// CAFile is a PEM bundle trusted in addition to the system roots
+		CAFile             string `yaml:"ca_file" json:"ca_file"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify" json:"insecure_skip_verify"`
+	}
+	// ProfileConfig describes one backend the app can connect to. Name is the
+	// stable identifier used for per-profile data; DisplayName is shown in the UI.
+	ProfileConfig struct {
+		Name        string    `yaml:"name" json:"name"`
+		DisplayName string    `yaml:"display_name" json:"display_name"`
+		BaseURL     string    `yaml:"base_url" json:"base_url"`
+		Timeout     int       `yaml:"timeout" json:"timeout"`
+		TLS         TLSConfig `yaml:"tls" json:"tls"`
 	}
 	LoggerConfig struct {
-		Level string `yaml:"level"`
+		Level      string `yaml:"level" json:"level"`
+		Format     string `yaml:"format" json:"format"`
+		Output     string `yaml:"output" json:"output"`
+		File       string `yaml:"file" json:"file"`
+		MaxSize    int    `yaml:"max_size" json:"max_size"`
+		MaxBackups int    `yaml:"max_backups" json:"max_backups"`
+		MaxAge     int    `yaml:"max_age" json:"max_age"`
+		Compress   bool   `yaml:"compress" json:"compress"`
+	}
+	// TariffTier prices the units consumed above the previous tier's bound
+	TariffTier struct {
+		// UpTo is the upper bound of the tier in units; zero means unbounded
+		UpTo  float64 `yaml:"up_to" json:"up_to"`
+		Price float64 `yaml:"price" json:"price"`
+	}
+	TariffConfig struct {
+		// MeterMax is the largest reading a meter shows before wrapping to zero;
+		// zero disables rollover detection
+		MeterMax float64      `yaml:"meter_max" json:"meter_max"`
+		Tiers    []TariffTier `yaml:"tiers" json:"tiers"`
+	}
+	MeteringConfig struct {
+		Electricity TariffConfig `yaml:"electricity" json:"electricity"`
+		Water       TariffConfig `yaml:"water" json:"water"`
+	}
+	// DunningConfig controls how overdue invoices are chased. The notice
+	// thresholds count days past the end of the grace period.
+	DunningConfig struct {
+		Enabled bool `yaml:"enabled" json:"enabled"`
+		// Interval is the number of minutes between runs
+		Interval  int `yaml:"interval" json:"interval"`
+		GraceDays int `yaml:"grace_days" json:"grace_days"`
+		// FeeType is "none", "flat" or "percentage"; FeeAmount is dong or percent of the outstanding amount
+		FeeType         string  `yaml:"fee_type" json:"fee_type"`
+		FeeAmount       float64 `yaml:"fee_amount" json:"fee_amount"`
+		ReminderDays    int     `yaml:"reminder_days" json:"reminder_days"`
+		WarningDays     int     `yaml:"warning_days" json:"warning_days"`
+		FinalNoticeDays int     `yaml:"final_notice_days" json:"final_notice_days"`
+		// FlagAccount sets the student's account to FlagStatus once the final notice is sent
+		FlagAccount bool   `yaml:"flag_account" json:"flag_account"`
+		FlagStatus  string `yaml:"flag_status" json:"flag_status"`
+	}
+	// BankConfig is the account students transfer rent to, used for VietQR codes
+	BankConfig struct {
+		// BIN is the 6-digit NAPAS bank identification number, e.g. 970436 for Vietcombank
+		BIN           string `yaml:"bin" json:"bin"`
+		AccountNumber string `yaml:"account_number" json:"account_number"`
+		AccountName   string `yaml:"account_name" json:"account_name"`
+	}
+	// ContractsConfig sets the terms applied when a contract ends early
+	ContractsConfig struct {
+		// NoticeDays is how long before leaving a student must terminate
+		NoticeDays int `yaml:"notice_days" json:"notice_days"`
+		// PenaltyMonths of rent are charged when less notice is given
+		PenaltyMonths float64      `yaml:"penalty_months" json:"penalty_months"`
+		Expiry        ExpiryConfig `yaml:"expiry" json:"expiry"`
+	}
+	// ExpiryConfig controls the watcher that reminds staff of contracts about to end
+	ExpiryConfig struct {
+		Enabled bool `yaml:"enabled" json:"enabled"`
+		// Interval is the number of minutes between scans
+		Interval int `yaml:"interval" json:"interval"`
+		// Windows are the days before the end date at which a contract is reported
+		Windows []int `yaml:"windows" json:"windows"`
+		// DesktopNotifications shows a native notification when contracts enter a window
+		DesktopNotifications bool `yaml:"desktop_notifications" json:"desktop_notifications"`
 	}
 )
 
 type Config struct {
-	Client ClientConfig `yaml:"client"`
-	Logger LoggerConfig `yaml:"logging"`
+	Client        ClientConfig    `yaml:"client" json:"client"`
+	Profiles      []ProfileConfig `yaml:"profiles" json:"profiles"`
+	ActiveProfile string          `yaml:"active_profile" json:"active_profile"`
+	Logger        LoggerConfig    `yaml:"logging" json:"logging"`
+	Metering      MeteringConfig  `yaml:"metering" json:"metering"`
+	Dunning       DunningConfig   `yaml:"dunning" json:"dunning"`
+	Bank          BankConfig      `yaml:"bank" json:"bank"`
+	Contracts     ContractsConfig `yaml:"contracts" json:"contracts"`
+}
+
+// ServerProfiles returns the configured profiles with defaults applied. When
+// none are configured, a single "default" profile is built from the client block.
+func (c *Config) ServerProfiles() []ProfileConfig {
+	if len(c.Profiles) == 0 {
+		return []ProfileConfig{{
+			Name:        DefaultProfile,
+			DisplayName: "Default",
+			BaseURL:     c.Client.BaseURL,
+			Timeout:     c.Client.Timeout,
+		}}
+	}
+
+	profiles := make([]ProfileConfig, len(c.Profiles))
+	for i, profile := range c.Profiles {
+		if profile.Timeout == 0 {
+			profile.Timeout = c.Client.Timeout
+		}
+		if profile.DisplayName == "" {
+			profile.DisplayName = profile.Name
+		}
+		profiles[i] = profile
+	}
+	return profiles
+}
+
+// Profile returns the profile with the given name
+func (c *Config) Profile(name string) (ProfileConfig, bool) {
+	for _, profile := range c.ServerProfiles() {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return ProfileConfig{}, false
+}
+
+// Active returns the active profile, falling back to the first one
+func (c *Config) Active() ProfileConfig {
+	if profile, ok := c.Profile(c.ActiveProfile); ok {
+		return profile
+	}
+	return c.ServerProfiles()[0]
 }
 
-func LoadConfig() (*Config, error) {
-	data, err := os.ReadFile("configs/config.yaml")
+// ProfileDir returns the directory holding the sessions, cache and outbox of a profile
+func ProfileDir(name string) (string, error) {
+	dir, err := UserDir()
 	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profiles", name), nil
+}
+
+// Options controls where Load looks for configuration
+type Options struct {
+	// Defaults is the YAML embedded in the binary; it is always applied first
+	Defaults []byte
+	// Path is an explicit config file, usually from the --config flag. It
+	// falls back to the DORM_CONFIG environment variable when empty.
+	Path string
+}
+
+// Load resolves the configuration in layers, each overriding the previous one:
+// embedded defaults, the user config file, an explicit config file, and
+// finally DORM_* environment variables. The result is validated.
+func Load(opts Options) (*Config, error) {
+	cfg := &Config{}
+
+	if err := apply(cfg, opts.Defaults, "embedded defaults"); err != nil {
 		return nil, err
 	}
+
+	if path, err := UserConfigPath(); err == nil {
+		if err := applyFile(cfg, path, false); err != nil {
+			return nil, err
+		}
+	}
+
+	path := opts.Path
+	if path == "" {
+		path = os.Getenv(EnvConfigPath)
+	}
+	if path != "" {
+		if err := applyFile(cfg, path, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg, EnvPrefix, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Save writes the fields that differ between previous and next into the user
+// config file. The rest of the file is kept as written, so values that came
+// from the embedded defaults, an explicit config file, DORM_* variables or
+// ${VAR} expansion are not copied into it.
+func Save(previous, next *Config) error {
+	path, err := UserConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	layer := map[interface{}]interface{}{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &layer); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	before, err := toTree(previous)
+	if err != nil {
+		return err
+	}
+	after, err := toTree(next)
+	if err != nil {
+		return err
+	}
+	mergeChanges(layer, before, after)
+
+	data, err = yaml.Marshal(layer)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// toTree converts cfg to the generic form yaml.v2 decodes documents into
+func toTree(cfg *Config) (map[interface{}]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	tree := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return tree, nil
+}
+
+// mergeChanges copies into layer every value that differs between before and
+// after. Lists are compared and replaced as a whole.
+func mergeChanges(layer, before, after map[interface{}]interface{}) {
+	for key, value := range after {
+		if nested, ok := value.(map[interface{}]interface{}); ok {
+			previous, _ := before[key].(map[interface{}]interface{})
+			target, ok := layer[key].(map[interface{}]interface{})
+			if !ok {
+				target = map[interface{}]interface{}{}
+			}
+			mergeChanges(target, previous, nested)
+			if len(target) > 0 {
+				layer[key] = target
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(before[key], value) {
+			layer[key] = value
+		}
+	}
+}
+
+// UserDir returns the per-user directory the application keeps its data in
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// UserConfigPath returns the config file in the user directory
+func UserConfigPath() (string, error) {
+	dir, err := UserDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+func applyFile(cfg *Config, path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	return apply(cfg, data, path)
+}
+
+// apply overlays the YAML document onto cfg; keys it does not set keep their value
+func apply(cfg *Config, data []byte, source string) error {
 	replaced := os.ExpandEnv(string(data))
-	cfg := &Config{}
-	err = yaml.Unmarshal([]byte(replaced), cfg)
-	return cfg, err
+	if err := yaml.Unmarshal([]byte(replaced), cfg); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", source, err)
+	}
+	return nil
 }
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// userDir points UserDir at a temporary directory and returns the user config path
+func userDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv(EnvConfigPath, "")
+
+	path, err := UserConfigPath()
+	if err != nil {
+		t.Fatalf("UserConfigPath() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func defaults(t *testing.T) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("../../configs/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		explicit string
+		env      map[string]string
+		// want is checked against the loaded base URL, timeout, log level and grace days
+		want    [4]any
+		wantErr bool
+	}{
+		{
+			name: "embedded defaults",
+			want: [4]any{"http://localhost:8080/api/v1", 5, "info", 5},
+		},
+		{
+			name: "user file over defaults",
+			user: "client:\n  timeout: 20\nlogging:\n  level: debug\n",
+			want: [4]any{"http://localhost:8080/api/v1", 20, "debug", 5},
+		},
+		{
+			name:     "explicit file over the user file",
+			user:     "client:\n  timeout: 20\nlogging:\n  level: debug\n",
+			explicit: "logging:\n  level: warn\n",
+			want:     [4]any{"http://localhost:8080/api/v1", 20, "warn", 5},
+		},
+		{
+			name:     "environment over every file",
+			user:     "dunning:\n  grace_days: 3\n",
+			explicit: "client:\n  base_url: https://staging.example.com/api/v1\n",
+			env:      map[string]string{"DORM_CLIENT_BASE_URL": "https://prod.example.com/api/v1", "DORM_DUNNING_GRACE_DAYS": "10"},
+			want:     [4]any{"https://prod.example.com/api/v1", 5, "info", 10},
+		},
+		{
+			name: "variables are expanded",
+			user: "client:\n  base_url: ${TEST_API_URL}\n",
+			env:  map[string]string{"TEST_API_URL": "https://dorm.example.com/api"},
+			want: [4]any{"https://dorm.example.com/api", 5, "info", 5},
+		},
+		{
+			name:    "invalid values are rejected",
+			user:    "client:\n  timeout: 0\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed environment value",
+			env:     map[string]string{"DORM_CLIENT_TIMEOUT": "soon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := userDir(t)
+			if tt.user != "" {
+				if err := os.WriteFile(path, []byte(tt.user), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			opts := Options{Defaults: defaults(t)}
+			if tt.explicit != "" {
+				opts.Path = filepath.Join(t.TempDir(), "explicit.yaml")
+				if err := os.WriteFile(opts.Path, []byte(tt.explicit), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load(opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Load() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			got := [4]any{cfg.Client.BaseURL, cfg.Client.Timeout, cfg.Logger.Level, cfg.Dunning.GraceDays}
+			if got != tt.want {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing explicit file", func(t *testing.T) {
+		userDir(t)
+		_, err := Load(Options{Defaults: defaults(t), Path: filepath.Join(t.TempDir(), "missing.yaml")})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Load() error = %v, want a missing file error", err)
+		}
+	})
+}
+
+func TestSave(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   string
+		change func(cfg *Config)
+		want   string
+	}{
+		{
+			name:   "only the edited field is written",
+			change: func(cfg *Config) { cfg.Dunning.GraceDays = 7 },
+			want:   "dunning:\n  grace_days: 7\n",
+		},
+		{
+			name:   "the rest of the user file is kept as written",
+			user:   "client:\n  base_url: ${TEST_API_URL}\nlogging:\n  level: debug\n",
+			change: func(cfg *Config) { cfg.Logger.Format = "json" },
+			want:   "client:\n  base_url: ${TEST_API_URL}\nlogging:\n  format: json\n  level: debug\n",
+		},
+		{
+			name: "lists are replaced whole",
+			change: func(cfg *Config) {
+				cfg.Contracts.Expiry.Windows = []int{60, 30}
+			},
+			want: "contracts:\n  expiry:\n    windows:\n    - 60\n    - 30\n",
+		},
+		{
+			name:   "nothing edited",
+			user:   "logging:\n  level: debug\n",
+			change: func(cfg *Config) {},
+			want:   "logging:\n  level: debug\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := userDir(t)
+			if tt.user != "" {
+				if err := os.WriteFile(path, []byte(tt.user), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("TEST_API_URL", "https://dorm.example.com/api")
+			t.Setenv("DORM_CLIENT_TIMEOUT", "30")
+
+			previous, err := Load(Options{Defaults: defaults(t)})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			next := *previous
+			next.Contracts.Expiry.Windows = append([]int(nil), previous.Contracts.Expiry.Windows...)
+			tt.change(&next)
+
+			if err := Save(previous, &next); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, want map[string]any
+			if err := yaml.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Save() wrote\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// applyEnv overrides scalar fields from environment variables named after
+// their YAML path, e.g. client.base_url is read from DORM_CLIENT_BASE_URL
+func applyEnv(cfg *Config, prefix string, lookup func(string) (string, bool)) error {
+	return applyEnvValue(reflect.ValueOf(cfg).Elem(), prefix, lookup)
+}
+
+func applyEnvValue(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + strings.ToUpper(name)
+		value := v.Field(i)
+
+		if value.Kind() == reflect.Struct {
+			if err := applyEnvValue(value, key+"_", lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Lists such as profiles cannot be addressed by a single variable
+		if value.Kind() == reflect.Slice {
+			continue
+		}
+
+		raw, ok := lookup(key)
+		if !ok {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString(raw)
+		case reflect.Int:
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				return fmt.Errorf("%s: expected an integer, got %q", key, raw)
+			}
+			value.SetInt(int64(n))
+		case reflect.Float64:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("%s: expected a number, got %q", key, raw)
+			}
+			value.SetFloat(f)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%s: expected a boolean, got %q", key, raw)
+			}
+			value.SetBool(b)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// FieldError describes one invalid setting by its YAML path
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every invalid setting found in a config
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		parts[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return "invalid configuration: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+var (
+	profileName   = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	bankBIN       = regexp.MustCompile(`^[0-9]{6}$`)
+	accountNumber = regexp.MustCompile(`^[0-9A-Za-z]{1,19}$`)
+)
+
+var (
+	feeTypes     = []string{"none", "flat", "percentage"}
+	flagStatuses = []string{"pending", "rejected", "banned"}
+	logLevels    = []string{"debug", "info", "warn", "error"}
+	logFormats   = []string{"text", "json"}
+	logOutputs   = []string{"stdout", "stderr", "file"}
+)
+
+// Validate checks every field and reports all problems at once
+func (c *Config) Validate() error {
+	errs := &ValidationError{}
+
+	if len(c.Profiles) == 0 {
+		validateBaseURL(errs, "client.base_url", c.Client.BaseURL)
+	}
+	if c.Client.Timeout <= 0 {
+		errs.add("client.timeout", "must be a positive number of seconds, got %d", c.Client.Timeout)
+	}
+
+	seen := make(map[string]bool)
+	for i, profile := range c.Profiles {
+		field := fmt.Sprintf("profiles[%d]", i)
+		switch {
+		case !profileName.MatchString(profile.Name):
+			errs.add(field+".name", "must be lowercase letters, digits, '-' or '_', got %q", profile.Name)
+		case seen[profile.Name]:
+			errs.add(field+".name", "duplicate profile %q", profile.Name)
+		}
+		seen[profile.Name] = true
+
+		validateBaseURL(errs, field+".base_url", profile.BaseURL)
+		if profile.Timeout < 0 {
+			errs.add(field+".timeout", "must not be negative")
+		}
+		if profile.TLS.CAFile != "" {
+			if _, err := os.Stat(profile.TLS.CAFile); err != nil {
+				errs.add(field+".tls.ca_file", "cannot be read: %v", err)
+			}
+		}
+	}
+	if c.ActiveProfile != "" {
+		if _, ok := c.Profile(c.ActiveProfile); !ok {
+			errs.add("active_profile", "no profile named %q", c.ActiveProfile)
+		}
+	}
+
+	validateOneOf(errs, "logging.level", c.Logger.Level, logLevels)
+	validateOneOf(errs, "logging.format", c.Logger.Format, logFormats)
+	validateOneOf(errs, "logging.output", c.Logger.Output, logOutputs)
+	if c.Logger.MaxSize < 0 {
+		errs.add("logging.max_size", "must not be negative")
+	}
+	if c.Logger.MaxBackups < 0 {
+		errs.add("logging.max_backups", "must not be negative")
+	}
+	if c.Logger.MaxAge < 0 {
+		errs.add("logging.max_age", "must not be negative")
+	}
+
+	validateTariff(errs, "metering.electricity", c.Metering.Electricity)
+	validateTariff(errs, "metering.water", c.Metering.Water)
+
+	validateDunning(errs, c.Dunning)
+	validateBank(errs, c.Bank)
+
+	if c.Contracts.NoticeDays < 0 {
+		errs.add("contracts.notice_days", "must not be negative")
+	}
+	if c.Contracts.PenaltyMonths < 0 {
+		errs.add("contracts.penalty_months", "must not be negative")
+	}
+	validateExpiry(errs, c.Contracts.Expiry)
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateBaseURL(errs *ValidationError, field, value string) {
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.add(field, "must be an absolute http(s) URL, got %q", value)
+	}
+}
+
+func validateOneOf(errs *ValidationError, field, value string, allowed []string) {
+	for _, candidate := range allowed {
+		if strings.EqualFold(value, candidate) {
+			return
+		}
+	}
+	errs.add(field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func validateTariff(errs *ValidationError, field string, tariff TariffConfig) {
+	if tariff.MeterMax < 0 {
+		errs.add(field+".meter_max", "must not be negative")
+	}
+
+	last := 0.0
+	for i, tier := range tariff.Tiers {
+		tierField := fmt.Sprintf("%s.tiers[%d]", field, i)
+		if tier.Price < 0 {
+			errs.add(tierField+".price", "must not be negative")
+		}
+		switch {
+		case tier.UpTo == 0 && i != len(tariff.Tiers)-1:
+			errs.add(tierField+".up_to", "only the last tier may be unbounded")
+		case tier.UpTo != 0 && tier.UpTo <= last:
+			errs.add(tierField+".up_to", "must be greater than the previous tier, got %g", tier.UpTo)
+		}
+		last = tier.UpTo
+	}
+}
+
+func validateDunning(errs *ValidationError, dunning DunningConfig) {
+	if dunning.Enabled && dunning.Interval <= 0 {
+		errs.add("dunning.interval", "must be a positive number of minutes, got %d", dunning.Interval)
+	}
+	if dunning.GraceDays < 0 {
+		errs.add("dunning.grace_days", "must not be negative")
+	}
+
+	validateOneOf(errs, "dunning.fee_type", dunning.FeeType, feeTypes)
+	if dunning.FeeAmount < 0 {
+		errs.add("dunning.fee_amount", "must not be negative")
+	}
+	if strings.EqualFold(dunning.FeeType, "percentage") && dunning.FeeAmount > 100 {
+		errs.add("dunning.fee_amount", "must be at most 100 percent, got %g", dunning.FeeAmount)
+	}
+
+	if dunning.ReminderDays < 0 {
+		errs.add("dunning.reminder_days", "must not be negative")
+	}
+	if dunning.WarningDays < dunning.ReminderDays {
+		errs.add("dunning.warning_days", "must not be less than reminder_days")
+	}
+	if dunning.FinalNoticeDays < dunning.WarningDays {
+		errs.add("dunning.final_notice_days", "must not be less than warning_days")
+	}
+
+	if dunning.FlagAccount {
+		validateOneOf(errs, "dunning.flag_status", dunning.FlagStatus, flagStatuses)
+	}
+}
+
+func validateExpiry(errs *ValidationError, expiry ExpiryConfig) {
+	if expiry.Enabled && expiry.Interval <= 0 {
+		errs.add("contracts.expiry.interval", "must be a positive number of minutes, got %d", expiry.Interval)
+	}
+
+	seen := make(map[int]bool, len(expiry.Windows))
+	for i, days := range expiry.Windows {
+		field := fmt.Sprintf("contracts.expiry.windows[%d]", i)
+		if days <= 0 {
+			errs.add(field, "must be a positive number of days, got %d", days)
+		}
+		if seen[days] {
+			errs.add(field, "%d days is listed twice", days)
+		}
+		seen[days] = true
+	}
+}
+
+func validateBank(errs *ValidationError, bank BankConfig) {
+	if bank.BIN == "" && bank.AccountNumber == "" {
+		return
+	}
+	if !bankBIN.MatchString(bank.BIN) {
+		errs.add("bank.bin", "must be a 6-digit bank BIN, got %q", bank.BIN)
+	}
+	if !accountNumber.MatchString(bank.AccountNumber) {
+		errs.add("bank.account_number", "must be up to 19 letters or digits, got %q", bank.AccountNumber)
+	}
+}
@@ -0,0 +1,312 @@
+package deposit
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotSettled blocks closing a contract whose deposit is still held
+	ErrNotSettled = errors.New("the contract's deposit has not been settled")
+	// ErrNoDeposit is returned when changing a deposit that was never received
+	ErrNoDeposit = errors.New("no deposit has been recorded for the contract")
+	// ErrAlreadySettled is returned when changing a settled deposit
+	ErrAlreadySettled = errors.New("the deposit has already been settled")
+)
+
+// Line is one entry of a settlement statement
+type Line struct {
+	Date                 time.Time `json:"date"`
+	Description          string    `json:"description"`
+	Amount               float64   `json:"amount"`
+	MaintenanceHistoryID *int      `json:"maintenance_history_id"`
+}
+
+// Statement is the settlement view of a contract's deposit. Balance is what
+// is still held for the student; a negative balance is owed by the student.
+type Statement struct {
+	ContractID      int                  `json:"contract_id"`
+	ContractCode    string               `json:"contract_code"`
+	StudentName     string               `json:"student_name"`
+	StudentCode     string               `json:"student_code"`
+	RoomNumber      string               `json:"room_number"`
+	Status          models.DepositStatus `json:"status"`
+	Received        []Line               `json:"received"`
+	Deductions      []Line               `json:"deductions"`
+	Refunds         []Line               `json:"refunds"`
+	TotalReceived   float64              `json:"total_received"`
+	TotalDeductions float64              `json:"total_deductions"`
+	TotalRefunded   float64              `json:"total_refunded"`
+	Balance         float64              `json:"balance"`
+	SettledAt       *time.Time           `json:"settled_at"`
+}
+
+// Build summarises a deposit ledger for its contract
+func Build(contract models.Contract, deposit models.Deposit) *Statement {
+	statement := &Statement{
+		ContractID:   contract.ID,
+		ContractCode: contract.Code,
+		StudentName:  contract.User.FullName,
+		StudentCode:  contract.User.StudentCode,
+		RoomNumber:   contract.Room.RoomNumber,
+		Status:       deposit.Status,
+		Received:     []Line{},
+		Deductions:   []Line{},
+		Refunds:      []Line{},
+		SettledAt:    deposit.SettledAt,
+	}
+
+	for _, entry := range deposit.Entries {
+		line := Line{
+			Date:                 entry.Date,
+			Description:          entry.Description,
+			Amount:               entry.Amount,
+			MaintenanceHistoryID: entry.MaintenanceHistoryID,
+		}
+		switch entry.Type {
+		case models.DepositEntryReceived:
+			statement.Received = append(statement.Received, line)
+			statement.TotalReceived += entry.Amount
+		case models.DepositEntryDeduction:
+			statement.Deductions = append(statement.Deductions, line)
+			statement.TotalDeductions += entry.Amount
+		case models.DepositEntryRefund:
+			statement.Refunds = append(statement.Refunds, line)
+			statement.TotalRefunded += entry.Amount
+		}
+	}
+	statement.Balance = statement.TotalReceived - statement.TotalDeductions - statement.TotalRefunded
+
+	return statement
+}
+
+// Ledger records deposit movements and settles them at move-out
+type Ledger struct {
+	api *api.API
+}
+
+func NewLedger(api *api.API) *Ledger {
+	return &Ledger{
+		api: api,
+	}
+}
+
+// Statement returns the current settlement statement of a contract
+func (l *Ledger) Statement(ctx context.Context, contractID int) (*Statement, error) {
+	_, statement, err := l.load(ctx, contractID)
+	return statement, err
+}
+
+// load reads the contract and its deposit from the server. Refunds and
+// settlement are decided from them, so a copy from the offline cache is refused.
+func (l *Ledger) load(ctx context.Context, contractID int) (*models.Contract, *Statement, error) {
+	contract, err := api.Live(l.api.Contract().GetContractDetails(ctx, contractID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deposit, err := api.Live(l.api.Deposit().GetContractDeposit(ctx, contractID))
+	if api.IsNotFound(err) {
+		return &contract.Data, Build(contract.Data, models.Deposit{ContractID: contractID}), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &contract.Data, Build(contract.Data, deposit.Data), nil
+}
+
+// Receive records money paid into the deposit
+func (l *Ledger) Receive(ctx context.Context, contractID int, amount float64, description string) (*Statement, error) {
+	if amount <= 0 {
+		return nil, errors.New("deposit amount must be positive")
+	}
+
+	statement, err := l.Statement(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Status == models.DepositStatusSettled {
+		return nil, ErrAlreadySettled
+	}
+
+	return l.add(ctx, contractID, map[string]interface{}{
+		"type":        models.DepositEntryReceived,
+		"amount":      amount,
+		"date":        time.Now().Format(time.DateOnly),
+		"description": description,
+	})
+}
+
+// Deduct charges the cost of a repair to the deposit. The maintenance
+// history entry must belong to the contract's room; a zero amount takes its cost.
+func (l *Ledger) Deduct(ctx context.Context, contractID int, historyID int, amount float64, description string) (*Statement, error) {
+	contract, statement, err := l.held(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := l.api.MaintenanceHistory().GetMaintenanceHistoryDetails(ctx, strconv.Itoa(historyID))
+	if err != nil {
+		return nil, err
+	}
+	if history.Data.RoomID != contract.RoomID {
+		return nil, fmt.Errorf("maintenance history %d is for another room than contract %s", historyID, statement.ContractCode)
+	}
+	for _, line := range statement.Deductions {
+		if line.MaintenanceHistoryID != nil && *line.MaintenanceHistoryID == historyID {
+			return nil, fmt.Errorf("maintenance history %d has already been deducted", historyID)
+		}
+	}
+
+	if amount == 0 {
+		amount = history.Data.Cost
+	}
+	if amount <= 0 {
+		return nil, errors.New("deduction amount must be positive")
+	}
+	if description == "" {
+		description = history.Data.Description
+	}
+
+	return l.add(ctx, contractID, map[string]interface{}{
+		"type":                   models.DepositEntryDeduction,
+		"amount":                 amount,
+		"date":                   time.Now().Format(time.DateOnly),
+		"description":            description,
+		"maintenance_history_id": historyID,
+	})
+}
+
+// Refund pays part or all of the remaining balance back to the student
+func (l *Ledger) Refund(ctx context.Context, contractID int, amount float64, description string) (*Statement, error) {
+	_, statement, err := l.held(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, errors.New("refund amount must be positive")
+	}
+	if amount > statement.Balance {
+		return nil, fmt.Errorf("refund of %.0f exceeds the remaining balance of %.0f", amount, statement.Balance)
+	}
+
+	return l.add(ctx, contractID, map[string]interface{}{
+		"type":        models.DepositEntryRefund,
+		"amount":      amount,
+		"date":        time.Now().Format(time.DateOnly),
+		"description": description,
+	})
+}
+
+// Settle closes the deposit. Any positive balance must be refunded first; a
+// negative balance is invoiced to the student as damages before settling.
+func (l *Ledger) Settle(ctx context.Context, contractID int) (*Statement, error) {
+	contract, statement, err := l.held(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Balance > 0 {
+		return nil, fmt.Errorf("refund the remaining balance of %.0f before settling", statement.Balance)
+	}
+
+	if statement.Balance < 0 {
+		if err := l.invoiceShortfall(ctx, contract, statement); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := l.api.Deposit().SettleDeposit(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	settled := *statement
+	settled.Status = resp.Data.Status
+	settled.SettledAt = resp.Data.SettledAt
+	return &settled, nil
+}
+
+// invoiceShortfall bills the student for what the deposit did not cover. A
+// settlement that failed after invoicing is run again, so an invoice it
+// already created is found by its code and kept.
+func (l *Ledger) invoiceShortfall(ctx context.Context, contract *models.Contract, statement *Statement) error {
+	code := "DEP-" + statement.ContractCode
+	invoices, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return l.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{
+			Page:    page,
+			Keyword: code,
+			Type:    string(models.InvoiceTypeOther),
+			UserID:  contract.UserID,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look up the shortfall invoice: %w", err)
+	}
+	for _, invoice := range invoices {
+		if invoice.Code == code && invoice.Status != models.InvoiceStatusVoid {
+			return nil
+		}
+	}
+
+	now := time.Now()
+	_, err = l.api.Invoice().CreateInvoice(ctx, map[string]interface{}{
+		"code":        code,
+		"user_id":     contract.UserID,
+		"room_id":     contract.RoomID,
+		"contract_id": contract.ID,
+		"type":        models.InvoiceTypeOther,
+		"description": "Bồi thường vượt tiền đặt cọc hợp đồng " + statement.ContractCode,
+		"amount":      -statement.Balance,
+		"issue_date":  now.Format(time.DateOnly),
+		"due_date":    now.AddDate(0, 0, billing.DefaultDueDays).Format(time.DateOnly),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invoice the shortfall: %w", err)
+	}
+	return nil
+}
+
+// EnsureSettled returns ErrNotSettled while a contract's deposit is still
+// held. Contracts without a recorded deposit have nothing to settle.
+func (l *Ledger) EnsureSettled(ctx context.Context, contractID int) error {
+	deposit, err := api.Live(l.api.Deposit().GetContractDeposit(ctx, contractID))
+	if api.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deposit.Data.Status != models.DepositStatusSettled && len(deposit.Data.Entries) > 0 {
+		return ErrNotSettled
+	}
+	return nil
+}
+
+// held loads a deposit that can still change
+func (l *Ledger) held(ctx context.Context, contractID int) (*models.Contract, *Statement, error) {
+	contract, statement, err := l.load(ctx, contractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if statement.Status == models.DepositStatusSettled {
+		return nil, nil, ErrAlreadySettled
+	}
+	if len(statement.Received) == 0 {
+		return nil, nil, ErrNoDeposit
+	}
+	return contract, statement, nil
+}
+
+func (l *Ledger) add(ctx context.Context, contractID int, entryData map[string]interface{}) (*Statement, error) {
+	if _, err := l.api.Deposit().AddDepositEntry(ctx, contractID, entryData); err != nil {
+		return nil, err
+	}
+	return l.Statement(ctx, contractID)
+}
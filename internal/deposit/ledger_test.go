@@ -0,0 +1,188 @@
+package deposit
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCache is an in-memory client.Cache
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*client.CacheEntry
+}
+
+func (m *memoryCache) Get(key string) (*client.CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+func (m *memoryCache) Put(key string, entry *client.CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+	return nil
+}
+
+func entry(kind models.DepositEntryType, amount float64) models.DepositEntry {
+	return models.DepositEntry{ID: 1, Type: kind, Amount: amount}
+}
+
+// serve answers the contract and deposit endpoints of contract 9. A nil
+// deposit is a 404. The paths of the writes are appended to posted.
+func serve(t *testing.T, deposit *models.Deposit, posted *[]string) (*httptest.Server, *api.API) {
+	t.Helper()
+
+	respond := func(w http.ResponseWriter, data any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /contracts/9", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, models.Contract{ID: 9, Code: "C009", UserID: 5, RoomID: 3, Price: 1, Status: models.ContractStatusActive})
+	})
+	mux.HandleFunc("GET /contracts/9/deposit", func(w http.ResponseWriter, r *http.Request) {
+		if deposit == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		respond(w, deposit)
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		*posted = append(*posted, r.URL.Path)
+		respond(w, models.Invoice{ID: 1, Code: "DEP-C009", UserID: 5, Type: models.InvoiceTypeOther, Status: models.InvoiceStatusPending})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, api.NewAPI(client.New().SetBaseURL(server.URL))
+}
+
+func TestBuild(t *testing.T) {
+	statement := Build(models.Contract{ID: 9, Code: "C009"}, models.Deposit{
+		Status: models.DepositStatusHeld,
+		Entries: []models.DepositEntry{
+			entry(models.DepositEntryReceived, 3_000_000),
+			entry(models.DepositEntryDeduction, 500_000),
+			entry(models.DepositEntryDeduction, 200_000),
+			entry(models.DepositEntryRefund, 1_000_000),
+		},
+	})
+
+	if statement.TotalReceived != 3_000_000 || statement.TotalDeductions != 700_000 || statement.TotalRefunded != 1_000_000 || statement.Balance != 1_300_000 {
+		t.Errorf("Build() = received %v, deducted %v, refunded %v, balance %v; want 3000000, 700000, 1000000, 1300000",
+			statement.TotalReceived, statement.TotalDeductions, statement.TotalRefunded, statement.Balance)
+	}
+	if len(statement.Received) != 1 || len(statement.Deductions) != 2 || len(statement.Refunds) != 1 {
+		t.Errorf("Build() lines = %d received, %d deductions, %d refunds; want 1, 2, 1", len(statement.Received), len(statement.Deductions), len(statement.Refunds))
+	}
+}
+
+func TestRefund(t *testing.T) {
+	held := &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusHeld, Entries: []models.DepositEntry{
+		entry(models.DepositEntryReceived, 3_000_000),
+		entry(models.DepositEntryDeduction, 500_000),
+	}}
+
+	tests := []struct {
+		name    string
+		deposit *models.Deposit
+		amount  float64
+		wantErr bool
+		// wantIs is the sentinel the error must match, when there is one
+		wantIs error
+	}{
+		{"part of the balance", held, 1_000_000, false, nil},
+		{"the whole balance", held, 2_500_000, false, nil},
+		{"more than the balance", held, 2_500_001, true, nil},
+		{"nothing", held, 0, true, nil},
+		{"never received", nil, 1, true, ErrNoDeposit},
+		{"settled", &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusSettled, Entries: held.Entries}, 1, true, ErrAlreadySettled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted []string
+			_, a := serve(t, tt.deposit, &posted)
+
+			_, err := NewLedger(a).Refund(context.Background(), 9, tt.amount, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Refund() error = %v, want an error: %v", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("Refund() error = %v, want %v", err, tt.wantIs)
+			}
+			if wrote := len(posted) > 0; wrote == tt.wantErr {
+				t.Errorf("Refund() wrote %v, want a write: %v", posted, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnsureSettled(t *testing.T) {
+	tests := []struct {
+		name    string
+		deposit *models.Deposit
+		want    error
+	}{
+		{"no deposit", nil, nil},
+		{"held", &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusHeld, Entries: []models.DepositEntry{entry(models.DepositEntryReceived, 1)}}, ErrNotSettled},
+		{"held without entries", &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusHeld}, nil},
+		{"settled", &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusSettled, Entries: []models.DepositEntry{entry(models.DepositEntryReceived, 1)}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted []string
+			_, a := serve(t, tt.deposit, &posted)
+
+			if err := NewLedger(a).EnsureSettled(context.Background(), 9); !errors.Is(err, tt.want) {
+				t.Errorf("EnsureSettled() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLedgerRefusesOfflineCopies(t *testing.T) {
+	deposit := &models.Deposit{ID: 1, ContractID: 9, Status: models.DepositStatusHeld, Entries: []models.DepositEntry{
+		entry(models.DepositEntryReceived, 3_000_000),
+	}}
+	var posted []string
+	server, _ := serve(t, deposit, &posted)
+
+	c := client.New().SetBaseURL(server.URL).
+		SetCache(&memoryCache{entries: map[string]*client.CacheEntry{}}).
+		SetCacheScope("1").
+		SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1})
+	ledger := NewLedger(api.NewAPI(c))
+
+	// Fill the cache, then take the server away
+	ctx := client.AllowStale(context.Background())
+	if _, err := ledger.Statement(ctx, 9); err != nil {
+		t.Fatalf("Statement() error = %v", err)
+	}
+	server.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, err := ledger.Refund(ctx, 9, 3_000_000, ""); !errors.Is(err, api.ErrStale) {
+		t.Errorf("Refund() error = %v, want api.ErrStale", err)
+	}
+	if err := ledger.EnsureSettled(ctx, 9); !errors.Is(err, api.ErrStale) {
+		t.Errorf("EnsureSettled() error = %v, want api.ErrStale", err)
+	}
+	if len(posted) > 0 {
+		t.Errorf("wrote %v from an offline copy", posted)
+	}
+}
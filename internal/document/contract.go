@@ -0,0 +1,51 @@
+package document
+
+import (
+	"changeme/internal/models"
+	"fmt"
+)
+
+var contractStatuses = map[models.ContractStatus]string{
+	models.ContractStatusActive:    "Đang hiệu lực",
+	models.ContractStatusInactive:  "Hết hiệu lực",
+	models.ContractStatusCancelled: "Đã hủy",
+}
+
+// Contract renders a room contract as returned by GetContractDetails
+func Contract(contract models.Contract) ([]byte, error) {
+	p := newPage("Hợp đồng " + contract.Code)
+	if err := p.qr("contract-qr", contract.Code); err != nil {
+		return nil, err
+	}
+
+	p.title("HỢP ĐỒNG THUÊ PHÒNG KÝ TÚC XÁ")
+	p.subtitle("Số: " + contract.Code)
+
+	p.section("Bên thuê")
+	p.field("Họ và tên:", contract.User.FullName)
+	p.field("Mã sinh viên:", contract.User.StudentCode)
+	p.field("Email:", contract.User.Email)
+	p.field("Số điện thoại:", contract.User.Phone)
+
+	category := contract.Room.RoomCategory
+	p.section("Phòng thuê")
+	p.field("Số phòng:", contract.Room.RoomNumber)
+	p.field("Loại phòng:", category.Name)
+	p.field("Diện tích:", fmt.Sprintf("%g m²", category.Acreage))
+	p.field("Sức chứa:", fmt.Sprintf("%d người", category.Capacity))
+	p.field("Giá thuê:", formatMoney(contract.Price)+" / tháng")
+
+	p.section("Thời hạn")
+	p.field("Từ ngày:", formatDate(contract.StartDate))
+	p.field("Đến ngày:", formatDate(contract.EndDate))
+	p.field("Trạng thái:", contractStatuses[contract.Status])
+
+	if contract.Description != "" {
+		p.section("Điều khoản")
+		p.paragraph(contract.Description)
+	}
+
+	p.signatures("ĐẠI DIỆN KÝ TÚC XÁ", "BÊN THUÊ")
+
+	return p.bytes()
+}
@@ -0,0 +1,150 @@
+// Package document renders contracts, invoices and receipts as PDF.
+package document
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// DejaVu Sans is embedded because the standard PDF fonts have no glyphs for
+// Vietnamese diacritics. The files come from the DejaVu project under its
+// free font license.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+const (
+	fontFamily = "DejaVu"
+	margin     = 20.0
+	qrSize     = 32.0
+	lineHeight = 7.0
+	labelWidth = 50.0
+)
+
+// page wraps a gofpdf document with the helpers every template uses
+type page struct {
+	pdf *gofpdf.Fpdf
+}
+
+// newPage starts an A4 document with page numbers in the footer
+func newPage(title string) *page {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Dormitory Management", true)
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin + 5)
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Trang %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	return &page{pdf: pdf}
+}
+
+func (p *page) title(text string) {
+	p.pdf.SetFont(fontFamily, "B", 16)
+	p.pdf.CellFormat(0, 10, text, "", 1, "C", false, 0, "")
+	p.pdf.Ln(2)
+}
+
+func (p *page) subtitle(text string) {
+	p.pdf.SetFont(fontFamily, "", 11)
+	p.pdf.CellFormat(0, 6, text, "", 1, "C", false, 0, "")
+	p.pdf.Ln(4)
+}
+
+func (p *page) section(text string) {
+	p.pdf.Ln(3)
+	p.pdf.SetFont(fontFamily, "B", 12)
+	p.pdf.CellFormat(0, 8, text, "B", 1, "L", false, 0, "")
+	p.pdf.Ln(1)
+}
+
+// field writes a label and its value; long values wrap under the value column
+func (p *page) field(label, value string) {
+	p.pdf.SetFont(fontFamily, "", 11)
+	p.pdf.CellFormat(labelWidth, lineHeight, label, "", 0, "L", false, 0, "")
+	p.pdf.SetFont(fontFamily, "B", 11)
+	p.pdf.MultiCell(0, lineHeight, value, "", "L", false)
+}
+
+func (p *page) paragraph(text string) {
+	p.pdf.SetFont(fontFamily, "", 11)
+	p.pdf.MultiCell(0, lineHeight-1, text, "", "J", false)
+}
+
+// qr draws a QR code of content in the top right corner of the current page
+func (p *page) qr(name, content string) error {
+	png, err := qrcode.Encode(content, qrcode.Medium, 256)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	p.pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+	width, _ := p.pdf.GetPageSize()
+	p.pdf.ImageOptions(name, width-margin-qrSize, margin, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	return nil
+}
+
+// signatures writes the two signature blocks at the end of a document
+func (p *page) signatures(left, right string) {
+	p.pdf.Ln(10)
+	width, _ := p.pdf.GetPageSize()
+	half := (width - 2*margin) / 2
+
+	p.pdf.SetFont(fontFamily, "B", 11)
+	p.pdf.CellFormat(half, lineHeight, left, "", 0, "C", false, 0, "")
+	p.pdf.CellFormat(half, lineHeight, right, "", 1, "C", false, 0, "")
+	p.pdf.SetFont(fontFamily, "", 9)
+	p.pdf.CellFormat(half, 5, "(Ký, ghi rõ họ tên)", "", 0, "C", false, 0, "")
+	p.pdf.CellFormat(half, 5, "(Ký, ghi rõ họ tên)", "", 1, "C", false, 0, "")
+}
+
+func (p *page) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatDate formats t the way Vietnamese documents write dates
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02/01/2006")
+}
+
+// formatMoney formats an amount in dong with dots between thousands
+func formatMoney(amount float64) string {
+	digits := strconv.FormatInt(int64(amount), 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String() + " đ"
+}
@@ -0,0 +1,53 @@
+package document
+
+import "changeme/internal/models"
+
+var invoiceTypes = map[models.InvoiceType]string{
+	models.InvoiceTypeRoomFee: "Phí thuê phòng",
+	models.InvoiceTypeService: "Phí dịch vụ",
+	models.InvoiceTypePenalty: "Tiền phạt",
+	models.InvoiceTypeOther:   "Khác",
+}
+
+var invoiceStatuses = map[models.InvoiceStatus]string{
+	models.InvoiceStatusPending:       "Chưa thanh toán",
+	models.InvoiceStatusPartiallyPaid: "Thanh toán một phần",
+	models.InvoiceStatusPaid:          "Đã thanh toán",
+	models.InvoiceStatusOverdue:       "Quá hạn",
+	models.InvoiceStatusVoid:          "Đã hủy",
+}
+
+// Invoice renders an invoice; the QR code carries the invoice number
+func Invoice(invoice models.Invoice) ([]byte, error) {
+	p := newPage("Hóa đơn " + invoice.Code)
+	if err := p.qr("invoice-qr", invoice.Code); err != nil {
+		return nil, err
+	}
+
+	p.title("HÓA ĐƠN")
+	p.subtitle("Số: " + invoice.Code)
+
+	p.section("Sinh viên")
+	p.field("Họ và tên:", invoice.User.FullName)
+	p.field("Mã sinh viên:", invoice.User.StudentCode)
+	if invoice.Room != nil {
+		p.field("Phòng:", invoice.Room.RoomNumber)
+	}
+
+	p.section("Chi tiết")
+	p.field("Loại:", invoiceTypes[invoice.Type])
+	p.field("Nội dung:", invoice.Description)
+	if invoice.BillingPeriod != "" {
+		p.field("Kỳ thanh toán:", invoice.BillingPeriod)
+	}
+	p.field("Ngày lập:", formatDate(invoice.IssueDate))
+	p.field("Hạn thanh toán:", formatDate(invoice.DueDate))
+
+	p.section("Thanh toán")
+	p.field("Số tiền:", formatMoney(invoice.Amount))
+	p.field("Đã thanh toán:", formatMoney(invoice.PaidAmount))
+	p.field("Còn lại:", formatMoney(invoice.Outstanding()))
+	p.field("Trạng thái:", invoiceStatuses[invoice.Status])
+
+	return p.bytes()
+}
@@ -0,0 +1,45 @@
+package document
+
+import (
+	"changeme/internal/models"
+	"fmt"
+)
+
+var paymentMethods = map[models.PaymentMethod]string{
+	models.PaymentMethodCash:         "Tiền mặt",
+	models.PaymentMethodBankTransfer: "Chuyển khoản",
+}
+
+// Receipt renders the receipt of a payment against invoice
+func Receipt(payment models.Payment, invoice models.Invoice) ([]byte, error) {
+	number := fmt.Sprintf("PT-%06d", payment.ID)
+	p := newPage("Biên lai " + number)
+	if err := p.qr("receipt-qr", number); err != nil {
+		return nil, err
+	}
+
+	p.title("BIÊN LAI THU TIỀN")
+	p.subtitle("Số: " + number)
+
+	p.section("Người nộp")
+	p.field("Họ và tên:", invoice.User.FullName)
+	p.field("Mã sinh viên:", invoice.User.StudentCode)
+
+	p.section("Khoản thu")
+	p.field("Hóa đơn:", invoice.Code)
+	p.field("Nội dung:", invoice.Description)
+	p.field("Ngày thu:", formatDate(payment.PaidAt))
+	p.field("Hình thức:", paymentMethods[payment.Method])
+	if payment.Reference != "" {
+		p.field("Mã giao dịch:", payment.Reference)
+	}
+	p.field("Số tiền:", formatMoney(payment.Amount))
+	p.field("Còn phải trả:", formatMoney(invoice.Outstanding()))
+	if payment.Note != "" {
+		p.field("Ghi chú:", payment.Note)
+	}
+
+	p.signatures("NGƯỜI THU TIỀN", "NGƯỜI NỘP TIỀN")
+
+	return p.bytes()
+}
@@ -0,0 +1,406 @@
+package dunning
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyReversed is returned when reversing an action twice
+var ErrAlreadyReversed = errors.New("action has already been reversed")
+
+// ErrStatusChanged is returned when reversing a status change the invoice
+// has since moved on from, e.g. because the student paid
+var ErrStatusChanged = errors.New("the invoice is no longer overdue")
+
+// levels are ordered from mildest to most severe
+var levels = []Level{LevelReminder, LevelWarning, LevelFinalNotice}
+
+// Report summarises one run
+type Report struct {
+	RanAt    time.Time `json:"ran_at"`
+	Checked  int       `json:"checked"`
+	Overdue  int       `json:"overdue"`
+	Actions  []Action  `json:"actions"`
+	Failures []string  `json:"failures"`
+}
+
+// Engine marks invoices overdue, charges late fees, escalates notices and
+// optionally flags accounts. Every change is journaled; a reversed action
+// counts as waived and is not applied to the same invoice again.
+type Engine struct {
+	api     *api.API
+	journal *Journal
+
+	// mu keeps a manual run from overlapping a scheduled one
+	mu sync.Mutex
+}
+
+func NewEngine(api *api.API, journal *Journal) *Engine {
+	return &Engine{
+		api:     api,
+		journal: journal,
+	}
+}
+
+// LevelFor returns the most severe notice due after daysOverdue days, or "" for none
+func LevelFor(cfg config.DunningConfig, daysOverdue int) Level {
+	switch {
+	case daysOverdue <= 0:
+		return ""
+	case daysOverdue >= cfg.FinalNoticeDays:
+		return LevelFinalNotice
+	case daysOverdue >= cfg.WarningDays:
+		return LevelWarning
+	case daysOverdue >= cfg.ReminderDays:
+		return LevelReminder
+	}
+	return ""
+}
+
+// LateFee returns the fee charged on an overdue outstanding amount
+func LateFee(cfg config.DunningConfig, outstanding float64) float64 {
+	switch strings.ToLower(cfg.FeeType) {
+	case "flat":
+		return cfg.FeeAmount
+	case "percentage":
+		return billing.Round(outstanding * cfg.FeeAmount / 100)
+	}
+	return 0
+}
+
+// DaysOverdue counts the days since the grace period after the due date
+// ended; zero or less means the invoice is not overdue yet
+func DaysOverdue(cfg config.DunningConfig, dueDate, now time.Time) int {
+	deadline := billing.Date(dueDate).AddDate(0, 0, cfg.GraceDays)
+	return int(billing.Date(now).Sub(deadline).Hours() / 24)
+}
+
+func severity(level Level) int {
+	for i, candidate := range levels {
+		if candidate == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// history is what the journal already holds for one run
+type history struct {
+	invoices map[int]map[Kind][]Action
+	flagged  map[int]bool
+}
+
+func (h *history) done(invoiceID int, kind Kind) bool {
+	return len(h.invoices[invoiceID][kind]) > 0
+}
+
+// level returns the most severe notice recorded for an invoice. Reversed
+// notices are included when deciding what to send, as they were waived.
+func (h *history) level(invoiceID int, withReversed bool) Level {
+	var level Level
+	for _, action := range h.invoices[invoiceID][KindNotice] {
+		if action.ReversedAt != nil && !withReversed {
+			continue
+		}
+		if severity(action.Level) > severity(level) {
+			level = action.Level
+		}
+	}
+	return level
+}
+
+func (e *Engine) history() (*history, error) {
+	actions, err := e.journal.List()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &history{
+		invoices: make(map[int]map[Kind][]Action),
+		flagged:  make(map[int]bool),
+	}
+	for _, action := range actions {
+		if h.invoices[action.InvoiceID] == nil {
+			h.invoices[action.InvoiceID] = make(map[Kind][]Action)
+		}
+		h.invoices[action.InvoiceID][action.Kind] = append(h.invoices[action.InvoiceID][action.Kind], action)
+		if action.Kind == KindFlagAccount && action.ReversedAt == nil {
+			h.flagged[action.UserID] = true
+		}
+	}
+	return h, nil
+}
+
+// Run evaluates every unpaid invoice once
+func (e *Engine) Run(ctx context.Context, cfg config.DunningConfig, now time.Time) (*Report, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var invoices []models.Invoice
+	for _, status := range []models.InvoiceStatus{models.InvoiceStatusPending, models.InvoiceStatusPartiallyPaid, models.InvoiceStatusOverdue} {
+		list, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+			return e.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{Page: page, Status: string(status)})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s invoices: %w", status, err)
+		}
+		invoices = append(invoices, list...)
+	}
+
+	h, err := e.history()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dunning journal: %w", err)
+	}
+
+	report := &Report{
+		RanAt:    now,
+		Checked:  len(invoices),
+		Actions:  []Action{},
+		Failures: []string{},
+	}
+	for i := range invoices {
+		invoice := &invoices[i]
+		if invoice.Outstanding() <= 0 {
+			continue
+		}
+		days := DaysOverdue(cfg, invoice.DueDate, now)
+		if days <= 0 {
+			continue
+		}
+		report.Overdue++
+
+		if err := e.chase(ctx, cfg, h, invoice, days, report); err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			report.Failures = append(report.Failures, fmt.Sprintf("%s: %v", invoice.Code, err))
+		}
+	}
+
+	return report, nil
+}
+
+// chase applies whatever is still due for one overdue invoice, stopping at the first failure
+func (e *Engine) chase(ctx context.Context, cfg config.DunningConfig, h *history, invoice *models.Invoice, days int, report *Report) error {
+	record := func(action Action) error {
+		action.InvoiceID = invoice.ID
+		action.InvoiceCode = invoice.Code
+		action.UserID = invoice.UserID
+		action.DaysOverdue = days
+		if err := e.journal.Add(&action); err != nil {
+			return fmt.Errorf("failed to journal %s: %w", action.Kind, err)
+		}
+		report.Actions = append(report.Actions, action)
+		return nil
+	}
+
+	if invoice.Status != models.InvoiceStatusOverdue && !h.done(invoice.ID, KindMarkOverdue) {
+		if _, err := e.api.Invoice().UpdateInvoiceStatus(ctx, invoice.ID, models.InvoiceStatusOverdue); err != nil {
+			return fmt.Errorf("failed to mark overdue: %w", err)
+		}
+		err := record(Action{
+			Kind:           KindMarkOverdue,
+			PreviousStatus: string(invoice.Status),
+			NewStatus:      string(models.InvoiceStatusOverdue),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Late fees are charged on the original invoice only, never on a fee
+	if fee := LateFee(cfg, invoice.Outstanding()); fee > 0 && invoice.Type != models.InvoiceTypePenalty && !h.done(invoice.ID, KindLateFee) {
+		charged, err := e.lateFeeCharged(ctx, invoice)
+		if err != nil {
+			return fmt.Errorf("failed to look up late fee: %w", err)
+		}
+		if !charged {
+			if err := e.chargeLateFee(ctx, invoice, fee, report.RanAt, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	sent := h.level(invoice.ID, false)
+	if level := LevelFor(cfg, days); severity(level) > severity(h.level(invoice.ID, true)) {
+		// The journal only knows this machine's notices
+		server, err := e.noticeSent(ctx, invoice.ID)
+		if err != nil {
+			return fmt.Errorf("failed to look up notices: %w", err)
+		}
+		if severity(server) > severity(h.level(invoice.ID, true)) {
+			sent = server
+		}
+		if severity(level) > severity(server) {
+			if _, err := e.api.Invoice().SendInvoiceReminder(ctx, invoice.ID, string(level)); err != nil {
+				return fmt.Errorf("failed to send %s: %w", level, err)
+			}
+			if err := record(Action{Kind: KindNotice, Level: level}); err != nil {
+				return err
+			}
+			sent = level
+		}
+	}
+
+	if cfg.FlagAccount && sent == LevelFinalNotice && !h.flagged[invoice.UserID] && !h.done(invoice.ID, KindFlagAccount) {
+		userID := strconv.Itoa(invoice.UserID)
+		user, err := e.api.User().GetUserDetails(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get student: %w", err)
+		}
+		if string(user.Data.StatusAccount) == cfg.FlagStatus {
+			return nil
+		}
+		resp, err := e.api.User().UpdateUserStatus(ctx, userID, cfg.FlagStatus)
+		if err != nil {
+			return fmt.Errorf("failed to flag account: %w", err)
+		}
+		if resp.Queued {
+			return fmt.Errorf("flagging the account of %s was queued while the server is unreachable", user.Data.FullName)
+		}
+		err = record(Action{
+			Kind:           KindFlagAccount,
+			Level:          LevelFinalNotice,
+			PreviousStatus: string(user.Data.StatusAccount),
+			NewStatus:      cfg.FlagStatus,
+		})
+		if err != nil {
+			return err
+		}
+		h.flagged[invoice.UserID] = true
+	}
+
+	return nil
+}
+
+// lateFeeCharged reports whether the server already has the late fee of an
+// invoice, charged from another machine or voided there as waived
+func (e *Engine) lateFeeCharged(ctx context.Context, invoice *models.Invoice) (bool, error) {
+	code := "LATE-" + invoice.Code
+	fees, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return e.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{
+			Page:    page,
+			Keyword: code,
+			Type:    string(models.InvoiceTypePenalty),
+			UserID:  invoice.UserID,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, fee := range fees {
+		if fee.Code == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// chargeLateFee creates the late fee invoice and journals it
+func (e *Engine) chargeLateFee(ctx context.Context, invoice *models.Invoice, fee float64, now time.Time, record func(Action) error) error {
+	resp, err := e.api.Invoice().CreateInvoice(ctx, map[string]interface{}{
+		"code":        "LATE-" + invoice.Code,
+		"user_id":     invoice.UserID,
+		"room_id":     invoice.RoomID,
+		"contract_id": invoice.ContractID,
+		"type":        models.InvoiceTypePenalty,
+		"description": "Phí trễ hạn hóa đơn " + invoice.Code,
+		"amount":      fee,
+		"issue_date":  now.Format(time.DateOnly),
+		"due_date":    now.AddDate(0, 0, billing.DefaultDueDays).Format(time.DateOnly),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to charge late fee: %w", err)
+	}
+	return record(Action{
+		Kind:         KindLateFee,
+		Amount:       fee,
+		FeeInvoiceID: resp.Data.ID,
+	})
+}
+
+// noticeSent returns the most severe notice the server has sent for an
+// invoice, including notices sent from other machines
+func (e *Engine) noticeSent(ctx context.Context, invoiceID int) (Level, error) {
+	resp, err := e.api.Invoice().GetInvoiceReminders(ctx, invoiceID)
+	if err != nil {
+		return "", err
+	}
+	var level Level
+	for _, reminder := range resp.Data {
+		if severity(Level(reminder.Level)) > severity(level) {
+			level = Level(reminder.Level)
+		}
+	}
+	return level, nil
+}
+
+// Reverse undoes an action: the invoice status or account status is
+// restored and a late fee is voided. An invoice that is no longer overdue
+// keeps its status and ErrStatusChanged is returned. A notice cannot be unsent; reversing
+// it withdraws it, so it no longer leads to the account being flagged.
+func (e *Engine) Reverse(ctx context.Context, actionID string) (*Action, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	action, err := e.journal.Get(actionID)
+	if err != nil {
+		return nil, err
+	}
+	if action.ReversedAt != nil {
+		return nil, ErrAlreadyReversed
+	}
+
+	switch action.Kind {
+	case KindMarkOverdue:
+		err = e.restoreStatus(ctx, action)
+	case KindLateFee:
+		_, err = e.api.Invoice().VoidInvoice(ctx, action.FeeInvoiceID, "Miễn phí trễ hạn hóa đơn "+action.InvoiceCode)
+	case KindFlagAccount:
+		var resp *api.Response[any]
+		resp, err = e.api.User().UpdateUserStatus(ctx, strconv.Itoa(action.UserID), action.PreviousStatus)
+		if err == nil && resp.Queued {
+			err = errors.New("restoring the account status was queued while the server is unreachable")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	action.ReversedAt = &now
+	if err := e.journal.Update(action); err != nil {
+		return nil, err
+	}
+
+	return action, nil
+}
+
+// restoreStatus sets an invoice the engine marked overdue back to its
+// previous status, unless it is no longer overdue
+func (e *Engine) restoreStatus(ctx context.Context, action *Action) error {
+	invoice, err := e.api.Invoice().GetInvoiceDetails(ctx, action.InvoiceID)
+	if err != nil {
+		return err
+	}
+	if invoice.Data.Status != models.InvoiceStatusOverdue {
+		return fmt.Errorf("%s is %s: %w", action.InvoiceCode, invoice.Data.Status, ErrStatusChanged)
+	}
+
+	_, err = e.api.Invoice().UpdateInvoiceStatus(ctx, action.InvoiceID, models.InvoiceStatus(action.PreviousStatus))
+	return err
+}
+
+// List returns the journal, oldest first
+func (e *Engine) List() ([]Action, error) {
+	return e.journal.List()
+}
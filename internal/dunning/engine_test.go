@@ -0,0 +1,162 @@
+package dunning
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testConfig = config.DunningConfig{
+	GraceDays:       5,
+	ReminderDays:    1,
+	WarningDays:     10,
+	FinalNoticeDays: 30,
+}
+
+func TestLevelFor(t *testing.T) {
+	tests := []struct {
+		days int
+		want Level
+	}{
+		{-3, ""},
+		{0, ""},
+		{1, LevelReminder},
+		{9, LevelReminder},
+		{10, LevelWarning},
+		{29, LevelWarning},
+		{30, LevelFinalNotice},
+		{365, LevelFinalNotice},
+	}
+
+	for _, tt := range tests {
+		if got := LevelFor(testConfig, tt.days); got != tt.want {
+			t.Errorf("LevelFor(%d) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+
+	late := testConfig
+	late.ReminderDays = 3
+	if got := LevelFor(late, 2); got != "" {
+		t.Errorf("LevelFor(2) before the first reminder = %q, want none", got)
+	}
+}
+
+func TestDaysOverdue(t *testing.T) {
+	due := time.Date(2025, time.September, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{"before due", time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC), -14},
+		{"on the due date", time.Date(2025, time.September, 10, 23, 0, 0, 0, time.UTC), -5},
+		{"last day of grace", time.Date(2025, time.September, 15, 18, 30, 0, 0, time.UTC), 0},
+		{"first day overdue", time.Date(2025, time.September, 16, 0, 1, 0, 0, time.UTC), 1},
+		{"across a month", time.Date(2025, time.October, 15, 12, 0, 0, 0, time.UTC), 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysOverdue(testConfig, due, tt.now); got != tt.want {
+				t.Errorf("DaysOverdue() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLateFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		feeType     string
+		feeAmount   float64
+		outstanding float64
+		want        float64
+	}{
+		{"none", "none", 50_000, 3_000_000, 0},
+		{"unset", "", 50_000, 3_000_000, 0},
+		{"flat", "flat", 50_000, 3_000_000, 50_000},
+		{"flat ignores the amount", "FLAT", 50_000, 10_000, 50_000},
+		{"percentage", "percentage", 2, 3_000_000, 60_000},
+		{"percentage rounds", "percentage", 1.5, 1_234_567, 18_519},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DunningConfig{FeeType: tt.feeType, FeeAmount: tt.feeAmount}
+			if got := LateFee(cfg, tt.outstanding); got != tt.want {
+				t.Errorf("LateFee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReverseMarkOverdue(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     models.InvoiceStatus
+		wantErr    error
+		wantStatus string
+	}{
+		{"still overdue", models.InvoiceStatusOverdue, nil, "pending"},
+		{"paid since", models.InvoiceStatusPaid, ErrStatusChanged, ""},
+		{"partly paid since", models.InvoiceStatusPartiallyPaid, ErrStatusChanged, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var restored string
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /invoices/{id}", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]any{"success": true, "data": models.Invoice{
+					ID: 1, Code: "HD1", UserID: 5, Type: models.InvoiceTypeRoomFee, Amount: 100, Status: tt.status,
+				}})
+			})
+			mux.HandleFunc("PUT /invoices/{id}/status", func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Status string `json:"status"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				restored = body.Status
+				json.NewEncoder(w).Encode(map[string]any{"success": true, "data": models.Invoice{
+					ID: 1, Code: "HD1", UserID: 5, Type: models.InvoiceTypeRoomFee, Amount: 100, Status: models.InvoiceStatus(body.Status),
+				}})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			journal, err := Open(filepath.Join(t.TempDir(), "dunning.db"))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer journal.Close()
+			action := &Action{Kind: KindMarkOverdue, InvoiceID: 1, InvoiceCode: "HD1", PreviousStatus: "pending", NewStatus: "overdue"}
+			if err := journal.Add(action); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+
+			engine := NewEngine(api.NewAPI(client.New().SetBaseURL(server.URL)), journal)
+			_, err = engine.Reverse(context.Background(), action.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reverse() error = %v, want %v", err, tt.wantErr)
+			}
+			if restored != tt.wantStatus {
+				t.Errorf("Reverse() set the status to %q, want %q", restored, tt.wantStatus)
+			}
+
+			stored, _ := journal.Get(action.ID)
+			if wantReversed := tt.wantErr == nil; (stored.ReversedAt != nil) != wantReversed {
+				t.Errorf("journal marks the action reversed: %v, want %v", stored.ReversedAt != nil, wantReversed)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package dunning
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var actionsBucket = []byte("actions")
+
+// ErrNotFound is returned when an action ID does not exist
+var ErrNotFound = errors.New("action not found")
+
+type Kind string
+
+const (
+	KindMarkOverdue Kind = "mark_overdue"
+	KindLateFee     Kind = "late_fee"
+	KindNotice      Kind = "notice"
+	KindFlagAccount Kind = "flag_account"
+)
+
+type Level string
+
+const (
+	LevelReminder    Level = "reminder"
+	LevelWarning     Level = "warning"
+	LevelFinalNotice Level = "final_notice"
+)
+
+// Action is one change the engine made, with what is needed to undo it
+type Action struct {
+	ID          string `json:"id"`
+	Kind        Kind   `json:"kind"`
+	InvoiceID   int    `json:"invoice_id"`
+	InvoiceCode string `json:"invoice_code"`
+	UserID      int    `json:"user_id"`
+	Level       Level  `json:"level,omitempty"`
+	DaysOverdue int    `json:"days_overdue"`
+	// Amount and FeeInvoiceID are set for late fees
+	Amount       float64 `json:"amount,omitempty"`
+	FeeInvoiceID int     `json:"fee_invoice_id,omitempty"`
+	// PreviousStatus is the invoice or account status restored on reversal
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	NewStatus      string     `json:"new_status,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReversedAt     *time.Time `json:"reversed_at"`
+}
+
+// Journal keeps the engine's actions in order in a bbolt database
+type Journal struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the journal database at path
+func Open(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create dunning dir: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dunning journal: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(actionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize dunning journal: %w", err)
+	}
+
+	return &Journal{db: db}, nil
+}
+
+// Add records a new action and assigns its ID
+func (j *Journal) Add(action *Action) error {
+	return j.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(actionsBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		action.ID = strconv.FormatUint(seq, 10)
+		action.CreatedAt = time.Now()
+
+		return put(bucket, action)
+	})
+}
+
+// List returns all actions, oldest first
+func (j *Journal) List() ([]Action, error) {
+	actions := []Action{}
+
+	err := j.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(actionsBucket).ForEach(func(_, data []byte) error {
+			var action Action
+			if err := json.Unmarshal(data, &action); err != nil {
+				return err
+			}
+			actions = append(actions, action)
+			return nil
+		})
+	})
+
+	return actions, err
+}
+
+func (j *Journal) Get(id string) (*Action, error) {
+	var action Action
+
+	err := j.db.View(func(tx *bolt.Tx) error {
+		k, err := key(id)
+		if err != nil {
+			return err
+		}
+		data := tx.Bucket(actionsBucket).Get(k)
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &action)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// Update saves an existing action
+func (j *Journal) Update(action *Action) error {
+	return j.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(actionsBucket)
+		k, err := key(action.ID)
+		if err != nil {
+			return err
+		}
+		if bucket.Get(k) == nil {
+			return ErrNotFound
+		}
+
+		return put(bucket, action)
+	})
+}
+
+func (j *Journal) Close() error {
+	return j.db.Close()
+}
+
+func put(bucket *bolt.Bucket, action *Action) error {
+	k, err := key(action.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to encode action: %w", err)
+	}
+
+	return bucket.Put(k, data)
+}
+
+// key encodes the sequence number big-endian so bbolt iterates in journal order
+func key(id string) ([]byte, error) {
+	seq, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k, nil
+}
@@ -0,0 +1,121 @@
+package expiry
+
+import (
+	"changeme/internal/billing"
+	"changeme/internal/models"
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Due is an active contract that ends within a window or has already ended
+type Due struct {
+	ContractID   int       `json:"contract_id"`
+	ContractCode string    `json:"contract_code"`
+	UserID       int       `json:"user_id"`
+	StudentName  string    `json:"student_name"`
+	StudentCode  string    `json:"student_code"`
+	Phone        string    `json:"phone"`
+	Email        string    `json:"email"`
+	RoomID       int       `json:"room_id"`
+	RoomNumber   string    `json:"room_number"`
+	EndDate      time.Time `json:"end_date"`
+	// DaysLeft is negative once the end date has passed
+	DaysLeft int `json:"days_left"`
+	// Window is the tightest window the contract is in; 0 when expired
+	Window  int  `json:"window"`
+	Expired bool `json:"expired"`
+}
+
+// WindowGroup lists the contracts of one window that are not in a tighter one
+type WindowGroup struct {
+	Days      int   `json:"days"`
+	Contracts []Due `json:"contracts"`
+}
+
+// Renewals is the "renewals due" view of the staff dashboard
+type Renewals struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Expired   []Due         `json:"expired"`
+	Windows   []WindowGroup `json:"windows"`
+	Total     int           `json:"total"`
+}
+
+// Scan sorts active contracts into the windows (in days) they end in and
+// collects the ones that ended but are still active. A contract that was
+// already renewed, i.e. the student has an active contract starting after it,
+// is left out.
+func Scan(contracts []models.Contract, windows []int, now time.Time) *Renewals {
+	today := billing.Date(now)
+	windows = slices.Clone(windows)
+	slices.Sort(windows)
+
+	renewals := &Renewals{CheckedAt: now, Expired: []Due{}, Windows: make([]WindowGroup, len(windows))}
+	for i, days := range windows {
+		renewals.Windows[i] = WindowGroup{Days: days, Contracts: []Due{}}
+	}
+
+	for _, c := range contracts {
+		if c.Status != models.ContractStatusActive || renewed(c, contracts) {
+			continue
+		}
+
+		end := billing.Date(c.EndDate)
+		due := Due{
+			ContractID:   c.ID,
+			ContractCode: c.Code,
+			UserID:       c.UserID,
+			StudentName:  c.User.FullName,
+			StudentCode:  c.User.StudentCode,
+			Phone:        c.User.Phone,
+			Email:        c.User.Email,
+			RoomID:       c.RoomID,
+			RoomNumber:   c.Room.RoomNumber,
+			EndDate:      end,
+			DaysLeft:     int(end.Sub(today).Hours() / 24),
+		}
+
+		if end.Before(today) {
+			due.Expired = true
+			renewals.Expired = append(renewals.Expired, due)
+			renewals.Total++
+			continue
+		}
+		for i, days := range windows {
+			if due.DaysLeft <= days {
+				due.Window = days
+				renewals.Windows[i].Contracts = append(renewals.Windows[i].Contracts, due)
+				renewals.Total++
+				break
+			}
+		}
+	}
+
+	byDaysLeft := func(a, b Due) int {
+		return cmp.Or(cmp.Compare(a.DaysLeft, b.DaysLeft), cmp.Compare(a.ContractCode, b.ContractCode))
+	}
+	slices.SortFunc(renewals.Expired, byDaysLeft)
+	for _, group := range renewals.Windows {
+		slices.SortFunc(group.Contracts, byDaysLeft)
+	}
+	return renewals
+}
+
+// All returns every due contract, most urgent first
+func (r *Renewals) All() []Due {
+	all := slices.Clone(r.Expired)
+	for _, group := range r.Windows {
+		all = append(all, group.Contracts...)
+	}
+	return all
+}
+
+func renewed(c models.Contract, contracts []models.Contract) bool {
+	end := billing.Date(c.EndDate)
+	for _, other := range contracts {
+		if other.ID != c.ID && other.UserID == c.UserID && other.Status == models.ContractStatusActive && billing.Date(other.StartDate).After(end) {
+			return true
+		}
+	}
+	return false
+}
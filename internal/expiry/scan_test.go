@@ -0,0 +1,67 @@
+package expiry
+
+import (
+	"changeme/internal/models"
+	"testing"
+	"time"
+)
+
+func TestScan(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	contract := func(id, userID int, code, from, to string, status models.ContractStatus) models.Contract {
+		return models.Contract{ID: id, Code: code, UserID: userID, StartDate: date(from), EndDate: date(to), Status: status}
+	}
+	now := time.Date(2025, time.September, 1, 15, 30, 0, 0, time.UTC)
+
+	renewals := Scan([]models.Contract{
+		contract(1, 1, "C001", "2025-01-01", "2025-08-20", models.ContractStatusActive),
+		contract(2, 2, "C002", "2025-01-01", "2025-08-31", models.ContractStatusActive),
+		contract(3, 3, "C003", "2025-01-01", "2025-09-01", models.ContractStatusActive),
+		contract(4, 4, "C004", "2025-01-01", "2025-09-08", models.ContractStatusActive),
+		contract(5, 5, "C005", "2025-01-01", "2025-09-20", models.ContractStatusActive),
+		contract(6, 6, "C006", "2025-01-01", "2025-10-01", models.ContractStatusActive),
+		contract(7, 7, "C007", "2025-01-01", "2025-12-31", models.ContractStatusActive),
+		// Renewed: the student already has the next contract
+		contract(8, 8, "C008", "2025-01-01", "2025-09-05", models.ContractStatusActive),
+		contract(9, 8, "C009", "2025-09-06", "2026-06-30", models.ContractStatusActive),
+		// Not active
+		contract(10, 10, "C010", "2025-01-01", "2025-09-03", models.ContractStatusCancelled),
+		contract(11, 11, "C011", "2025-01-01", "2025-08-01", models.ContractStatusInactive),
+	}, []int{30, 7}, now)
+
+	tests := []struct {
+		name  string
+		got   []Due
+		codes []string
+		days  []int
+	}{
+		{"expired", renewals.Expired, []string{"C001", "C002"}, []int{-12, -1}},
+		{"within 7 days", renewals.Windows[0].Contracts, []string{"C003", "C004"}, []int{0, 7}},
+		{"within 30 days", renewals.Windows[1].Contracts, []string{"C005", "C006"}, []int{19, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.codes) {
+				t.Fatalf("Scan() listed %d contracts, want %v", len(tt.got), tt.codes)
+			}
+			for i, due := range tt.got {
+				if due.ContractCode != tt.codes[i] || due.DaysLeft != tt.days[i] {
+					t.Errorf("contract %d = %s with %d days left, want %s with %d", i, due.ContractCode, due.DaysLeft, tt.codes[i], tt.days[i])
+				}
+			}
+		})
+	}
+
+	if renewals.Windows[0].Days != 7 || renewals.Windows[1].Days != 30 {
+		t.Errorf("Scan() windows = %d, %d days; want 7, 30", renewals.Windows[0].Days, renewals.Windows[1].Days)
+	}
+	if renewals.Total != 6 {
+		t.Errorf("Scan() total = %d, want 6", renewals.Total)
+	}
+	if all := renewals.All(); len(all) != 6 || all[0].ContractCode != "C001" || !all[0].Expired || all[5].Window != 30 {
+		t.Errorf("All() = %v, want the expired contracts first", all)
+	}
+}
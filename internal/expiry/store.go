@@ -0,0 +1,87 @@
+package expiry
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var reportedBucket = []byte("reported")
+
+// Store keeps the stage each contract was last reported at, so a restart
+// does not announce every due contract again
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the store at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create expiry dir: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open expiry store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(reportedBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize expiry store: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Reported returns the stage of every reported contract by contract ID
+func (s *Store) Reported() (map[int]int, error) {
+	reported := make(map[int]int)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(reportedBucket).ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 8 {
+				return nil
+			}
+			reported[int(binary.BigEndian.Uint64(k))] = int(int64(binary.BigEndian.Uint64(v)))
+			return nil
+		})
+	})
+
+	return reported, err
+}
+
+// Replace stores reported as the complete set of reported contracts
+func (s *Store) Replace(reported map[int]int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(reportedBucket); err != nil {
+			return err
+		}
+		bucket, err := tx.CreateBucket(reportedBucket)
+		if err != nil {
+			return err
+		}
+
+		for contractID, stage := range reported {
+			k := make([]byte, 8)
+			v := make([]byte, 8)
+			binary.BigEndian.PutUint64(k, uint64(contractID))
+			binary.BigEndian.PutUint64(v, uint64(int64(stage)))
+			if err := bucket.Put(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,99 @@
+package expiry
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// expiredStage ranks above every window so an expired contract is reported
+// again after it was reported as about to expire
+const expiredStage = -1
+
+// Watcher scans contracts and remembers which ones it has already reported
+// so every contract is announced once per window it enters. With a store the
+// reported stages survive a restart; without one they are kept in memory.
+type Watcher struct {
+	api   *api.API
+	store *Store
+
+	mu       sync.Mutex
+	reported map[int]int
+}
+
+func NewWatcher(api *api.API, store *Store) *Watcher {
+	return &Watcher{
+		api:   api,
+		store: store,
+	}
+}
+
+// Renewals scans every contract and returns the renewals view without
+// marking anything as reported
+func (w *Watcher) Renewals(ctx context.Context, cfg config.ExpiryConfig, now time.Time) (*Renewals, error) {
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return w.api.Contract().GetListContracts(ctx, page, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	return Scan(contracts, cfg.Windows, now), nil
+}
+
+// Check scans every contract and returns the renewals view together with
+// the contracts that entered a tighter window since the previous check
+func (w *Watcher) Check(ctx context.Context, cfg config.ExpiryConfig, now time.Time) (*Renewals, []Due, error) {
+	renewals, err := w.Renewals(ctx, cfg, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.reported == nil {
+		w.reported = make(map[int]int)
+		if w.store != nil {
+			if w.reported, err = w.store.Reported(); err != nil {
+				return nil, nil, fmt.Errorf("failed to read reported contracts: %w", err)
+			}
+		}
+	}
+
+	var fresh []Due
+	current := make(map[int]int)
+	for _, due := range renewals.All() {
+		stage := due.Window
+		if due.Expired {
+			stage = expiredStage
+		}
+		current[due.ContractID] = stage
+
+		previous, seen := w.reported[due.ContractID]
+		if !seen || tighter(stage, previous) {
+			fresh = append(fresh, due)
+		}
+	}
+	// Contracts that were renewed or closed drop out and are announced
+	// afresh should they ever be due again
+	if w.store != nil {
+		if err := w.store.Replace(current); err != nil {
+			return nil, nil, fmt.Errorf("failed to save reported contracts: %w", err)
+		}
+	}
+	w.reported = current
+
+	return renewals, fresh, nil
+}
+
+func tighter(stage, previous int) bool {
+	if previous == expiredStage {
+		return false
+	}
+	return stage == expiredStage || stage < previous
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"changeme/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var userHeader = []string{
+	"ID", "Họ và tên", "Mã sinh viên", "Email", "Số điện thoại", "Giới tính", "Ngày sinh",
+	"Vai trò", "Trạng thái", "Tài khoản", "Đã xác thực", "Ngành", "Địa chỉ",
+	"Mã phòng", "Số phòng", "Loại phòng",
+	"Người liên hệ khẩn cấp", "SĐT liên hệ khẩn cấp", "Quan hệ", "Ngày tạo",
+}
+
+var roomHeader = []string{
+	"ID", "Số phòng", "Trạng thái", "Số sinh viên", "Mã loại phòng", "Loại phòng",
+	"Sức chứa", "Giá thuê", "Diện tích (m²)", "Tiện nghi", "Ngày tạo",
+}
+
+var contractHeader = []string{
+	"ID", "Mã hợp đồng", "Trạng thái", "Ngày bắt đầu", "Ngày kết thúc", "Giá thuê",
+	"Mã sinh viên", "Họ và tên", "Email", "Số điện thoại",
+	"Số phòng", "Loại phòng", "Ghi chú", "Ngày tạo",
+}
+
+var maintenanceHeader = []string{
+	"ID", "Mã phòng", "Số phòng", "Ngày bảo trì", "Nội dung", "Chi phí", "Ngày tạo",
+}
+
+var genderLabels = map[models.Gender]string{
+	models.GenderMale:   "Nam",
+	models.GenderFemale: "Nữ",
+	models.GenderOther:  "Khác",
+}
+
+var roleLabels = map[models.UserRole]string{
+	models.UserRoleAdmin:   "Quản trị viên",
+	models.UserRoleStaff:   "Nhân viên",
+	models.UserRoleStudent: "Sinh viên",
+}
+
+var userStatusLabels = map[models.UserStatus]string{
+	models.UserStatusActive:   "Đang ở",
+	models.UserStatusInactive: "Không hoạt động",
+	models.UserStatusAbsent:   "Vắng mặt",
+}
+
+var accountStatusLabels = map[models.UserStatusAccount]string{
+	models.UserStatusAccountPending:  "Chờ duyệt",
+	models.UserStatusAccountApproved: "Đã duyệt",
+	models.UserStatusAccountRejected: "Từ chối",
+	models.UserStatusAccountBanned:   "Bị khóa",
+}
+
+var roomStatusLabels = map[models.RoomStatus]string{
+	models.RoomStatusAvailable:   "Còn trống",
+	models.RoomStatusOccupied:    "Đã đầy",
+	models.RoomStatusMaintenance: "Bảo trì",
+}
+
+var contractStatusLabels = map[models.ContractStatus]string{
+	models.ContractStatusActive:    "Hiệu lực",
+	models.ContractStatusInactive:  "Hết hiệu lực",
+	models.ContractStatusCancelled: "Đã hủy",
+}
+
+func userRow(u models.User) []string {
+	var roomID, roomNumber, category string
+	if u.RoomID != nil {
+		roomID = strconv.Itoa(*u.RoomID)
+	}
+	if u.Room != nil {
+		roomNumber = u.Room.RoomNumber
+		category = u.Room.RoomCategory.Name
+	}
+
+	var contact models.EmergencyContact
+	if u.EmergencyContact != nil {
+		contact = *u.EmergencyContact
+	}
+
+	return []string{
+		strconv.Itoa(u.ID), u.FullName, u.StudentCode, u.Email, u.Phone,
+		label(genderLabels, u.Gender), optionalDate(u.Birthday),
+		label(roleLabels, u.Role), label(userStatusLabels, u.Status), label(accountStatusLabels, u.StatusAccount),
+		yesNo(u.IsVerify), deref(u.Major), deref(u.Address),
+		roomID, roomNumber, category,
+		contact.Name, contact.Phone, contact.Relationship, date(u.CreatedAt),
+	}
+}
+
+func roomRow(r models.Room) []string {
+	amenities := make([]string, len(r.RoomAmenities))
+	for i, ra := range r.RoomAmenities {
+		amenities[i] = ra.Amenity.Name
+	}
+
+	return []string{
+		strconv.Itoa(r.ID), r.RoomNumber, label(roomStatusLabels, r.Status), strconv.Itoa(r.UserCount),
+		strconv.Itoa(r.RoomCategoryID), r.RoomCategory.Name, strconv.Itoa(r.RoomCategory.Capacity),
+		amount(r.RoomCategory.Price), amount(r.RoomCategory.Acreage), strings.Join(amenities, ", "), date(r.CreatedAt),
+	}
+}
+
+func contractRow(c models.Contract) []string {
+	return []string{
+		strconv.Itoa(c.ID), c.Code, label(contractStatusLabels, c.Status), date(c.StartDate), date(c.EndDate), amount(c.Price),
+		c.User.StudentCode, c.User.FullName, c.User.Email, c.User.Phone,
+		c.Room.RoomNumber, c.Room.RoomCategory.Name, c.Description, date(c.CreatedAt),
+	}
+}
+
+func maintenanceRow(h models.MaintenanceHistory, roomNumber string) []string {
+	return []string{
+		strconv.Itoa(h.ID), strconv.Itoa(h.RoomID), roomNumber, date(h.MaintenanceDate),
+		h.Description, amount(h.Cost), date(h.CreatedAt),
+	}
+}
+
+// label localizes an enum value and falls back to the raw value for ones it does not know
+func label[T ~string](labels map[T]string, value T) string {
+	if l, ok := labels[value]; ok {
+		return l
+	}
+	return string(value)
+}
+
+func date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02/01/2006")
+}
+
+func optionalDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return date(*t)
+}
+
+// amount is written without grouping so spreadsheets read it as a number
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Có"
+	}
+	return "Không"
+}
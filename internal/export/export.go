@@ -0,0 +1,142 @@
+package export
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"changeme/internal/sheet"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Kind names a list endpoint that can be exported
+type Kind string
+
+const (
+	KindUsers                Kind = "users"
+	KindRooms                Kind = "rooms"
+	KindContracts            Kind = "contracts"
+	KindMaintenanceHistories Kind = "maintenance_histories"
+)
+
+// Filter carries the list filters of the screen being exported. Fields that
+// do not apply to a kind are ignored.
+type Filter struct {
+	Keyword       string `json:"keyword"`
+	Order         string `json:"order"`
+	Status        string `json:"status"`
+	Gender        string `json:"gender"`
+	StatusAccount string `json:"status_account"`
+	Role          string `json:"role"`
+	HasRoom       *bool  `json:"has_room"`
+	RoomID        string `json:"room_id"`
+}
+
+// Progress reports how many records have been fetched. Total is 0 while the
+// server has not said how many there are.
+type Progress func(fetched, total int)
+
+// Exporter walks every page of a list and renders it as a spreadsheet
+type Exporter struct {
+	api *api.API
+}
+
+func NewExporter(api *api.API) *Exporter {
+	return &Exporter{api: api}
+}
+
+// Export fetches every record of kind matching filter and writes them in the
+// format chosen by filename's extension. It returns the file and the number
+// of records in it.
+func (e *Exporter) Export(ctx context.Context, kind Kind, filter Filter, filename string, progress Progress) ([]byte, int, error) {
+	if _, err := sheet.Format(filename); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		header []string
+		rows   [][]string
+	)
+	switch kind {
+	case KindUsers:
+		users, err := fetch(ctx, progress, func(ctx context.Context, page int) (*api.Response[[]models.User], error) {
+			return e.api.User().GetListUsers(ctx, page, filter.Keyword, filter.Order, filter.Status, filter.Gender, filter.StatusAccount, filter.Role, filter.HasRoom)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		header, rows = userHeader, mapRows(users, userRow)
+
+	case KindRooms:
+		rooms, err := fetch(ctx, progress, e.api.Room().GetListRooms)
+		if err != nil {
+			return nil, 0, err
+		}
+		header, rows = roomHeader, mapRows(rooms, roomRow)
+
+	case KindContracts:
+		var keyword *string
+		if filter.Keyword != "" {
+			keyword = &filter.Keyword
+		}
+		contracts, err := fetch(ctx, progress, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+			return e.api.Contract().GetListContracts(ctx, page, keyword)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		header, rows = contractHeader, mapRows(contracts, contractRow)
+
+	case KindMaintenanceHistories:
+		histories, err := fetch(ctx, progress, func(ctx context.Context, page int) (*api.Response[[]models.MaintenanceHistory], error) {
+			return e.api.MaintenanceHistory().GetListMaintenanceHistories(ctx, strconv.Itoa(page), filter.RoomID)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Histories only carry the room ID; the office wants room numbers
+		rooms, err := api.FetchAll(ctx, e.api.Room().GetListRooms)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to load rooms: %w", err)
+		}
+		numbers := make(map[int]string, len(rooms))
+		for _, room := range rooms {
+			numbers[room.ID] = room.RoomNumber
+		}
+
+		header, rows = maintenanceHeader, mapRows(histories, func(h models.MaintenanceHistory) []string {
+			return maintenanceRow(h, numbers[h.RoomID])
+		})
+
+	default:
+		return nil, 0, fmt.Errorf("unknown list %q", kind)
+	}
+
+	data, err := sheet.Write(filename, header, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, len(rows), nil
+}
+
+// fetch is api.FetchAll with a progress callback after every page
+func fetch[T any](ctx context.Context, progress Progress, list func(ctx context.Context, page int) (*api.Response[[]T], error)) ([]T, error) {
+	fetched := 0
+	return api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]T], error) {
+		resp, err := list(ctx, page)
+		if err == nil && progress != nil {
+			fetched += len(resp.Data)
+			progress(fetched, resp.Total)
+		}
+		return resp, err
+	})
+}
+
+func mapRows[T any](items []T, row func(T) []string) [][]string {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = row(item)
+	}
+	return rows
+}
@@ -0,0 +1,291 @@
+package lease
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/config"
+	"changeme/internal/deposit"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Renewal is the next period of a contract
+type Renewal struct {
+	EndDate time.Time `json:"end_date"`
+	// Price defaults to the room category's current price
+	Price float64 `json:"price"`
+	// CarryOver moves the deposit to the new contract
+	CarryOver bool `json:"carry_over"`
+}
+
+// Termination is an early end of a contract and what it costs the student
+type Termination struct {
+	ContractID      int       `json:"contract_id"`
+	ContractCode    string    `json:"contract_code"`
+	StudentName     string    `json:"student_name"`
+	EndDate         time.Time `json:"end_date"`
+	OriginalEndDate time.Time `json:"original_end_date"`
+	// NoticeDays is how many days ahead of EndDate the termination is made
+	NoticeDays         int     `json:"notice_days"`
+	RequiredNoticeDays int     `json:"required_notice_days"`
+	Penalty            float64 `json:"penalty"`
+	Reason             string  `json:"reason"`
+
+	contract models.Contract
+}
+
+// TerminationResult is a completed termination
+type TerminationResult struct {
+	Termination    *Termination    `json:"termination"`
+	Contract       models.Contract `json:"contract"`
+	PenaltyInvoice *models.Invoice `json:"penalty_invoice"`
+}
+
+// Manager changes contracts after checking their dates
+type Manager struct {
+	api *api.API
+	cfg config.ContractsConfig
+}
+
+func NewManager(api *api.API, cfg config.ContractsConfig) *Manager {
+	return &Manager{api: api, cfg: cfg}
+}
+
+// Create validates and creates a contract from the CreateContract payload
+func (m *Manager) Create(ctx context.Context, contractData map[string]interface{}) (*api.Response[models.Contract], error) {
+	contract := models.Contract{Status: models.ContractStatusActive}
+	if err := apply(&contract, contractData); err != nil {
+		return nil, err
+	}
+	if err := m.check(ctx, contract); err != nil {
+		return nil, err
+	}
+
+	return m.api.Contract().CreateContract(ctx, contractData)
+}
+
+// Update validates and applies changes to a contract. The status is changed
+// through Renew, Terminate and Cancel instead.
+func (m *Manager) Update(ctx context.Context, contractID int, contractData map[string]interface{}) (*api.Response[models.Contract], error) {
+	current, err := m.api.Contract().GetContractDetails(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := current.Data
+	if err := apply(&contract, contractData); err != nil {
+		return nil, err
+	}
+	if contract.Status != current.Data.Status {
+		return nil, ErrStatusChange
+	}
+	if err := m.check(ctx, contract); err != nil {
+		return nil, err
+	}
+
+	return m.api.Contract().UpdateContract(ctx, contractID, contractData)
+}
+
+// Renew opens a contract for the same student and room from the day after
+// the current one ends
+func (m *Manager) Renew(ctx context.Context, contractID int, renewal Renewal) (*api.Response[models.Contract], error) {
+	current, err := m.active(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := models.Contract{
+		UserID:    current.UserID,
+		RoomID:    current.RoomID,
+		StartDate: billing.Date(current.EndDate).AddDate(0, 0, 1),
+		EndDate:   billing.Date(renewal.EndDate),
+		Price:     renewal.Price,
+		Status:    models.ContractStatusActive,
+	}
+	if next.Price < 0 {
+		return nil, errors.New("price must not be negative")
+	}
+	if next.Price == 0 {
+		next.Price = current.Price
+		if room, err := m.api.Room().GetRoomDetails(ctx, current.RoomID); err == nil && room.Data.RoomCategory.Price > 0 {
+			next.Price = room.Data.RoomCategory.Price
+		}
+	}
+	if err := m.check(ctx, next); err != nil {
+		return nil, err
+	}
+
+	return m.api.Contract().RenewContract(ctx, contractID, map[string]interface{}{
+		"start_date":  next.StartDate.Format(time.DateOnly),
+		"end_date":    next.EndDate.Format(time.DateOnly),
+		"price":       next.Price,
+		"carry_over":  renewal.CarryOver,
+		"description": "Gia hạn hợp đồng " + current.Code,
+	})
+}
+
+// PlanTermination works out the penalty for ending a contract on end when
+// the termination is made on now
+func (m *Manager) PlanTermination(ctx context.Context, contractID int, end time.Time, now time.Time) (*Termination, error) {
+	contract, err := m.active(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	end = billing.Date(end)
+	start, original := billing.Date(contract.StartDate), billing.Date(contract.EndDate)
+	if end.Before(start) || !end.Before(original) {
+		return nil, fmt.Errorf("the termination date must be between %s and the day before %s", formatDate(start), formatDate(original))
+	}
+
+	termination := &Termination{
+		ContractID:         contract.ID,
+		ContractCode:       contract.Code,
+		StudentName:        contract.User.FullName,
+		EndDate:            end,
+		OriginalEndDate:    original,
+		NoticeDays:         max(int(end.Sub(billing.Date(now)).Hours()/24), 0),
+		RequiredNoticeDays: m.cfg.NoticeDays,
+		contract:           *contract,
+	}
+	if termination.NoticeDays < m.cfg.NoticeDays {
+		termination.Penalty = billing.Round(contract.Price * m.cfg.PenaltyMonths)
+	}
+	return termination, nil
+}
+
+// Terminate ends a contract early. penalty overrides the computed one when
+// it is not nil; a positive penalty is invoiced to the student.
+func (m *Manager) Terminate(ctx context.Context, contractID int, end time.Time, reason string, penalty *float64, now time.Time) (*TerminationResult, error) {
+	if reason == "" {
+		return nil, errors.New("a reason is required to terminate a contract")
+	}
+
+	termination, err := m.PlanTermination(ctx, contractID, end, now)
+	if err != nil {
+		return nil, err
+	}
+	if penalty != nil {
+		if *penalty < 0 {
+			return nil, errors.New("penalty must not be negative")
+		}
+		termination.Penalty = billing.Round(*penalty)
+	}
+	termination.Reason = reason
+
+	result := &TerminationResult{Termination: termination}
+	contract := termination.contract
+	if termination.Penalty > 0 {
+		issued := billing.Date(now)
+		invoice, err := m.api.Invoice().CreateInvoice(ctx, map[string]interface{}{
+			"code":        "TER-" + contract.Code,
+			"user_id":     contract.UserID,
+			"room_id":     contract.RoomID,
+			"contract_id": contract.ID,
+			"type":        models.InvoiceTypePenalty,
+			"description": fmt.Sprintf("Phạt chấm dứt hợp đồng %s trước hạn: %s", contract.Code, reason),
+			"amount":      termination.Penalty,
+			"issue_date":  issued.Format(time.DateOnly),
+			"due_date":    issued.AddDate(0, 0, billing.DefaultDueDays).Format(time.DateOnly),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to invoice the penalty: %w", err)
+		}
+		result.PenaltyInvoice = &invoice.Data
+	}
+
+	resp, err := m.api.Contract().TerminateContract(ctx, contractID, map[string]interface{}{
+		"end_date": termination.EndDate.Format(time.DateOnly),
+		"reason":   reason,
+		"penalty":  termination.Penalty,
+	})
+	if err != nil {
+		if result.PenaltyInvoice != nil {
+			if _, voidErr := m.api.Invoice().VoidInvoice(context.WithoutCancel(ctx), result.PenaltyInvoice.ID, "Chấm dứt hợp đồng không thành công"); voidErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to void penalty invoice %s: %w", result.PenaltyInvoice.Code, voidErr))
+			}
+		}
+		return nil, err
+	}
+	result.Contract = resp.Data
+	return result, nil
+}
+
+// Cancel voids a contract that has not started yet. Its deposit must be
+// settled and its invoices unpaid; those invoices are voided.
+func (m *Manager) Cancel(ctx context.Context, contractID int, reason string, now time.Time) (*api.Response[models.Contract], error) {
+	if reason == "" {
+		return nil, errors.New("a reason is required to cancel a contract")
+	}
+
+	contract, err := m.active(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if !billing.Date(contract.StartDate).After(billing.Date(now)) {
+		return nil, fmt.Errorf("%s: %w", contract.Code, ErrStarted)
+	}
+	if err := deposit.NewLedger(m.api).EnsureSettled(ctx, contractID); err != nil {
+		return nil, err
+	}
+
+	invoices, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return m.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{Page: page, UserID: contract.UserID})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invoices: %w", err)
+	}
+
+	var open []models.Invoice
+	for _, invoice := range invoices {
+		if invoice.ContractID == nil || *invoice.ContractID != contractID || invoice.Status == models.InvoiceStatusVoid {
+			continue
+		}
+		if invoice.PaidAmount > 0 {
+			return nil, fmt.Errorf("invoice %s has payments; refund them before cancelling", invoice.Code)
+		}
+		open = append(open, invoice)
+	}
+	for _, invoice := range open {
+		if _, err := m.api.Invoice().VoidInvoice(ctx, invoice.ID, "Hủy hợp đồng: "+reason); err != nil {
+			return nil, fmt.Errorf("failed to void invoice %s: %w", invoice.Code, err)
+		}
+	}
+
+	return m.api.Contract().CancelContract(ctx, contractID, reason)
+}
+
+func (m *Manager) active(ctx context.Context, contractID int) (*models.Contract, error) {
+	resp, err := m.api.Contract().GetContractDetails(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data.Status != models.ContractStatusActive {
+		return nil, fmt.Errorf("%s: %w", resp.Data.Code, ErrNotActive)
+	}
+	return &resp.Data, nil
+}
+
+// check runs Check against the student's other contracts
+func (m *Manager) check(ctx context.Context, contract models.Contract) error {
+	if err := Check(contract, nil); err != nil || contract.Status != models.ContractStatusActive {
+		return err
+	}
+
+	user, err := m.api.User().GetUserDetails(ctx, strconv.Itoa(contract.UserID))
+	if err != nil {
+		return fmt.Errorf("failed to load student %d: %w", contract.UserID, err)
+	}
+	others, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return m.api.Contract().GetListContracts(ctx, page, &user.Data.StudentCode)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contracts of %s: %w", user.Data.FullName, err)
+	}
+
+	return Check(contract, others)
+}
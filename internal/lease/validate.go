@@ -0,0 +1,107 @@
+package lease
+
+import (
+	"changeme/internal/billing"
+	"changeme/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidPeriod is returned when a contract does not start before it ends
+	ErrInvalidPeriod = errors.New("the start date must be before the end date")
+	// ErrOverlap is returned when a student would hold two active contracts at once
+	ErrOverlap = errors.New("the student already has an active contract for these dates")
+	// ErrNotActive is returned when renewing, terminating or cancelling a contract that is not active
+	ErrNotActive = errors.New("the contract is not active")
+	// ErrStarted is returned when cancelling a contract that has taken effect
+	ErrStarted = errors.New("the contract has already started; terminate it instead")
+	// ErrStatusChange is returned when an update tries to change the status directly
+	ErrStatusChange = errors.New("change the status by renewing, terminating or cancelling the contract")
+)
+
+// Check validates a contract's period and that it does not overlap another
+// active contract of the same student. others may include c itself.
+func Check(c models.Contract, others []models.Contract) error {
+	start, end := billing.Date(c.StartDate), billing.Date(c.EndDate)
+	if !start.Before(end) {
+		return fmt.Errorf("%s – %s: %w", formatDate(start), formatDate(end), ErrInvalidPeriod)
+	}
+	if c.Status != models.ContractStatusActive {
+		return nil
+	}
+
+	for _, other := range others {
+		if other.ID == c.ID || other.UserID != c.UserID || other.Status != models.ContractStatusActive {
+			continue
+		}
+		if !start.After(billing.Date(other.EndDate)) && !billing.Date(other.StartDate).After(end) {
+			return fmt.Errorf("%s (%s – %s): %w", other.Code, formatDate(other.StartDate), formatDate(other.EndDate), ErrOverlap)
+		}
+	}
+	return nil
+}
+
+// apply copies the fields of a create or update payload that Check looks at
+// onto c. Dates may be YYYY-MM-DD or the ISO timestamps JavaScript sends.
+func apply(c *models.Contract, data map[string]interface{}) error {
+	for key, value := range data {
+		var err error
+		switch key {
+		case "user_id":
+			c.UserID, err = toInt(value)
+		case "room_id":
+			c.RoomID, err = toInt(value)
+		case "start_date":
+			c.StartDate, err = ParseDate(value)
+		case "end_date":
+			c.EndDate, err = ParseDate(value)
+		case "status":
+			status, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("expected a string, got %T", value)
+			}
+			c.Status = models.ContractStatus(status)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ParseDate reads a calendar date from a binding argument or payload value
+func ParseDate(value interface{}) (time.Time, error) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected a date string, got %T", value)
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+
+	// A date picker's local midnight arrives as the previous evening in UTC
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date", s)
+	}
+	return billing.Date(t.Local()), nil
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	case string:
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("expected a number, got %T", value)
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("02/01/2006")
+}
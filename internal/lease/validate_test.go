@@ -0,0 +1,49 @@
+package lease
+
+import (
+	"changeme/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	contract := func(id, userID int, from, to string, status models.ContractStatus) models.Contract {
+		return models.Contract{ID: id, UserID: userID, StartDate: date(from), EndDate: date(to), Status: status}
+	}
+	current := contract(1, 5, "2025-01-01", "2025-06-30", models.ContractStatusActive)
+
+	tests := []struct {
+		name     string
+		contract models.Contract
+		others   []models.Contract
+		want     error
+	}{
+		{"ends before it starts", contract(0, 5, "2025-09-01", "2025-08-31", models.ContractStatusActive), nil, ErrInvalidPeriod},
+		{"ends the day it starts", contract(0, 5, "2025-09-01", "2025-09-01", models.ContractStatusActive), nil, ErrInvalidPeriod},
+		{"no other contracts", contract(0, 5, "2025-09-01", "2026-06-30", models.ContractStatusActive), nil, nil},
+		{"overlaps", contract(0, 5, "2025-06-01", "2025-12-31", models.ContractStatusActive), []models.Contract{current}, ErrOverlap},
+		{"inside another", contract(0, 5, "2025-02-01", "2025-03-31", models.ContractStatusActive), []models.Contract{current}, ErrOverlap},
+		{"starts on the last day of another", contract(0, 5, "2025-06-30", "2025-12-31", models.ContractStatusActive), []models.Contract{current}, ErrOverlap},
+		{"starts the day after another", contract(0, 5, "2025-07-01", "2025-12-31", models.ContractStatusActive), []models.Contract{current}, nil},
+		{"ends the day before another", contract(0, 5, "2024-07-01", "2024-12-31", models.ContractStatusActive), []models.Contract{current}, nil},
+		{"itself", current, []models.Contract{current}, nil},
+		{"another student", contract(0, 6, "2025-02-01", "2025-12-31", models.ContractStatusActive), []models.Contract{current}, nil},
+		{"inactive contracts do not count", contract(0, 5, "2025-02-01", "2025-12-31", models.ContractStatusActive),
+			[]models.Contract{contract(1, 5, "2025-01-01", "2025-06-30", models.ContractStatusInactive)}, nil},
+		{"inactive contracts are not checked", contract(0, 5, "2025-02-01", "2025-12-31", models.ContractStatusCancelled), []models.Contract{current}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.contract, tt.others)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Check() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"changeme/internal/config"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// New builds a logger from the logging config block. The returned closer
+// flushes and closes the log file when output is "file".
+func New(cfg config.LoggerConfig) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		out    io.Writer
+		closer io.Closer = nopCloser{}
+	)
+	switch strings.ToLower(cfg.Output) {
+	case "", "stdout":
+		out = os.Stdout
+	case "stderr":
+		out = os.Stderr
+	case "file":
+		path, err := filePath(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		rotator := &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    cfg.MaxSize,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAge,
+			Compress:   cfg.Compress,
+		}
+		out, closer = rotator, rotator
+	default:
+		return nil, nil, fmt.Errorf("unknown log output %q", cfg.Output)
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(out, opts)
+	case "json":
+		handler = slog.NewJSONHandler(out, opts)
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	return slog.New(handler), closer, nil
+}
+
+// ParseLevel converts a config level name (debug, info, warn, error) to a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// filePath defaults the log file to the per-user application directory
+func filePath(file string) (string, error) {
+	if file == "" {
+		dir, err := config.UserDir()
+		if err != nil {
+			return "", err
+		}
+		file = filepath.Join(dir, "logs", "app.log")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create log dir: %w", err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces sensitive values in log output
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute, header, JSON and query keys whose values never
+// reach the log output. Every redaction path in the app reads this one set.
+// Plain "code" is not in it: contract, invoice and room codes are what a
+// request is traced by.
+var sensitiveKeys = map[string]bool{
+	"authorization":     true,
+	"password":          true,
+	"new_password":      true,
+	"access_token":      true,
+	"refresh_token":     true,
+	"token":             true,
+	"otp":               true,
+	"verification_code": true,
+	"auth_code":         true,
+}
+
+// Sensitive reports whether values under key must be redacted
+func Sensitive(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// RedactValue returns a copy of value with every sensitive key redacted,
+// descending into nested maps and slices.
+func RedactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, inner := range v {
+			if Sensitive(key) {
+				out[key] = Redacted
+				continue
+			}
+			out[key] = RedactValue(inner)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for key, inner := range v {
+			if Sensitive(key) {
+				inner = Redacted
+			}
+			out[key] = inner
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, inner := range v {
+			out[i] = RedactValue(inner)
+		}
+		return out
+	}
+	return value
+}
+
+// RedactQuery redacts the values of sensitive parameters in a raw URL query.
+// A query that cannot be parsed is dropped entirely rather than logged.
+func RedactQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	for key := range values {
+		if Sensitive(key) {
+			values[key] = []string{Redacted}
+		}
+	}
+	return values.Encode()
+}
+
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if Sensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	if attr.Value.Kind() == slog.KindAny {
+		return slog.Any(attr.Key, RedactValue(attr.Value.Any()))
+	}
+	return attr
+}
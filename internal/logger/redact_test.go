@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "credentials",
+			value: map[string]any{"email": "a@b.c", "Password": "secret", "otp": "123456"},
+			want:  map[string]any{"email": "a@b.c", "Password": Redacted, "otp": Redacted},
+		},
+		{
+			name:  "identifiers are kept",
+			value: map[string]any{"code": "HD-2025/001", "contract_code": "C001"},
+			want:  map[string]any{"code": "HD-2025/001", "contract_code": "C001"},
+		},
+		{
+			name:  "nested",
+			value: map[string]any{"data": []any{map[string]any{"access_token": "x", "id": 1.0}}},
+			want:  map[string]any{"data": []any{map[string]any{"access_token": Redacted, "id": 1.0}}},
+		},
+		{
+			name:  "headers",
+			value: map[string]string{"Authorization": "Bearer x", "Accept": "application/json"},
+			want:  map[string]string{"Authorization": Redacted, "Accept": "application/json"},
+		},
+		{"scalar", "token", "token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactValue(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RedactValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	original := map[string]any{"password": "secret"}
+	RedactValue(original)
+	if original["password"] != "secret" {
+		t.Error("RedactValue() changed its argument")
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"page=1&code=HD1", "code=HD1&page=1"},
+		{"token=abc&email=a%40b.c", "email=a%40b.c&token=%5BREDACTED%5D"},
+		{"verification_code=123", "verification_code=%5BREDACTED%5D"},
+		{"bad=%zz", Redacted},
+	}
+
+	for _, tt := range tests {
+		if got := RedactQuery(tt.query); got != tt.want {
+			t.Errorf("RedactQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package metering
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// RoomCharge is one utility bill of a room for a period
+type RoomCharge struct {
+	RoomID     int                `json:"room_id"`
+	RoomNumber string             `json:"room_number"`
+	Utility    models.UtilityType `json:"utility"`
+	Usage      Usage              `json:"usage"`
+	Tiers      []TierCharge       `json:"tiers"`
+	Amount     float64            `json:"amount"`
+	Shares     []Share            `json:"shares"`
+}
+
+// Statement is the utility billing of every metered room for a period
+type Statement struct {
+	Period    string       `json:"billing_period"`
+	Charges   []RoomCharge `json:"charges"`
+	Anomalies int          `json:"anomalies"`
+	Total     float64      `json:"total"`
+}
+
+// Failure is a share the server refused to invoice
+type Failure struct {
+	RoomNumber string `json:"room_number"`
+	Share      Share  `json:"share"`
+	Error      string `json:"error"`
+}
+
+// Result reports the invoices Bill created. Charges with anomalies other
+// than a rollover, including rooms nobody occupied, are left for review and
+// not invoiced.
+type Result struct {
+	Statement *Statement       `json:"statement"`
+	Created   []models.Invoice `json:"created"`
+	Skipped   int              `json:"skipped"`
+	Failed    []Failure        `json:"failed"`
+}
+
+// Engine bills meter readings using the room and contract data from the API
+type Engine struct {
+	api *api.API
+	cfg config.MeteringConfig
+}
+
+func NewEngine(api *api.API, cfg config.MeteringConfig) *Engine {
+	return &Engine{
+		api: api,
+		cfg: cfg,
+	}
+}
+
+func (e *Engine) tariff(utility models.UtilityType) config.TariffConfig {
+	if utility == models.UtilityWater {
+		return e.cfg.Water
+	}
+	return e.cfg.Electricity
+}
+
+func (e *Engine) readings(ctx context.Context, p billing.Period) ([]models.MeterReading, error) {
+	return api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.MeterReading], error) {
+		return e.api.MeterReading().GetListMeterReadings(ctx, page, nil, p.String())
+	})
+}
+
+// Preview computes the charges of a period from its readings and the previous period's
+func (e *Engine) Preview(ctx context.Context, p billing.Period) (*Statement, error) {
+	current, err := e.readings(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meter readings: %w", err)
+	}
+	previous, err := e.readings(ctx, p.Prev())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list previous meter readings: %w", err)
+	}
+
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return e.api.Contract().GetListContracts(ctx, page, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	type meter struct {
+		roomID  int
+		utility models.UtilityType
+	}
+	// A meter read twice in a period is billed once, from its latest reading
+	latest := func(readings []models.MeterReading) (map[meter]*models.MeterReading, []meter) {
+		byMeter := make(map[meter]*models.MeterReading)
+		var order []meter
+		for i := range readings {
+			reading := &readings[i]
+			key := meter{reading.RoomID, reading.Utility}
+			prev, ok := byMeter[key]
+			if !ok {
+				order = append(order, key)
+			}
+			if !ok || reading.ReadAt.After(prev.ReadAt) {
+				byMeter[key] = reading
+			}
+		}
+		return byMeter, order
+	}
+	last, _ := latest(previous)
+	readings, meters := latest(current)
+
+	rooms := make(map[int]*models.Room)
+	statement := &Statement{Period: p.String(), Charges: []RoomCharge{}}
+	for _, key := range meters {
+		reading := readings[key]
+
+		room, ok := rooms[reading.RoomID]
+		if !ok {
+			resp, err := e.api.Room().GetRoomDetails(ctx, reading.RoomID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get room %d: %w", reading.RoomID, err)
+			}
+			room = &resp.Data
+			rooms[reading.RoomID] = room
+		}
+
+		tariff := e.tariff(reading.Utility)
+		charge := RoomCharge{
+			RoomID:     room.ID,
+			RoomNumber: room.RoomNumber,
+			Utility:    reading.Utility,
+			Usage:      Consume(last[meter{reading.RoomID, reading.Utility}], reading, tariff.MeterMax),
+			Tiers:      []TierCharge{},
+			Shares:     []Share{},
+		}
+		if charge.Usage.Billable() {
+			charge.Amount, charge.Tiers = Cost(tariff, charge.Usage.Units)
+			charge.Shares = Split(charge.Amount, p, *room, contracts)
+			if len(charge.Shares) == 0 && charge.Amount > 0 {
+				// Nobody can be invoiced, so the charge is left for review
+				charge.Usage.Anomaly = AnomalyUnoccupied
+			} else {
+				statement.Total += charge.Amount
+			}
+		}
+		if charge.Usage.Anomaly != AnomalyNone {
+			statement.Anomalies++
+		}
+		statement.Charges = append(statement.Charges, charge)
+	}
+
+	return statement, nil
+}
+
+// Bill creates a service invoice for every occupant's share of the period's
+// billable charges. Shares that were already invoiced are not created again.
+func (e *Engine) Bill(ctx context.Context, p billing.Period) (*Result, error) {
+	statement, err := e.Preview(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+		return e.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{
+			Page:   page,
+			Type:   string(models.InvoiceTypeService),
+			Period: p.String(),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	codes := make(map[string]bool)
+	for _, invoice := range existing {
+		if invoice.Status != models.InvoiceStatusVoid {
+			codes[invoice.Code] = true
+		}
+	}
+
+	result := &Result{Statement: statement, Created: []models.Invoice{}, Failed: []Failure{}}
+	for _, charge := range statement.Charges {
+		if !charge.Usage.Billable() {
+			result.Skipped++
+			continue
+		}
+
+		for _, share := range charge.Shares {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			code := InvoiceCode(charge.Utility, charge.RoomNumber, share.UserID, p)
+			if codes[code] || share.Amount <= 0 {
+				continue
+			}
+			codes[code] = true
+
+			resp, err := e.api.Invoice().CreateInvoice(ctx, map[string]interface{}{
+				"code":           code,
+				"user_id":        share.UserID,
+				"room_id":        charge.RoomID,
+				"type":           models.InvoiceTypeService,
+				"description":    Description(charge.Utility, charge.RoomNumber, p),
+				"amount":         share.Amount,
+				"billing_period": p.String(),
+				"issue_date":     p.Next().Start().Format(time.DateOnly),
+				"due_date":       p.Next().Start().AddDate(0, 0, billing.DefaultDueDays).Format(time.DateOnly),
+			})
+			if err != nil {
+				result.Failed = append(result.Failed, Failure{RoomNumber: charge.RoomNumber, Share: share, Error: err.Error()})
+				continue
+			}
+			result.Created = append(result.Created, resp.Data)
+		}
+	}
+
+	return result, nil
+}
+
+// InvoiceCode returns the invoice number of one occupant's utility share
+func InvoiceCode(utility models.UtilityType, roomNumber string, userID int, p billing.Period) string {
+	prefix := "ELE"
+	if utility == models.UtilityWater {
+		prefix = "WAT"
+	}
+	return fmt.Sprintf("%s-%s-%d-%04d%02d", prefix, roomNumber, userID, p.Year, int(p.Month))
+}
+
+// Description returns the invoice description shown to the student
+func Description(utility models.UtilityType, roomNumber string, p billing.Period) string {
+	name := "Tiền điện"
+	if utility == models.UtilityWater {
+		name = "Tiền nước"
+	}
+	return fmt.Sprintf("%s tháng %d/%d - phòng %s", name, int(p.Month), p.Year, roomNumber)
+}
@@ -0,0 +1,104 @@
+package metering
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/client"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// serve answers the list and detail endpoints Preview reads from fixed data
+func serve(t *testing.T, readings map[string][]models.MeterReading, rooms map[string]models.Room) *api.API {
+	t.Helper()
+
+	respond := func(w http.ResponseWriter, data any, total int) {
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data, "total": total})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/meter-readings", func(w http.ResponseWriter, r *http.Request) {
+		list := readings[r.URL.Query().Get("billing_period")]
+		if r.URL.Query().Get("page") != "1" {
+			list = nil
+		}
+		respond(w, list, len(list))
+	})
+	mux.HandleFunc("/contracts", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, []models.Contract{}, 0)
+	})
+	mux.HandleFunc("/rooms/{id}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, rooms[r.PathValue("id")], 0)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return api.NewAPI(client.New().SetBaseURL(server.URL))
+}
+
+func TestPreview(t *testing.T) {
+	at := func(s string) time.Time {
+		d, _ := time.Parse(time.DateTime, s)
+		return d
+	}
+	reading := func(id, roomID int, value float64, readAt string) models.MeterReading {
+		return models.MeterReading{ID: id, RoomID: roomID, Utility: models.UtilityElectricity, Reading: value, ReadAt: at(readAt)}
+	}
+	cfg := config.MeteringConfig{Electricity: config.TariffConfig{Tiers: []config.TariffTier{{Price: 1000}}}}
+
+	a := serve(t,
+		map[string][]models.MeterReading{
+			"2025-08": {
+				reading(1, 101, 100, "2025-08-31 08:00:00"),
+				reading(2, 102, 500, "2025-08-31 08:00:00"),
+			},
+			"2025-09": {
+				// Read twice; only the later reading counts
+				reading(3, 101, 150, "2025-09-30 08:00:00"),
+				reading(4, 101, 160, "2025-09-30 17:00:00"),
+				reading(5, 102, 520, "2025-09-30 08:00:00"),
+			},
+		},
+		map[string]models.Room{
+			"101": {ID: 101, RoomNumber: "A101", Users: []models.User{{ID: 1}, {ID: 2}}},
+			"102": {ID: 102, RoomNumber: "A102"},
+		},
+	)
+
+	statement, err := NewEngine(a, cfg).Preview(context.Background(), billing.Period{Year: 2025, Month: time.September})
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+
+	if len(statement.Charges) != 2 {
+		t.Fatalf("Preview() returned %d charges, want one per meter", len(statement.Charges))
+	}
+	tests := []struct {
+		room        string
+		wantUnits   float64
+		wantAmount  float64
+		wantShares  int
+		wantAnomaly Anomaly
+	}{
+		{"A101", 60, 60_000, 2, AnomalyNone},
+		{"A102", 20, 20_000, 0, AnomalyUnoccupied},
+	}
+	for i, tt := range tests {
+		charge := statement.Charges[i]
+		if charge.RoomNumber != tt.room || charge.Usage.Units != tt.wantUnits || charge.Amount != tt.wantAmount ||
+			len(charge.Shares) != tt.wantShares || charge.Usage.Anomaly != tt.wantAnomaly {
+			t.Errorf("charge %d = %s %v units %v dong, %d shares, anomaly %q; want %s %v units %v dong, %d shares, anomaly %q",
+				i, charge.RoomNumber, charge.Usage.Units, charge.Amount, len(charge.Shares), charge.Usage.Anomaly,
+				tt.room, tt.wantUnits, tt.wantAmount, tt.wantShares, tt.wantAnomaly)
+		}
+	}
+	if statement.Total != 60_000 || statement.Anomalies != 1 {
+		t.Errorf("Preview() total %v with %d anomalies, want 60000 with 1", statement.Total, statement.Anomalies)
+	}
+}
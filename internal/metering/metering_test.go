@@ -0,0 +1,183 @@
+package metering
+
+import (
+	"changeme/internal/billing"
+	"changeme/internal/config"
+	"changeme/internal/models"
+	"testing"
+	"time"
+)
+
+func TestConsume(t *testing.T) {
+	reading := func(value float64) *models.MeterReading {
+		return &models.MeterReading{Reading: value}
+	}
+
+	tests := []struct {
+		name        string
+		prev, cur   *models.MeterReading
+		meterMax    float64
+		wantUnits   float64
+		wantAnomaly Anomaly
+	}{
+		{"normal", reading(1200), reading(1350), 99999, 150, AnomalyNone},
+		{"no consumption", reading(1200), reading(1200), 99999, 0, AnomalyNone},
+		{"new meter", nil, reading(15), 99999, 0, AnomalyMissingPrevious},
+		{"rollover", reading(99950), reading(30), 99999, 80, AnomalyRollover},
+		{"decrease far from the maximum", reading(5000), reading(4900), 99999, 0, AnomalyDecreasing},
+		{"decrease without a maximum", reading(99950), reading(30), 0, 0, AnomalyDecreasing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := Consume(tt.prev, tt.cur, tt.meterMax)
+			if usage.Units != tt.wantUnits || usage.Anomaly != tt.wantAnomaly {
+				t.Errorf("Consume() = %v units, anomaly %q; want %v, %q", usage.Units, usage.Anomaly, tt.wantUnits, tt.wantAnomaly)
+			}
+		})
+	}
+}
+
+func TestCost(t *testing.T) {
+	tiered := config.TariffConfig{Tiers: []config.TariffTier{
+		{UpTo: 50, Price: 1806},
+		{UpTo: 100, Price: 1866},
+		{UpTo: 200, Price: 2167},
+		{UpTo: 0, Price: 2729},
+	}}
+	bounded := config.TariffConfig{Tiers: []config.TariffTier{
+		{UpTo: 10, Price: 6000},
+		{UpTo: 20, Price: 7000},
+	}}
+
+	tests := []struct {
+		name      string
+		tariff    config.TariffConfig
+		units     float64
+		wantTotal float64
+		wantTiers int
+	}{
+		{"nothing used", tiered, 0, 0, 0},
+		{"first tier only", tiered, 30, 54180, 1},
+		{"first tier exactly", tiered, 50, 90300, 1},
+		{"spans two tiers", tiered, 80, 90300 + 55980, 2},
+		{"unbounded last tier", tiered, 250, 90300 + 93300 + 216700 + 136450, 4},
+		{"beyond a bounded last tier", bounded, 25, 60000 + 105000, 2},
+		{"no tiers", config.TariffConfig{}, 100, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, tiers := Cost(tt.tariff, tt.units)
+			if total != tt.wantTotal || len(tiers) != tt.wantTiers {
+				t.Errorf("Cost() = %v over %d tiers; want %v over %d", total, len(tiers), tt.wantTotal, tt.wantTiers)
+			}
+
+			units := 0.0
+			for _, tier := range tiers {
+				units += tier.Units
+			}
+			if len(tt.tariff.Tiers) > 0 && units != tt.units {
+				t.Errorf("tiers cover %v units, want %v", units, tt.units)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	september := billing.Period{Year: 2025, Month: time.September}
+	room := models.Room{ID: 7, Users: []models.User{{ID: 1}, {ID: 2}, {ID: 3}}}
+	contract := func(userID int, from, to string, status models.ContractStatus) models.Contract {
+		return models.Contract{UserID: userID, RoomID: 7, StartDate: date(from), EndDate: date(to), Status: status}
+	}
+
+	tests := []struct {
+		name      string
+		amount    float64
+		room      models.Room
+		contracts []models.Contract
+		want      map[int]float64
+	}{
+		{
+			name:   "even split of the whole month",
+			amount: 300_000,
+			room:   room,
+			contracts: []models.Contract{
+				contract(1, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(2, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(3, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+			},
+			want: map[int]float64{1: 100_000, 2: 100_000, 3: 100_000},
+		},
+		{
+			name:   "by days of occupancy",
+			amount: 450_000,
+			room:   models.Room{ID: 7, Users: []models.User{{ID: 1}, {ID: 2}}},
+			contracts: []models.Contract{
+				contract(1, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(2, "2025-09-16", "2026-06-30", models.ContractStatusActive),
+			},
+			want: map[int]float64{1: 300_000, 2: 150_000},
+		},
+		{
+			name:   "last share absorbs rounding",
+			amount: 100_000,
+			room:   room,
+			contracts: []models.Contract{
+				contract(1, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(2, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(3, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+			},
+			want: map[int]float64{1: 33_333, 2: 33_333, 3: 33_334},
+		},
+		{
+			name:   "cancelled contracts do not count",
+			amount: 300_000,
+			room:   models.Room{ID: 7, Users: []models.User{{ID: 1}, {ID: 2}}},
+			contracts: []models.Contract{
+				contract(1, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+				contract(2, "2025-01-01", "2025-12-31", models.ContractStatusCancelled),
+				contract(2, "2025-10-01", "2026-06-30", models.ContractStatusActive),
+			},
+			want: map[int]float64{1: 300_000},
+		},
+		{
+			name:   "listed without a contract counts the whole month",
+			amount: 200_000,
+			room:   models.Room{ID: 7, Users: []models.User{{ID: 1}, {ID: 2}}},
+			contracts: []models.Contract{
+				contract(1, "2025-01-01", "2025-12-31", models.ContractStatusActive),
+			},
+			want: map[int]float64{1: 100_000, 2: 100_000},
+		},
+		{
+			name:   "empty room",
+			amount: 200_000,
+			room:   models.Room{ID: 7},
+			want:   map[int]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares := Split(tt.amount, september, tt.room, tt.contracts)
+			got := make(map[int]float64, len(shares))
+			for _, share := range shares {
+				got[share.UserID] = share.Amount
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Split() = %v, want %v", got, tt.want)
+			}
+			for userID, amount := range tt.want {
+				if got[userID] != amount {
+					t.Errorf("Split() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package metering
+
+import (
+	"changeme/internal/billing"
+	"changeme/internal/models"
+)
+
+// Share is one occupant's part of a room's charge
+type Share struct {
+	UserID   int     `json:"user_id"`
+	FullName string  `json:"full_name"`
+	Days     int     `json:"days"`
+	Amount   float64 `json:"amount"`
+}
+
+// OccupancyDays returns how many days of the period a user lived in a room,
+// from their non-cancelled contracts for it. A user listed in the room
+// without such a contract is counted for the whole period.
+func OccupancyDays(p billing.Period, roomID, userID int, contracts []models.Contract) int {
+	found := false
+	days := 0
+	for _, contract := range contracts {
+		if contract.UserID != userID || contract.RoomID != roomID || contract.Status == models.ContractStatusCancelled {
+			continue
+		}
+		found = true
+		_, _, overlap := p.Overlap(contract.StartDate, contract.EndDate)
+		days += overlap
+	}
+	if !found {
+		return p.Days()
+	}
+	return min(days, p.Days())
+}
+
+// Split divides amount among the room's users by days of occupancy. Shares
+// are rounded to whole dong and the last share absorbs the rounding.
+func Split(amount float64, p billing.Period, room models.Room, contracts []models.Contract) []Share {
+	shares := []Share{}
+	totalDays := 0
+	for _, user := range room.Users {
+		days := OccupancyDays(p, room.ID, user.ID, contracts)
+		if days == 0 {
+			continue
+		}
+		shares = append(shares, Share{UserID: user.ID, FullName: user.FullName, Days: days})
+		totalDays += days
+	}
+	if totalDays == 0 {
+		return shares
+	}
+
+	allocated := 0.0
+	for i := range shares {
+		if i == len(shares)-1 {
+			shares[i].Amount = amount - allocated
+			break
+		}
+		shares[i].Amount = billing.Round(amount * float64(shares[i].Days) / float64(totalDays))
+		allocated += shares[i].Amount
+	}
+	return shares
+}
@@ -0,0 +1,45 @@
+package metering
+
+import (
+	"changeme/internal/billing"
+	"changeme/internal/config"
+)
+
+// TierCharge is the part of a bill priced at one tier
+type TierCharge struct {
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+	Units  float64 `json:"units"`
+	Price  float64 `json:"price"`
+	Amount float64 `json:"amount"`
+}
+
+// Cost prices consumed units against the tariff's tiers. Units beyond a
+// bounded last tier are charged at its price.
+func Cost(tariff config.TariffConfig, units float64) (float64, []TierCharge) {
+	charges := []TierCharge{}
+	total := 0.0
+	from := 0.0
+	for i, tier := range tariff.Tiers {
+		if units <= from {
+			break
+		}
+
+		to := tier.UpTo
+		if to == 0 || to > units || i == len(tariff.Tiers)-1 {
+			to = units
+		}
+
+		charge := TierCharge{
+			From:  from,
+			To:    to,
+			Units: to - from,
+			Price: tier.Price,
+		}
+		charge.Amount = billing.Round(charge.Units * charge.Price)
+		charges = append(charges, charge)
+		total += charge.Amount
+		from = to
+	}
+	return total, charges
+}
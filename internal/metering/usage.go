@@ -0,0 +1,55 @@
+package metering
+
+import "changeme/internal/models"
+
+// Anomaly flags a reading that needs a human look before it is billed
+type Anomaly string
+
+const (
+	AnomalyNone Anomaly = ""
+	// AnomalyRollover means the meter wrapped past its maximum; consumption is still computed
+	AnomalyRollover Anomaly = "rollover"
+	// AnomalyDecreasing means the reading went down without a plausible rollover
+	AnomalyDecreasing Anomaly = "decreasing"
+	// AnomalyMissingPrevious means there is no reading for the previous period to compare with
+	AnomalyMissingPrevious Anomaly = "missing_previous"
+	// AnomalyUnoccupied means the room has a charge but nobody lived in it during the period
+	AnomalyUnoccupied Anomaly = "unoccupied"
+)
+
+// rolloverBand is the share of the meter range near its ends where a
+// decreasing reading is taken for a wrap rather than an error
+const rolloverBand = 0.1
+
+// Usage is the consumption between two readings of the same meter
+type Usage struct {
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+	Units    float64 `json:"units"`
+	Anomaly  Anomaly `json:"anomaly"`
+}
+
+// Billable reports whether the usage can be billed without review
+func (u Usage) Billable() bool {
+	return u.Anomaly == AnomalyNone || u.Anomaly == AnomalyRollover
+}
+
+// Consume computes the consumption from prev to cur. prev may be nil for a
+// new meter; meterMax of zero disables rollover detection.
+func Consume(prev, cur *models.MeterReading, meterMax float64) Usage {
+	if prev == nil {
+		return Usage{Current: cur.Reading, Anomaly: AnomalyMissingPrevious}
+	}
+
+	usage := Usage{Previous: prev.Reading, Current: cur.Reading}
+	switch {
+	case cur.Reading >= prev.Reading:
+		usage.Units = cur.Reading - prev.Reading
+	case meterMax > 0 && prev.Reading >= meterMax*(1-rolloverBand) && cur.Reading <= meterMax*rolloverBand:
+		usage.Units = meterMax - prev.Reading + 1 + cur.Reading
+		usage.Anomaly = AnomalyRollover
+	default:
+		usage.Anomaly = AnomalyDecreasing
+	}
+	return usage
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Amenity struct {
+	ID        int       `json:"id" api:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name" api:"required"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type Login struct {
+	AccessToken  string `json:"access_token" api:"required"`
+	RefreshToken string `json:"refresh_token"`
+	User         User   `json:"user" api:"required"`
+	IsVerified   bool   `json:"is_verified"`
+}
+
+type AuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type ContractStatus string
+
+const (
+	ContractStatusActive    ContractStatus = "active"
+	ContractStatusInactive  ContractStatus = "inactive"
+	ContractStatusCancelled ContractStatus = "cancelled"
+)
+
+type Contract struct {
+	ID          int            `json:"id" api:"required"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	Code        string         `json:"code" api:"required"`
+	UserID      int            `json:"user_id" api:"required"`
+	User        User           `json:"user"`
+	RoomID      int            `json:"room_id" api:"required"`
+	Room        Room           `json:"room"`
+	StartDate   time.Time      `json:"start_date" api:"required"`
+	EndDate     time.Time      `json:"end_date" api:"required"`
+	Price       float64        `json:"price" api:"required"`
+	Status      ContractStatus `json:"status" api:"required"`
+	Description string         `json:"description"`
+}
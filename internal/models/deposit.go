@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type (
+	DepositStatus    string
+	DepositEntryType string
+)
+
+const (
+	DepositStatusHeld    DepositStatus = "held"
+	DepositStatusSettled DepositStatus = "settled"
+)
+
+const (
+	DepositEntryReceived  DepositEntryType = "received"
+	DepositEntryDeduction DepositEntryType = "deduction"
+	DepositEntryRefund    DepositEntryType = "refund"
+)
+
+type Deposit struct {
+	ID         int            `json:"id" api:"required"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	ContractID int            `json:"contract_id" api:"required"`
+	Status     DepositStatus  `json:"status" api:"required"`
+	SettledAt  *time.Time     `json:"settled_at"`
+	Entries    []DepositEntry `json:"entries"`
+}
+
+// DepositEntry is one movement on a deposit. Deductions for damage point at
+// the maintenance history entry that recorded the repair.
+type DepositEntry struct {
+	ID                   int                 `json:"id" api:"required"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	DepositID            int                 `json:"deposit_id"`
+	Type                 DepositEntryType    `json:"type" api:"required"`
+	Amount               float64             `json:"amount" api:"required"`
+	Date                 time.Time           `json:"date"`
+	Description          string              `json:"description"`
+	MaintenanceHistoryID *int                `json:"maintenance_history_id"`
+	MaintenanceHistory   *MaintenanceHistory `json:"maintenance_history"`
+}
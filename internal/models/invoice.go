@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+type (
+	InvoiceStatus string
+	InvoiceType   string
+)
+
+const (
+	InvoiceStatusPending       InvoiceStatus = "pending"
+	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid"
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+	InvoiceStatusOverdue       InvoiceStatus = "overdue"
+	InvoiceStatusVoid          InvoiceStatus = "void"
+)
+
+const (
+	InvoiceTypeRoomFee InvoiceType = "room_fee"
+	InvoiceTypeService InvoiceType = "service"
+	InvoiceTypePenalty InvoiceType = "penalty"
+	InvoiceTypeOther   InvoiceType = "other"
+)
+
+type Invoice struct {
+	ID          int         `json:"id" api:"required"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Code        string      `json:"code" api:"required"`
+	UserID      int         `json:"user_id" api:"required"`
+	User        User        `json:"user"`
+	RoomID      *int        `json:"room_id"`
+	Room        *Room       `json:"room"`
+	ContractID  *int        `json:"contract_id"`
+	Type        InvoiceType `json:"type" api:"required"`
+	Description string      `json:"description"`
+	// BillingPeriod is the month a recurring invoice covers, as YYYY-MM
+	BillingPeriod string        `json:"billing_period"`
+	Amount        float64       `json:"amount" api:"required"`
+	PaidAmount    float64       `json:"paid_amount" api:"required"`
+	Status        InvoiceStatus `json:"status" api:"required"`
+	IssueDate     time.Time     `json:"issue_date"`
+	DueDate       time.Time     `json:"due_date" api:"required"`
+	PaidAt        *time.Time    `json:"paid_at"`
+	VoidedAt      *time.Time    `json:"voided_at"`
+	VoidReason    string        `json:"void_reason"`
+	Payments      []Payment     `json:"payments"`
+}
+
+// InvoiceReminder is a notice the server sent to a student about an overdue invoice
+type InvoiceReminder struct {
+	ID        int       `json:"id" api:"required"`
+	InvoiceID int       `json:"invoice_id"`
+	Level     string    `json:"level" api:"required"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// Outstanding returns the amount still to be paid
+func (i *Invoice) Outstanding() float64 {
+	return max(i.Amount-i.PaidAmount, 0)
+}
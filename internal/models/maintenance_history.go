@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type MaintenanceHistory struct {
+	ID              int       `json:"id" api:"required"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	RoomID          int       `json:"room_id" api:"required"`
+	MaintenanceDate time.Time `json:"maintenance_date" api:"required"`
+	Description     string    `json:"description"`
+	Cost            float64   `json:"cost" api:"required"`
+}
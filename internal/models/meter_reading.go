@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type UtilityType string
+
+const (
+	UtilityElectricity UtilityType = "electricity"
+	UtilityWater       UtilityType = "water"
+)
+
+type MeterReading struct {
+	ID            int         `json:"id" api:"required"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	RoomID        int         `json:"room_id" api:"required"`
+	Utility       UtilityType `json:"utility" api:"required"`
+	BillingPeriod string      `json:"billing_period" api:"required"`
+	Reading       float64     `json:"reading" api:"required"`
+	ReadAt        time.Time   `json:"read_at"`
+	Note          string      `json:"note"`
+}
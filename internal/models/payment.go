@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash         PaymentMethod = "cash"
+	PaymentMethodBankTransfer PaymentMethod = "bank_transfer"
+)
+
+type Payment struct {
+	ID        int           `json:"id" api:"required"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	InvoiceID int           `json:"invoice_id" api:"required"`
+	Invoice   *Invoice      `json:"invoice"`
+	UserID    int           `json:"user_id"`
+	Amount    float64       `json:"amount" api:"required"`
+	Method    PaymentMethod `json:"method"`
+	PaidAt    time.Time     `json:"paid_at" api:"required"`
+	Reference string        `json:"reference"`
+	Note      string        `json:"note"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type RoomStatus string
+
+const (
+	RoomStatusAvailable   RoomStatus = "available"
+	RoomStatusOccupied    RoomStatus = "occupied"
+	RoomStatusMaintenance RoomStatus = "maintenance"
+)
+
+type Room struct {
+	ID                   int                  `json:"id" api:"required"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+	RoomNumber           string               `json:"room_number" api:"required"`
+	Status               RoomStatus           `json:"status" api:"required"`
+	UserCount            int                  `json:"user_count" api:"required"`
+	RoomCategoryID       int                  `json:"room_category_id" api:"required"`
+	RoomCategory         RoomCategory         `json:"room_category"`
+	RoomAmenities        []RoomAmenity        `json:"room_amenities"`
+	Users                []User               `json:"users"`
+	MaintenanceHistories []MaintenanceHistory `json:"maintenance_histories"`
+}
+
+type RoomAmenity struct {
+	ID        int       `json:"id" api:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	RoomID    int       `json:"room_id" api:"required"`
+	AmenityID int       `json:"amenity_id" api:"required"`
+	Amenity   Amenity   `json:"amenity"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type RoomCategory struct {
+	ID          int       `json:"id" api:"required"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name" api:"required"`
+	Description string    `json:"description"`
+	Capacity    int       `json:"capacity" api:"required"`
+	Price       float64   `json:"price" api:"required"`
+	Acreage     float64   `json:"acreage"`
+}
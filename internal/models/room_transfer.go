@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// RoomTransfer records a student moving rooms. Both students of a swap get
+// a transfer that points at the other through SwapWithUserID.
+type RoomTransfer struct {
+	ID             int       `json:"id" api:"required"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UserID         int       `json:"user_id" api:"required"`
+	User           User      `json:"user"`
+	FromRoomID     int       `json:"from_room_id" api:"required"`
+	FromRoom       Room      `json:"from_room"`
+	ToRoomID       int       `json:"to_room_id" api:"required"`
+	ToRoom         Room      `json:"to_room"`
+	FromContractID int       `json:"from_contract_id"`
+	ToContractID   int       `json:"to_contract_id"`
+	SwapWithUserID *int      `json:"swap_with_user_id"`
+	MoveDate       time.Time `json:"move_date"`
+	Reason         string    `json:"reason"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+type (
+	UserRole          string
+	Gender            string
+	UserStatus        string
+	UserStatusAccount string
+)
+
+const (
+	UserRoleAdmin   UserRole = "admin"
+	UserRoleStudent UserRole = "student"
+	UserRoleStaff   UserRole = "staff"
+)
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+	GenderOther  Gender = "other"
+)
+
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusInactive UserStatus = "inactive"
+	UserStatusAbsent   UserStatus = "absent"
+)
+
+const (
+	UserStatusAccountPending  UserStatusAccount = "pending"
+	UserStatusAccountApproved UserStatusAccount = "approved"
+	UserStatusAccountRejected UserStatusAccount = "rejected"
+	UserStatusAccountBanned   UserStatusAccount = "banned"
+)
+
+type EmergencyContact struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	Relationship string `json:"relationship"`
+}
+
+type User struct {
+	ID               int               `json:"id" api:"required"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	FullName         string            `json:"full_name" api:"required"`
+	StudentCode      string            `json:"student_code"`
+	Email            string            `json:"email" api:"required"`
+	Role             UserRole          `json:"role" api:"required"`
+	Gender           Gender            `json:"gender"`
+	Status           UserStatus        `json:"status" api:"required"`
+	StatusAccount    UserStatusAccount `json:"status_account" api:"required"`
+	Phone            string            `json:"phone"`
+	IsVerify         bool              `json:"is_verify"`
+	Birthday         *time.Time        `json:"birthday"`
+	Avatar           *string           `json:"avatar"`
+	RoomID           *int              `json:"room_id"`
+	Room             *Room             `json:"room"`
+	Address          *string           `json:"address"`
+	Major            *string           `json:"major"`
+	EmergencyContact *EmergencyContact `json:"emergency_contact"`
+}
@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"changeme/internal/client"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Result describes the outcome of replaying one operation
+type Result struct {
+	Operation Operation `json:"operation"`
+	// Replayed is set when the server accepted the operation
+	Replayed bool `json:"replayed"`
+	// Conflict is set when the server rejected the operation, e.g. because the room became full
+	Conflict bool   `json:"conflict"`
+	Message  string `json:"message"`
+}
+
+// Replayer periodically sends queued operations in order once the server is reachable again
+type Replayer struct {
+	store    *Store
+	client   *client.Client
+	interval time.Duration
+	onResult func(Result)
+
+	mu      sync.Mutex
+	trigger chan struct{}
+	// settled holds operations the server has answered but whose outcome
+	// could not be written to the store, so that they are not sent again
+	settled map[string]settlement
+}
+
+type settlement struct {
+	op       Operation
+	replayed bool
+}
+
+func NewReplayer(store *Store, client *client.Client, interval time.Duration, onResult func(Result)) *Replayer {
+	return &Replayer{
+		store:    store,
+		client:   client,
+		interval: interval,
+		onResult: onResult,
+		trigger:  make(chan struct{}, 1),
+		settled:  make(map[string]settlement),
+	}
+}
+
+// Run replays the queue every interval until ctx is cancelled
+func (r *Replayer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.Flush(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.trigger:
+		case <-r.store.queued:
+		}
+	}
+}
+
+// Trigger asks Run to replay the queue now instead of waiting for the next tick
+func (r *Replayer) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Flush replays pending operations oldest first. It stops at the first
+// operation that cannot reach the server so that ordering is preserved;
+// operations the server rejects are marked failed and skipped from then on.
+func (r *Replayer) Flush(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.settled {
+		r.settle(s.op, s.replayed)
+	}
+
+	operations, err := r.store.List()
+	if err != nil {
+		return
+	}
+
+	for _, op := range operations {
+		if _, ok := r.settled[op.ID]; ok || op.Status != StatusPending {
+			continue
+		}
+
+		op.Attempts++
+		resp, err := r.client.Replay(ctx, &op.Request)
+		if err != nil {
+			op.LastError = err.Error()
+			r.store.Update(&op)
+			if errors.Is(err, client.ErrSessionExpired) {
+				r.report(Result{Operation: op, Message: op.LastError})
+			}
+			return
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusUnauthorized {
+			op.LastError = resp.Status
+			r.store.Update(&op)
+			return
+		}
+
+		if resp.IsError() {
+			op.Status = StatusFailed
+			op.LastError = message(resp)
+			r.settle(op, false)
+			r.report(Result{Operation: op, Conflict: true, Message: op.LastError})
+			continue
+		}
+
+		r.settle(op, true)
+		r.report(Result{Operation: op, Replayed: true, Message: message(resp)})
+	}
+}
+
+// settle removes a replayed operation from the queue or saves a rejected one
+// as failed. When the store cannot be written the outcome is kept in memory
+// and written on the next Flush instead of sending the operation again.
+func (r *Replayer) settle(op Operation, replayed bool) {
+	var err error
+	if replayed {
+		err = r.store.Delete(op.ID)
+	} else {
+		err = r.store.Update(&op)
+	}
+
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		r.settled[op.ID] = settlement{op: op, replayed: replayed}
+		return
+	}
+	delete(r.settled, op.ID)
+}
+
+func (r *Replayer) report(result Result) {
+	if r.onResult != nil {
+		r.onResult(result)
+	}
+}
+
+func message(resp *client.Response) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.RawBody(), &body); err == nil && body.Message != "" {
+		return body.Message
+	}
+	return resp.Status
+}
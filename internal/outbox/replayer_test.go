@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"changeme/internal/client"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlush(t *testing.T) {
+	tests := []struct {
+		name string
+		// answers is the status the server gives each queued operation, in queue order
+		answers      []int
+		wantSent     []string
+		wantResults  []string
+		wantRemain   map[string]Status
+		wantPending  bool
+		wantAttempts map[string]int
+	}{
+		{
+			name:        "all accepted in order",
+			answers:     []int{200, 201, 204},
+			wantSent:    []string{"/ops/1", "/ops/2", "/ops/3"},
+			wantResults: []string{"replayed 1", "replayed 2", "replayed 3"},
+			wantRemain:  map[string]Status{},
+		},
+		{
+			name:        "a conflict is kept as failed and skipped",
+			answers:     []int{200, 409, 200},
+			wantSent:    []string{"/ops/1", "/ops/2", "/ops/3"},
+			wantResults: []string{"replayed 1", "conflict 2", "replayed 3"},
+			wantRemain:  map[string]Status{"2": StatusFailed},
+		},
+		{
+			name:         "stops at the first server error to keep the order",
+			answers:      []int{200, 503, 200},
+			wantSent:     []string{"/ops/1", "/ops/2"},
+			wantResults:  []string{"replayed 1"},
+			wantRemain:   map[string]Status{"2": StatusPending, "3": StatusPending},
+			wantPending:  true,
+			wantAttempts: map[string]int{"2": 1, "3": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu   sync.Mutex
+				sent []string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				sent = append(sent, r.URL.Path)
+				mu.Unlock()
+
+				i := int(r.URL.Path[len(r.URL.Path)-1] - '1')
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.answers[i])
+				w.Write([]byte(`{"message":"done"}`))
+			}))
+			defer server.Close()
+
+			store, err := Open(filepath.Join(t.TempDir(), "outbox.db"))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+			for i := range tt.answers {
+				_, err := store.Enqueue(&client.QueuedRequest{
+					Method:    http.MethodPatch,
+					URL:       server.URL + "/ops/" + string(rune('1'+i)),
+					CreatedAt: time.Now(),
+				})
+				if err != nil {
+					t.Fatalf("Enqueue() error = %v", err)
+				}
+			}
+
+			var results []string
+			c := client.New().SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1})
+			replayer := NewReplayer(store, c, time.Hour, func(result Result) {
+				outcome := "replayed"
+				if result.Conflict {
+					outcome = "conflict"
+				}
+				results = append(results, outcome+" "+result.Operation.ID)
+			})
+
+			replayer.Flush(context.Background())
+			if !slices.Equal(sent, tt.wantSent) {
+				t.Errorf("Flush() sent %v, want %v", sent, tt.wantSent)
+			}
+			if !slices.Equal(results, tt.wantResults) {
+				t.Errorf("Flush() reported %v, want %v", results, tt.wantResults)
+			}
+
+			remaining, err := store.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			got := make(map[string]Status, len(remaining))
+			for _, op := range remaining {
+				got[op.ID] = op.Status
+				if want, ok := tt.wantAttempts[op.ID]; ok && op.Attempts != want {
+					t.Errorf("operation %s has %d attempts, want %d", op.ID, op.Attempts, want)
+				}
+			}
+			if len(got) != len(tt.wantRemain) {
+				t.Fatalf("queue holds %v, want %v", got, tt.wantRemain)
+			}
+			for id, status := range tt.wantRemain {
+				if got[id] != status {
+					t.Errorf("queue holds %v, want %v", got, tt.wantRemain)
+					break
+				}
+			}
+			if pending, _ := store.Pending(); pending != tt.wantPending {
+				t.Errorf("Pending() = %v, want %v", pending, tt.wantPending)
+			}
+
+			// Nothing the server settled is sent again
+			sent = nil
+			replayer.Flush(context.Background())
+			for _, path := range sent {
+				if !strings.HasSuffix(path, "/2") && !strings.HasSuffix(path, "/3") || !tt.wantPending {
+					t.Errorf("second Flush() sent %s again", path)
+				}
+			}
+		})
+	}
+}
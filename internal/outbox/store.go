@@ -0,0 +1,199 @@
+package outbox
+
+import (
+	"changeme/internal/client"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var operationsBucket = []byte("operations")
+
+// ErrNotFound is returned when an operation ID does not exist
+var ErrNotFound = errors.New("operation not found")
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusFailed  Status = "failed"
+)
+
+// Operation is a queued write together with its replay state
+type Operation struct {
+	ID        string               `json:"id"`
+	Request   client.QueuedRequest `json:"request"`
+	Status    Status               `json:"status"`
+	Attempts  int                  `json:"attempts"`
+	LastError string               `json:"last_error"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// Store keeps queued operations in insertion order in a bbolt database.
+// It implements client.Outbox.
+type Store struct {
+	db *bolt.DB
+	// queued wakes the replayer after Enqueue
+	queued chan struct{}
+}
+
+// Open opens (or creates) the outbox database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create outbox dir: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(operationsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize outbox: %w", err)
+	}
+
+	return &Store{db: db, queued: make(chan struct{}, 1)}, nil
+}
+
+func (s *Store) Enqueue(req *client.QueuedRequest) (string, error) {
+	var id string
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(operationsBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		id = strconv.FormatUint(seq, 10)
+
+		return put(bucket, &Operation{
+			ID:        id,
+			Request:   *req,
+			Status:    StatusPending,
+			UpdatedAt: time.Now(),
+		})
+	})
+
+	if err == nil {
+		select {
+		case s.queued <- struct{}{}:
+		default:
+		}
+	}
+	return id, err
+}
+
+// Pending reports whether any operation still waits to be replayed. Failed
+// operations do not count; they are only sent again after being discarded.
+func (s *Store) Pending() (bool, error) {
+	pending := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(operationsBucket).ForEach(func(_, data []byte) error {
+			var op Operation
+			if err := json.Unmarshal(data, &op); err != nil {
+				return err
+			}
+			if op.Status == StatusPending {
+				pending = true
+			}
+			return nil
+		})
+	})
+
+	return pending, err
+}
+
+// List returns all queued operations, oldest first
+func (s *Store) List() ([]Operation, error) {
+	operations := []Operation{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(operationsBucket).ForEach(func(_, data []byte) error {
+			var op Operation
+			if err := json.Unmarshal(data, &op); err != nil {
+				return err
+			}
+			operations = append(operations, op)
+			return nil
+		})
+	})
+
+	return operations, err
+}
+
+// Update saves the replay state of an existing operation
+func (s *Store) Update(op *Operation) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(operationsBucket)
+		k, err := key(op.ID)
+		if err != nil {
+			return err
+		}
+		if bucket.Get(k) == nil {
+			return ErrNotFound
+		}
+
+		op.UpdatedAt = time.Now()
+		return put(bucket, op)
+	})
+}
+
+// Delete removes an operation from the queue
+func (s *Store) Delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(operationsBucket)
+		k, err := key(id)
+		if err != nil {
+			return err
+		}
+		if bucket.Get(k) == nil {
+			return ErrNotFound
+		}
+
+		return bucket.Delete(k)
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func put(bucket *bolt.Bucket, op *Operation) error {
+	k, err := key(op.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	return bucket.Put(k, data)
+}
+
+// key encodes the sequence number big-endian so bbolt iterates in queue order
+func key(id string) ([]byte, error) {
+	seq, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k, nil
+}
@@ -0,0 +1,233 @@
+package reconcile
+
+import (
+	"changeme/internal/models"
+	"changeme/internal/sheet"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MatchThreshold is the lowest score proposed as a payment without review
+const MatchThreshold = 0.8
+
+const (
+	MatchedByInvoice  = "invoice"
+	MatchedByContract = "contract"
+)
+
+// Match links a transaction to the invoice it most likely pays
+type Match struct {
+	InvoiceID    int     `json:"invoice_id"`
+	InvoiceCode  string  `json:"invoice_code"`
+	ContractCode string  `json:"contract_code"`
+	UserID       int     `json:"user_id"`
+	StudentName  string  `json:"student_name"`
+	Outstanding  float64 `json:"outstanding"`
+	// Amount is what would be recorded: the transfer capped at the outstanding amount
+	Amount    float64 `json:"amount"`
+	Score     float64 `json:"score"`
+	MatchedBy string  `json:"matched_by"`
+}
+
+// compact keeps only letters and digits; banks drop punctuation from memos,
+// so "HD-2025/001" and "HD2025001" compare equal
+func compact(s string) string {
+	var b strings.Builder
+	for _, r := range sheet.Fold(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// words splits s into runs of letters and runs of digits. Code boundaries
+// fall between words, so "HD1" is found in "CK HD1 T10" and "HD1thang10"
+// but not in "HD12".
+func words(s string) []string {
+	var (
+		out  []string
+		word []rune
+	)
+	for _, r := range sheet.Fold(s) {
+		letter, digit := unicode.IsLetter(r), unicode.IsDigit(r)
+		if len(word) > 0 && (!letter && !digit || unicode.IsDigit(word[len(word)-1]) != digit) {
+			out = append(out, string(word))
+			word = word[:0]
+		}
+		if letter || digit {
+			word = append(word, r)
+		}
+	}
+	if len(word) > 0 {
+		out = append(out, string(word))
+	}
+	return out
+}
+
+// Score rates how well a memo carries code, from 0 to 1. Only runs of whole
+// words of the memo are compared with the code: an exact run scores 1, and
+// runs of about the code's length are compared by edit distance to tolerate
+// typos.
+func Score(memo, code string) float64 {
+	code = compact(code)
+	memoWords := words(memo)
+	if code == "" || len(memoWords) == 0 {
+		return 0
+	}
+
+	target := []rune(code)
+	best := 0.0
+	for start := range memoWords {
+		var run []rune
+		for _, word := range memoWords[start:] {
+			run = append(run, []rune(word)...)
+			if len(run) > len(target)+1 {
+				break
+			}
+			if len(run) < len(target)-1 {
+				continue
+			}
+			distance := levenshtein(run, target)
+			if distance == 0 {
+				return 1
+			}
+			best = max(best, 1-float64(distance)/float64(max(len(run), len(target))))
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Matcher scores transactions against the unpaid invoices and their contracts
+type Matcher struct {
+	invoices  []models.Invoice
+	contracts []models.Contract
+	// reserved is what earlier lines were already matched to pay per invoice
+	reserved map[int]float64
+}
+
+// NewMatcher takes the invoices that can still be paid and the contracts
+// whose codes students put in their memos
+func NewMatcher(invoices []models.Invoice, contracts []models.Contract) *Matcher {
+	unpaid := []models.Invoice{}
+	for _, invoice := range invoices {
+		if invoice.Status != models.InvoiceStatusVoid && invoice.Outstanding() > 0 {
+			unpaid = append(unpaid, invoice)
+		}
+	}
+
+	// Oldest first, so a contract code pays off the oldest debt
+	sort.SliceStable(unpaid, func(i, j int) bool {
+		return unpaid[i].DueDate.Before(unpaid[j].DueDate)
+	})
+
+	return &Matcher{invoices: unpaid, contracts: contracts, reserved: make(map[int]float64)}
+}
+
+// Reserve takes a match's amount off its invoice, so later transfers are
+// matched against what is left to pay
+func (m *Matcher) Reserve(match Match) {
+	m.reserved[match.InvoiceID] += match.Amount
+}
+
+// outstanding returns what is left to pay on invoice after the reserved matches
+func (m *Matcher) outstanding(invoice models.Invoice) float64 {
+	return max(invoice.Outstanding()-m.reserved[invoice.ID], 0)
+}
+
+// Candidates returns every invoice the transaction may pay, best first.
+// Invoice numbers win over contract codes at the same score.
+func (m *Matcher) Candidates(txn Transaction) []Match {
+	matches := []Match{}
+	seen := make(map[int]bool)
+
+	for _, invoice := range m.invoices {
+		if m.outstanding(invoice) <= 0 {
+			continue
+		}
+		if score := Score(txn.Memo, invoice.Code); score >= MatchThreshold {
+			matches = append(matches, m.match(txn, invoice, "", score, MatchedByInvoice))
+			seen[invoice.ID] = true
+		}
+	}
+
+	for _, contract := range m.contracts {
+		score := Score(txn.Memo, contract.Code)
+		if score < MatchThreshold {
+			continue
+		}
+		for _, invoice := range m.invoices {
+			if seen[invoice.ID] || m.outstanding(invoice) <= 0 || !invoiceOf(invoice, contract) {
+				continue
+			}
+			matches = append(matches, m.match(txn, invoice, contract.Code, score, MatchedByContract))
+			seen[invoice.ID] = true
+			// Only the oldest open invoice of the contract is a candidate
+			break
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].MatchedBy == MatchedByInvoice && matches[j].MatchedBy != MatchedByInvoice
+	})
+	return matches
+}
+
+// Best returns the match to propose, or nil with the reason it needs review
+func (m *Matcher) Best(txn Transaction) (*Match, []Match, string) {
+	candidates := m.Candidates(txn)
+	switch {
+	case len(candidates) == 0:
+		return nil, candidates, "no invoice or contract code found in the memo"
+	case len(candidates) > 1 && candidates[0].Score == candidates[1].Score && candidates[0].MatchedBy == candidates[1].MatchedBy:
+		return nil, candidates, "memo matches more than one invoice"
+	}
+
+	best := candidates[0]
+	return &best, candidates, ""
+}
+
+func (m *Matcher) match(txn Transaction, invoice models.Invoice, contractCode string, score float64, by string) Match {
+	return Match{
+		InvoiceID:    invoice.ID,
+		InvoiceCode:  invoice.Code,
+		ContractCode: contractCode,
+		UserID:       invoice.UserID,
+		StudentName:  invoice.User.FullName,
+		Outstanding:  m.outstanding(invoice),
+		Amount:       min(txn.Amount, m.outstanding(invoice)),
+		Score:        score,
+		MatchedBy:    by,
+	}
+}
+
+func invoiceOf(invoice models.Invoice, contract models.Contract) bool {
+	if invoice.ContractID != nil {
+		return *invoice.ContractID == contract.ID
+	}
+	return invoice.UserID == contract.UserID
+}
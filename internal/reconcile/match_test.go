@@ -0,0 +1,73 @@
+package reconcile
+
+import (
+	"changeme/internal/models"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name string
+		memo string
+		code string
+		want float64
+	}{
+		{"exact", "HD1", "HD1", 1},
+		{"among other words", "CK HD1 T10", "HD1", 1},
+		{"followed by letters", "hd1thang10", "HD1", 1},
+		{"punctuation dropped by the bank", "CK HD 2025 001 Nguyen Van A", "HD-2025/001", 1},
+		{"diacritics and case", "Thanh toán hđ-2025/001", "HD-2025/001", 1},
+		{"longer number", "HD12", "HD1", 0.75},
+		{"longer prefix", "XHD1", "HD1", 0.75},
+		{"one typo", "CK HD-2025/0001", "HD-2025/001", 0.9},
+		{"no word run of the code's length", "chuyenkhoantienphong", "HD-2025/001", 0},
+		{"empty memo", "", "HD1", 0},
+		{"empty code", "HD1", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.memo, tt.code); got != tt.want {
+				t.Errorf("Score(%q, %q) = %v, want %v", tt.memo, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherReserve(t *testing.T) {
+	contractID := 9
+	invoices := []models.Invoice{
+		{ID: 1, Code: "HD1", UserID: 5, ContractID: &contractID, Amount: 3_000_000, DueDate: time.Date(2025, time.September, 10, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Code: "HD2", UserID: 5, ContractID: &contractID, Amount: 3_000_000, DueDate: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	contracts := []models.Contract{{ID: contractID, Code: "C001", UserID: 5}}
+
+	tests := []struct {
+		name          string
+		txn           Transaction
+		wantInvoiceID int
+		wantAmount    float64
+	}{
+		{"invoice code", Transaction{Amount: 2_000_000, Memo: "CK HD1"}, 1, 2_000_000},
+		{"capped at what the first line left", Transaction{Amount: 2_000_000, Memo: "CK HD1"}, 1, 1_000_000},
+		{"contract code skips the paid invoice", Transaction{Amount: 1_000_000, Memo: "C001"}, 2, 1_000_000},
+		{"capped again", Transaction{Amount: 5_000_000, Memo: "C001"}, 2, 2_000_000},
+		{"nothing left", Transaction{Amount: 100_000, Memo: "C001"}, 0, 0},
+	}
+
+	// The cases run in order against one matcher, like the lines of one statement
+	matcher := NewMatcher(invoices, contracts)
+	for _, tt := range tests {
+		match, _, _ := matcher.Best(tt.txn)
+		switch {
+		case match == nil && tt.wantInvoiceID != 0:
+			t.Errorf("%s: no match, want invoice %d", tt.name, tt.wantInvoiceID)
+		case match != nil && (match.InvoiceID != tt.wantInvoiceID || match.Amount != tt.wantAmount):
+			t.Errorf("%s: matched invoice %d for %v, want invoice %d for %v", tt.name, match.InvoiceID, match.Amount, tt.wantInvoiceID, tt.wantAmount)
+		}
+		if match != nil {
+			matcher.Reserve(*match)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package reconcile
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotPending is returned when confirming a line that is not proposed or in review
+var ErrNotPending = errors.New("statement line has already been handled")
+
+// ImportResult summarises one imported statement
+type ImportResult struct {
+	Source     string `json:"source"`
+	Parsed     int    `json:"parsed"`
+	Duplicates int    `json:"duplicates"`
+	Proposed   []Line `json:"proposed"`
+	Review     []Line `json:"review"`
+}
+
+// Reconciler turns statement lines into payments
+type Reconciler struct {
+	api   *api.API
+	store *Store
+
+	// mu keeps two imports from matching against the same open invoices
+	mu sync.Mutex
+}
+
+func NewReconciler(api *api.API, store *Store) *Reconciler {
+	return &Reconciler{
+		api:   api,
+		store: store,
+	}
+}
+
+// Import parses a statement, matches each new transfer and stores it as a
+// proposal or in the review queue. Transfers imported before are skipped.
+func (r *Reconciler) Import(ctx context.Context, filename string, data []byte) (*ImportResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transactions, err := ParseStatement(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := r.matcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Proposals waiting for confirmation already claim part of their invoices
+	pending, err := r.store.List(LineProposed)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range pending {
+		if line.Match != nil {
+			matcher.Reserve(*line.Match)
+		}
+	}
+
+	result := &ImportResult{
+		Source:   filename,
+		Parsed:   len(transactions),
+		Proposed: []Line{},
+		Review:   []Line{},
+	}
+	for _, txn := range transactions {
+		match, candidates, reason := matcher.Best(txn)
+		line := &Line{
+			Source:      filename,
+			Transaction: txn,
+			Status:      LineReview,
+			Candidates:  candidates,
+			Reason:      reason,
+		}
+		if match != nil {
+			line.Status = LineProposed
+			line.Match = match
+			if txn.Amount > match.Outstanding {
+				line.Reason = fmt.Sprintf("transfer exceeds the outstanding amount by %.0f", txn.Amount-match.Outstanding)
+			}
+		}
+
+		added, err := r.store.Add(line)
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			result.Duplicates++
+			continue
+		}
+
+		if line.Status == LineProposed {
+			matcher.Reserve(*line.Match)
+			result.Proposed = append(result.Proposed, *line)
+		} else {
+			result.Review = append(result.Review, *line)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) matcher(ctx context.Context) (*Matcher, error) {
+	var invoices []models.Invoice
+	for _, status := range []models.InvoiceStatus{models.InvoiceStatusPending, models.InvoiceStatusPartiallyPaid, models.InvoiceStatusOverdue} {
+		list, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Invoice], error) {
+			return r.api.Invoice().GetListInvoices(ctx, api.InvoiceQuery{Page: page, Status: string(status)})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s invoices: %w", status, err)
+		}
+		invoices = append(invoices, list...)
+	}
+
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return r.api.Contract().GetListContracts(ctx, page, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	return NewMatcher(invoices, contracts), nil
+}
+
+// Lines returns the stored lines with the given statuses, or all of them
+func (r *Reconciler) Lines(statuses ...LineStatus) ([]Line, error) {
+	return r.store.List(statuses...)
+}
+
+// Confirm records the proposed payments of the given lines. A line the
+// server rejects keeps its proposal with the error attached.
+func (r *Reconciler) Confirm(ctx context.Context, lineIDs []string) ([]Line, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := []Line{}
+	for _, id := range lineIDs {
+		line, err := r.store.Get(id)
+		if err != nil {
+			return lines, err
+		}
+		if line.Status != LineProposed || line.Match == nil {
+			return lines, fmt.Errorf("line %s: %w", id, ErrNotPending)
+		}
+
+		if err := r.apply(ctx, line, line.Match.InvoiceID, line.Match.Amount); err != nil {
+			return lines, err
+		}
+		lines = append(lines, *line)
+	}
+	return lines, nil
+}
+
+// Resolve pays an invoice picked by staff with a proposed or review line
+func (r *Reconciler) Resolve(ctx context.Context, lineID string, invoiceID int) (*Line, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	line, err := r.store.Get(lineID)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status != LineReview && line.Status != LineProposed {
+		return nil, ErrNotPending
+	}
+
+	invoice, err := r.api.Invoice().GetInvoiceDetails(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Data.Status == models.InvoiceStatusVoid || invoice.Data.Outstanding() <= 0 {
+		return nil, fmt.Errorf("invoice %s has nothing left to pay", invoice.Data.Code)
+	}
+
+	amount := min(line.Transaction.Amount, invoice.Data.Outstanding())
+	line.Match = &Match{
+		InvoiceID:   invoice.Data.ID,
+		InvoiceCode: invoice.Data.Code,
+		UserID:      invoice.Data.UserID,
+		StudentName: invoice.Data.User.FullName,
+		Outstanding: invoice.Data.Outstanding(),
+		Amount:      amount,
+		Score:       1,
+	}
+	if err := r.apply(ctx, line, invoiceID, amount); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// Dismiss sets a line aside without recording a payment
+func (r *Reconciler) Dismiss(lineID string, reason string) (*Line, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	line, err := r.store.Get(lineID)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status == LineApplied {
+		return nil, ErrNotPending
+	}
+
+	line.Status = LineDismissed
+	line.Reason = reason
+	return line, r.store.Update(line)
+}
+
+// apply records the payment of line and saves the outcome. The invoice is
+// read again first and the amount capped at what is still outstanding, as it
+// may have been paid since the line was matched.
+func (r *Reconciler) apply(ctx context.Context, line *Line, invoiceID int, amount float64) error {
+	invoice, err := api.Live(r.api.Invoice().GetInvoiceDetails(ctx, invoiceID))
+	if err == nil && (invoice.Data.Status == models.InvoiceStatusVoid || invoice.Data.Outstanding() <= 0) {
+		err = fmt.Errorf("invoice %s has nothing left to pay", invoice.Data.Code)
+	}
+	if err != nil {
+		return r.fail(line, err)
+	}
+	amount = min(amount, invoice.Data.Outstanding())
+	if line.Match != nil {
+		line.Match.Outstanding = invoice.Data.Outstanding()
+		line.Match.Amount = amount
+	}
+
+	reference := line.Transaction.Reference
+	if reference == "" {
+		reference = line.Transaction.Fingerprint()
+	}
+
+	resp, err := r.api.Payment().RecordPayment(ctx, map[string]interface{}{
+		"invoice_id": invoiceID,
+		"amount":     amount,
+		"method":     models.PaymentMethodBankTransfer,
+		"paid_at":    line.Transaction.Date.Format(time.DateOnly),
+		"reference":  reference,
+		"note":       line.Transaction.Memo,
+	})
+	if err != nil {
+		return r.fail(line, err)
+	}
+
+	line.Status = LineApplied
+	line.PaymentID = resp.Data.ID
+	line.LastError = ""
+	return r.store.Update(line)
+}
+
+// fail keeps the line as it was with err attached
+func (r *Reconciler) fail(line *Line, err error) error {
+	line.LastError = err.Error()
+	if updateErr := r.store.Update(line); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+// Package reconcile imports bank statements, matches incoming transfers to
+// invoices and contracts, and builds VietQR payloads for invoices.
+package reconcile
+
+import (
+	"changeme/internal/sheet"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transaction is one incoming transfer from a bank statement
+type Transaction struct {
+	// Row is the 1-based row in the source file, for pointing staff at it
+	Row       int       `json:"row"`
+	Date      time.Time `json:"date"`
+	Amount    float64   `json:"amount"`
+	Memo      string    `json:"memo"`
+	Reference string    `json:"reference"`
+}
+
+// Fingerprint identifies a transaction across imports of overlapping statements
+func (t Transaction) Fingerprint() string {
+	return strings.Join([]string{
+		t.Date.Format(time.DateOnly),
+		strconv.FormatFloat(t.Amount, 'f', 0, 64),
+		t.Reference,
+		sheet.Fold(t.Memo),
+	}, "|")
+}
+
+// ErrNoHeader is returned when no row of the file looks like a statement header
+var ErrNoHeader = errors.New("could not find the date, amount and description columns")
+
+// columnAliases are header texts of the common Vietnamese bank exports, as sheet.HeaderKey returns them
+var columnAliases = map[string][]string{
+	"date":      {"ngay giao dich", "ngay gd", "ngay hieu luc", "ngay", "transaction date", "effective date", "date", "ngay thuc hien"},
+	"credit":    {"so tien ghi co", "ghi co", "so tien co", "tien vao", "phat sinh co", "credit amount", "credit", "co"},
+	"amount":    {"so tien", "amount", "so tien giao dich"},
+	"memo":      {"noi dung giao dich", "noi dung", "dien giai", "mo ta", "chi tiet giao dich", "transaction details", "description", "remark", "remarks"},
+	"reference": {"so tham chieu", "ma giao dich", "so but toan", "so gd", "reference", "ref no", "transaction id", "ma gd"},
+}
+
+// ParseStatement reads a CSV or Excel (.xlsx) statement and returns its
+// incoming transfers. Outgoing lines and rows that are not transactions,
+// such as totals, are skipped.
+func ParseStatement(filename string, data []byte) ([]Transaction, error) {
+	rows, err := sheet.Read(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statements list credits in their own column or signed in a single amount column
+	header, columns, err := sheet.FindHeader(rows, columnAliases, "date", "memo", "credit")
+	if err != nil {
+		header, columns, err = sheet.FindHeader(rows, columnAliases, "date", "memo", "amount")
+	}
+	if err != nil {
+		return nil, ErrNoHeader
+	}
+
+	amountColumn := "credit"
+	if _, ok := columns["credit"]; !ok {
+		amountColumn = "amount"
+	}
+
+	cell := func(row []string, column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+
+	transactions := []Transaction{}
+	for i := header + 1; i < len(rows); i++ {
+		row := rows[i]
+
+		date, err := sheet.ParseDate(cell(row, "date"))
+		if err != nil {
+			continue
+		}
+		amount, err := sheet.ParseAmount(cell(row, amountColumn))
+		if err != nil || amount <= 0 {
+			continue
+		}
+
+		transactions = append(transactions, Transaction{
+			Row:       i + 1,
+			Date:      date,
+			Amount:    amount,
+			Memo:      cell(row, "memo"),
+			Reference: cell(row, "reference"),
+		})
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,203 @@
+package reconcile
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	linesBucket        = []byte("lines")
+	fingerprintsBucket = []byte("fingerprints")
+)
+
+// ErrNotFound is returned when a line ID does not exist
+var ErrNotFound = errors.New("statement line not found")
+
+type LineStatus string
+
+const (
+	// LineProposed has a confident match waiting for confirmation
+	LineProposed LineStatus = "proposed"
+	// LineReview could not be matched and waits in the review queue
+	LineReview LineStatus = "review"
+	// LineApplied was recorded as a payment
+	LineApplied LineStatus = "applied"
+	// LineDismissed was set aside, e.g. a transfer that is not rent
+	LineDismissed LineStatus = "dismissed"
+)
+
+// Line is an imported transaction together with its reconciliation state
+type Line struct {
+	ID          string      `json:"id"`
+	Source      string      `json:"source"`
+	Transaction Transaction `json:"transaction"`
+	Status      LineStatus  `json:"status"`
+	Match       *Match      `json:"match"`
+	Candidates  []Match     `json:"candidates"`
+	Reason      string      `json:"reason"`
+	PaymentID   int         `json:"payment_id"`
+	LastError   string      `json:"last_error"`
+	ImportedAt  time.Time   `json:"imported_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Store keeps imported lines, and the fingerprints of every transaction
+// seen, so importing overlapping statements does not pay an invoice twice
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the reconciliation database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create reconciliation dir: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reconciliation store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{linesBucket, fingerprintsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize reconciliation store: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Add stores a line unless its transaction was imported before. It reports
+// whether the line was added.
+func (s *Store) Add(line *Line) (bool, error) {
+	added := false
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		fingerprints := tx.Bucket(fingerprintsBucket)
+		fingerprint := []byte(line.Transaction.Fingerprint())
+		if fingerprints.Get(fingerprint) != nil {
+			return nil
+		}
+
+		bucket := tx.Bucket(linesBucket)
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		line.ID = strconv.FormatUint(seq, 10)
+		line.ImportedAt = time.Now()
+		line.UpdatedAt = line.ImportedAt
+
+		if err := fingerprints.Put(fingerprint, []byte(line.ID)); err != nil {
+			return err
+		}
+		added = true
+		return put(bucket, line)
+	})
+
+	return added, err
+}
+
+// List returns the lines with one of the given statuses, or all lines when none are given
+func (s *Store) List(statuses ...LineStatus) ([]Line, error) {
+	lines := []Line{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(linesBucket).ForEach(func(_, data []byte) error {
+			var line Line
+			if err := json.Unmarshal(data, &line); err != nil {
+				return err
+			}
+			if len(statuses) == 0 || slices.Contains(statuses, line.Status) {
+				lines = append(lines, line)
+			}
+			return nil
+		})
+	})
+
+	return lines, err
+}
+
+func (s *Store) Get(id string) (*Line, error) {
+	var line Line
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		k, err := key(id)
+		if err != nil {
+			return err
+		}
+		data := tx.Bucket(linesBucket).Get(k)
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &line)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &line, nil
+}
+
+// Update saves an existing line
+func (s *Store) Update(line *Line) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(linesBucket)
+		k, err := key(line.ID)
+		if err != nil {
+			return err
+		}
+		if bucket.Get(k) == nil {
+			return ErrNotFound
+		}
+
+		line.UpdatedAt = time.Now()
+		return put(bucket, line)
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func put(bucket *bolt.Bucket, line *Line) error {
+	k, err := key(line.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to encode statement line: %w", err)
+	}
+
+	return bucket.Put(k, data)
+}
+
+// key encodes the sequence number big-endian so bbolt iterates in import order
+func key(id string) ([]byte, error) {
+	seq, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k, nil
+}
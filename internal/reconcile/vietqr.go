@@ -0,0 +1,93 @@
+package reconcile
+
+import (
+	"changeme/internal/config"
+	"changeme/internal/sheet"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrBankNotConfigured is returned when no receiving account is configured
+var ErrBankNotConfigured = errors.New("bank account for VietQR is not configured")
+
+const (
+	// napasGUID identifies the NAPAS VietQR scheme in the merchant account field
+	napasGUID = "A000000727"
+	// transferService is the NAPAS service code for transfers to an account number
+	transferService = "QRIBFTTA"
+	// maxPurposeLength is the EMVCo limit of the purpose of transaction field
+	maxPurposeLength = 25
+)
+
+// VietQR builds the EMVCo payload of a VietQR transfer to the configured
+// account. A positive amount makes a dynamic code with the amount filled in;
+// memo becomes the transfer description, reduced to what banks keep.
+func VietQR(bank config.BankConfig, amount float64, memo string) (string, error) {
+	if bank.BIN == "" || bank.AccountNumber == "" {
+		return "", ErrBankNotConfigured
+	}
+
+	initiation := "11"
+	if amount > 0 {
+		initiation = "12"
+	}
+
+	var b strings.Builder
+	b.WriteString(tlv("00", "01"))
+	b.WriteString(tlv("01", initiation))
+	b.WriteString(tlv("38",
+		tlv("00", napasGUID)+
+			tlv("01", tlv("00", bank.BIN)+tlv("01", bank.AccountNumber))+
+			tlv("02", transferService)))
+	b.WriteString(tlv("53", "704"))
+	if amount > 0 {
+		b.WriteString(tlv("54", strconv.FormatInt(int64(amount), 10)))
+	}
+	b.WriteString(tlv("58", "VN"))
+	if purpose := purpose(memo); purpose != "" {
+		b.WriteString(tlv("62", tlv("08", purpose)))
+	}
+	b.WriteString("6304")
+
+	payload := b.String()
+	return payload + fmt.Sprintf("%04X", crc16(payload)), nil
+}
+
+func tlv(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// purpose keeps the ASCII letters, digits, spaces and dashes of memo
+func purpose(memo string) string {
+	var b strings.Builder
+	for _, r := range sheet.Fold(memo) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || r == '-') {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	result := strings.TrimSpace(b.String())
+	if len(result) > maxPurposeLength {
+		result = result[:maxPurposeLength]
+	}
+	return result
+}
+
+// crc16 is CRC-16/CCITT-FALSE as required by EMVCo
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
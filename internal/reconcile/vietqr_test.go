@@ -0,0 +1,71 @@
+package reconcile
+
+import (
+	"changeme/internal/config"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCRC16(t *testing.T) {
+	tests := []struct {
+		data string
+		want uint16
+	}{
+		{"", 0xFFFF},
+		{"A", 0xB915},
+		{"123456789", 0x29B1},
+	}
+
+	for _, tt := range tests {
+		if got := crc16(tt.data); got != tt.want {
+			t.Errorf("crc16(%q) = %04X, want %04X", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestVietQR(t *testing.T) {
+	bank := config.BankConfig{BIN: "970436", AccountNumber: "0011001234567"}
+
+	account := "3857" + "0010A000000727" + "0127" + "0006970436" + "01130011001234567" + "0208QRIBFTTA"
+
+	tests := []struct {
+		name   string
+		amount float64
+		memo   string
+		want   string
+	}{
+		{
+			name: "static",
+			want: "000201" + "010211" + account + "5303704" + "5802VN" + "6304",
+		},
+		{
+			name:   "dynamic with memo",
+			amount: 3_000_000,
+			memo:   "Hóa đơn HD-2025/001",
+			want:   "000201" + "010212" + account + "5303704" + "54073000000" + "5802VN" + "6222" + "0818HOA DON HD-2025001" + "6304",
+		},
+		{
+			name:   "memo cut to the purpose limit",
+			amount: 1,
+			memo:   "Thanh toan tien phong thang 9 nam 2025",
+			want:   "000201" + "010212" + account + "5303704" + "54011" + "5802VN" + "6229" + "0825THANH TOAN TIEN PHONG THA" + "6304",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := VietQR(bank, tt.amount, tt.memo)
+			if err != nil {
+				t.Fatalf("VietQR() error = %v", err)
+			}
+			if want := tt.want + fmt.Sprintf("%04X", crc16(tt.want)); payload != want {
+				t.Errorf("VietQR() = %q, want %q", payload, want)
+			}
+		})
+	}
+
+	if _, err := VietQR(config.BankConfig{}, 1, ""); !errors.Is(err, ErrBankNotConfigured) {
+		t.Errorf("VietQR() without a bank error = %v, want ErrBankNotConfigured", err)
+	}
+}
@@ -0,0 +1,245 @@
+package roster
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is how many accounts are created at once
+const DefaultConcurrency = 4
+
+type Outcome string
+
+const (
+	// OutcomeReady is a valid new student; only previews report it
+	OutcomeReady     Outcome = "ready"
+	OutcomeCreated   Outcome = "created"
+	OutcomeDuplicate Outcome = "duplicate"
+	OutcomeInvalid   Outcome = "invalid"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// Result is the outcome of one roster row
+type Result struct {
+	Row         int      `json:"row"`
+	StudentCode string   `json:"student_code"`
+	FullName    string   `json:"full_name"`
+	Email       string   `json:"email"`
+	Outcome     Outcome  `json:"outcome"`
+	Errors      []string `json:"errors"`
+	UserID      int      `json:"user_id"`
+}
+
+// Report is the per-row outcome of an import or preview
+type Report struct {
+	Source     string            `json:"source"`
+	Mapping    map[string]string `json:"mapping"`
+	Total      int               `json:"total"`
+	Ready      int               `json:"ready"`
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Failed     int               `json:"failed"`
+	Results    []Result          `json:"results"`
+	FinishedAt time.Time         `json:"finished_at"`
+}
+
+func (r *Report) count() {
+	r.Total = len(r.Results)
+	r.Ready, r.Created, r.Duplicates, r.Invalid, r.Failed = 0, 0, 0, 0, 0
+	for _, result := range r.Results {
+		switch result.Outcome {
+		case OutcomeReady:
+			r.Ready++
+		case OutcomeCreated:
+			r.Created++
+		case OutcomeDuplicate:
+			r.Duplicates++
+		case OutcomeInvalid:
+			r.Invalid++
+		case OutcomeFailed:
+			r.Failed++
+		}
+	}
+	r.FinishedAt = time.Now()
+}
+
+// Importer checks rosters against existing users and creates the new accounts
+type Importer struct {
+	api         *api.API
+	concurrency int
+}
+
+func NewImporter(api *api.API, concurrency int) *Importer {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	return &Importer{
+		api:         api,
+		concurrency: concurrency,
+	}
+}
+
+// Preview validates a roster and marks rows whose student code or email
+// already exists, in the file or on the server, without creating anything
+func (i *Importer) Preview(ctx context.Context, filename string, data []byte, mapping map[string]string) (*Report, []Row, error) {
+	roster, err := Parse(filename, data, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.User], error) {
+		return i.api.User().GetListUsers(ctx, page, "", "", "", "", "", "", nil)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list existing users: %w", err)
+	}
+
+	codes := make(map[string]string)
+	emails := make(map[string]string)
+	for _, user := range users {
+		codes[strings.ToUpper(user.StudentCode)] = "an existing user"
+		emails[strings.ToLower(user.Email)] = "an existing user"
+	}
+
+	report := &Report{Source: filename, Mapping: roster.Mapping, Results: []Result{}}
+	for _, row := range roster.Rows {
+		result := Result{
+			Row:         row.Number,
+			StudentCode: row.Student.StudentCode,
+			FullName:    row.Student.FullName,
+			Email:       row.Student.Email,
+			Outcome:     OutcomeReady,
+			Errors:      row.Errors,
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			result.Outcome = OutcomeInvalid
+		case codes[row.Student.StudentCode] != "":
+			result.Outcome = OutcomeDuplicate
+			result.Errors = append(result.Errors, "student code already used by "+codes[row.Student.StudentCode])
+		case emails[row.Student.Email] != "":
+			result.Outcome = OutcomeDuplicate
+			result.Errors = append(result.Errors, "email already used by "+emails[row.Student.Email])
+		default:
+			owner := "row " + strconv.Itoa(row.Number)
+			codes[row.Student.StudentCode] = owner
+			emails[row.Student.Email] = owner
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	report.count()
+	return report, roster.Rows, nil
+}
+
+// Import previews the roster and creates an account for every ready row,
+// a few at a time. progress is called after each account with the number
+// of rows done and to do.
+func (i *Importer) Import(ctx context.Context, filename string, data []byte, mapping map[string]string, progress func(done, total int)) (*Report, error) {
+	report, rows, err := i.Preview(ctx, filename, data, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	total := report.Ready
+	done := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, i.concurrency)
+
+	for index := range report.Results {
+		if report.Results[index].Outcome != OutcomeReady {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(result *Result, student Student) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			resp, err := i.api.User().CreateUser(ctx, student.Payload())
+			mu.Lock()
+			defer mu.Unlock()
+
+			var apiErr *api.Error
+			switch {
+			case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict:
+				result.Outcome = OutcomeDuplicate
+				result.Errors = append(result.Errors, apiErr.Message)
+			case err != nil:
+				result.Outcome = OutcomeFailed
+				result.Errors = append(result.Errors, err.Error())
+			default:
+				result.Outcome = OutcomeCreated
+				result.UserID = resp.Data.ID
+			}
+
+			done++
+			if progress != nil {
+				progress(done, total)
+			}
+		}(&report.Results[index], rows[index].Student)
+	}
+	wg.Wait()
+
+	// Rows never attempted because the import was cancelled
+	for index := range report.Results {
+		if report.Results[index].Outcome == OutcomeReady {
+			report.Results[index].Outcome = OutcomeFailed
+			report.Results[index].Errors = append(report.Results[index].Errors, "import was cancelled")
+		}
+	}
+
+	report.count()
+	return report, ctx.Err()
+}
+
+// reportHeader is in Vietnamese, matching the rest of the staff screens
+var reportHeader = []string{"Dòng", "Mã sinh viên", "Họ và tên", "Email", "Kết quả", "Lỗi", "ID người dùng"}
+
+var outcomeLabels = map[Outcome]string{
+	OutcomeReady:     "Sẵn sàng",
+	OutcomeCreated:   "Đã tạo",
+	OutcomeDuplicate: "Trùng lặp",
+	OutcomeInvalid:   "Không hợp lệ",
+	OutcomeFailed:    "Lỗi",
+}
+
+// Table returns the report as a header and rows for sheet.Write
+func (r *Report) Table() ([]string, [][]string) {
+	rows := make([][]string, len(r.Results))
+	for i, result := range r.Results {
+		userID := ""
+		if result.UserID != 0 {
+			userID = strconv.Itoa(result.UserID)
+		}
+		rows[i] = []string{
+			strconv.Itoa(result.Row),
+			result.StudentCode,
+			result.FullName,
+			result.Email,
+			outcomeLabels[result.Outcome],
+			strings.Join(result.Errors, "; "),
+			userID,
+		}
+	}
+	return reportHeader, rows
+}
@@ -0,0 +1,235 @@
+// Package roster imports student rosters from spreadsheets.
+package roster
+
+import (
+	"changeme/internal/models"
+	"changeme/internal/sheet"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Fields a roster column can be mapped to
+const (
+	FieldFullName                     = "full_name"
+	FieldStudentCode                  = "student_code"
+	FieldEmail                        = "email"
+	FieldPhone                        = "phone"
+	FieldGender                       = "gender"
+	FieldBirthday                     = "birthday"
+	FieldMajor                        = "major"
+	FieldEmergencyContactName         = "emergency_contact_name"
+	FieldEmergencyContactPhone        = "emergency_contact_phone"
+	FieldEmergencyContactRelationship = "emergency_contact_relationship"
+)
+
+// requiredFields must be mapped and filled in on every row
+var requiredFields = []string{FieldFullName, FieldStudentCode, FieldEmail}
+
+// fieldAliases are the header texts recognised without an explicit mapping, as sheet.HeaderKey returns them
+var fieldAliases = map[string][]string{
+	FieldFullName:                     {"ho va ten", "ho ten", "ten sinh vien", "full name", "fullname", "name"},
+	FieldStudentCode:                  {"ma sinh vien", "mssv", "ma sv", "student code", "student id", "code"},
+	FieldEmail:                        {"email", "e-mail", "thu dien tu"},
+	FieldPhone:                        {"so dien thoai", "sdt", "dien thoai", "phone", "phone number"},
+	FieldGender:                       {"gioi tinh", "gender", "sex"},
+	FieldBirthday:                     {"ngay sinh", "birthday", "date of birth", "dob"},
+	FieldMajor:                        {"nganh", "nganh hoc", "chuyen nganh", "major"},
+	FieldEmergencyContactName:         {"nguoi lien he khan cap", "lien he khan cap", "nguoi lien he", "emergency contact", "emergency contact name"},
+	FieldEmergencyContactPhone:        {"sdt khan cap", "sdt nguoi lien he", "so dien thoai nguoi lien he", "emergency phone", "emergency contact phone"},
+	FieldEmergencyContactRelationship: {"quan he", "moi quan he", "relationship", "emergency contact relationship"},
+}
+
+var (
+	studentCodePattern = regexp.MustCompile(`^[A-Za-z0-9]{4,20}$`)
+	phonePattern       = regexp.MustCompile(`^(0|\+84)[0-9]{9,10}$`)
+)
+
+var genders = map[string]models.Gender{
+	"nam":    models.GenderMale,
+	"male":   models.GenderMale,
+	"m":      models.GenderMale,
+	"nu":     models.GenderFemale,
+	"female": models.GenderFemale,
+	"f":      models.GenderFemale,
+	"khac":   models.GenderOther,
+	"other":  models.GenderOther,
+}
+
+// Student is one validated roster row
+type Student struct {
+	FullName         string                   `json:"full_name"`
+	StudentCode      string                   `json:"student_code"`
+	Email            string                   `json:"email"`
+	Phone            string                   `json:"phone"`
+	Gender           models.Gender            `json:"gender"`
+	Birthday         *time.Time               `json:"birthday"`
+	Major            string                   `json:"major"`
+	EmergencyContact *models.EmergencyContact `json:"emergency_contact"`
+}
+
+// Payload returns the body for UserAPI.CreateUser
+func (s *Student) Payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		"full_name":    s.FullName,
+		"student_code": s.StudentCode,
+		"email":        s.Email,
+		"role":         models.UserRoleStudent,
+	}
+	if s.Phone != "" {
+		payload["phone"] = s.Phone
+	}
+	if s.Gender != "" {
+		payload["gender"] = s.Gender
+	}
+	if s.Birthday != nil {
+		payload["birthday"] = s.Birthday.Format(time.DateOnly)
+	}
+	if s.Major != "" {
+		payload["major"] = s.Major
+	}
+	if s.EmergencyContact != nil {
+		payload["emergency_contact"] = s.EmergencyContact
+	}
+	return payload
+}
+
+// Row is a parsed roster row with whatever was wrong with it
+type Row struct {
+	// Number is the 1-based row in the source file
+	Number  int      `json:"number"`
+	Student Student  `json:"student"`
+	Errors  []string `json:"errors"`
+}
+
+// Roster is a parsed file with the column mapping that was used
+type Roster struct {
+	// Mapping maps each field to the header text it was read from
+	Mapping map[string]string `json:"mapping"`
+	Rows    []Row             `json:"rows"`
+}
+
+// Parse reads and validates a roster. mapping maps fields to header texts
+// in the file; when it is empty, columns are recognised by their usual
+// Vietnamese or English names.
+func Parse(filename string, data []byte, mapping map[string]string) (*Roster, error) {
+	rows, err := sheet.Read(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := fieldAliases
+	if len(mapping) > 0 {
+		aliases = make(map[string][]string)
+		for field, header := range mapping {
+			if _, ok := fieldAliases[field]; !ok {
+				return nil, fmt.Errorf("unknown field %q", field)
+			}
+			if header != "" {
+				aliases[field] = []string{sheet.HeaderKey(header)}
+			}
+		}
+	}
+
+	header, columns, err := sheet.FindHeader(rows, aliases, requiredFields...)
+	if errors.Is(err, sheet.ErrNoHeader) {
+		return nil, fmt.Errorf("could not find the %s columns", strings.Join(requiredFields, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	roster := &Roster{Mapping: make(map[string]string), Rows: []Row{}}
+	for field, index := range columns {
+		roster.Mapping[field] = strings.TrimSpace(rows[header][index])
+	}
+
+	for i := header + 1; i < len(rows); i++ {
+		values := make(map[string]string)
+		empty := true
+		for field, index := range columns {
+			if index < len(rows[i]) {
+				values[field] = strings.TrimSpace(rows[i][index])
+				empty = empty && values[field] == ""
+			}
+		}
+		if empty {
+			continue
+		}
+
+		roster.Rows = append(roster.Rows, parseRow(i+1, values))
+	}
+
+	return roster, nil
+}
+
+func parseRow(number int, values map[string]string) Row {
+	row := Row{Number: number, Errors: []string{}}
+	fail := func(format string, args ...interface{}) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	student := &row.Student
+	student.FullName = strings.Join(strings.Fields(values[FieldFullName]), " ")
+	student.StudentCode = strings.ToUpper(values[FieldStudentCode])
+	student.Email = strings.ToLower(values[FieldEmail])
+	student.Major = values[FieldMajor]
+
+	if student.FullName == "" {
+		fail("full name is required")
+	}
+	if !studentCodePattern.MatchString(student.StudentCode) {
+		fail("student code %q must be 4 to 20 letters or digits", values[FieldStudentCode])
+	}
+	if address, err := mail.ParseAddress(student.Email); err != nil || address.Address != student.Email {
+		fail("email %q is not valid", values[FieldEmail])
+	}
+
+	if phone := values[FieldPhone]; phone != "" {
+		student.Phone = cleanPhone(phone)
+		if !phonePattern.MatchString(student.Phone) {
+			fail("phone %q is not a Vietnamese phone number", phone)
+		}
+	}
+
+	if gender := values[FieldGender]; gender != "" {
+		var ok bool
+		if student.Gender, ok = genders[sheet.HeaderKey(gender)]; !ok {
+			fail("gender %q is not recognised", gender)
+		}
+	}
+
+	if birthday := values[FieldBirthday]; birthday != "" {
+		date, err := sheet.ParseDate(birthday)
+		switch {
+		case err != nil:
+			fail("birthday %q is not a date", birthday)
+		case date.After(time.Now().AddDate(-14, 0, 0)) || date.Before(time.Now().AddDate(-100, 0, 0)):
+			fail("birthday %s is not plausible for a student", date.Format("02/01/2006"))
+		default:
+			student.Birthday = &date
+		}
+	}
+
+	contact := models.EmergencyContact{
+		Name:         values[FieldEmergencyContactName],
+		Phone:        cleanPhone(values[FieldEmergencyContactPhone]),
+		Relationship: values[FieldEmergencyContactRelationship],
+	}
+	if contact != (models.EmergencyContact{}) {
+		if contact.Phone != "" && !phonePattern.MatchString(contact.Phone) {
+			fail("emergency contact phone %q is not a Vietnamese phone number", values[FieldEmergencyContactPhone])
+		}
+		student.EmergencyContact = &contact
+	}
+
+	return row
+}
+
+// cleanPhone drops the spaces, dots and dashes people format numbers with
+func cleanPhone(phone string) string {
+	return strings.NewReplacer(" ", "", ".", "", "-", "", "(", "", ")", "").Replace(phone)
+}
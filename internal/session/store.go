@@ -0,0 +1,293 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// legacyKeyFile is where earlier versions kept the key next to the sessions
+	legacyKeyFile = "session.key"
+	sessionSuffix = ".session"
+	keySize       = 32
+)
+
+// ErrNotFound is returned when no session is stored for a server
+var ErrNotFound = errors.New("session not found")
+
+// Session is what is kept on disk to sign a user back in after a restart
+type Session struct {
+	BaseURL      string    `json:"base_url"`
+	UserID       int       `json:"user_id"`
+	Email        string    `json:"email"`
+	RefreshToken string    `json:"refresh_token"`
+	SavedAt      time.Time `json:"saved_at"`
+}
+
+// Store keeps sessions as AES-GCM encrypted files, one per base URL and user.
+// The key is generated on first use and kept in the OS keyring, so reading the
+// session files alone is not enough to recover the refresh tokens.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+	key []byte
+}
+
+// NewStore opens (or creates) a session store in dir. The key is stored in
+// the OS keyring under service, with dir as the account name.
+func NewStore(dir, service string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create session dir: %w", err)
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve session dir: %w", err)
+	}
+
+	key, err := loadKey(service, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir, key: key}, nil
+}
+
+// Save writes the session, replacing any previous one for the same server and user
+func (s *Store) Save(sess *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess.SavedAt = time.Now()
+	plain, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	sealed, err := s.seal(plain)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(sess.BaseURL, sess.UserID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, sealed, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Load returns the most recently saved session for baseURL
+func (s *Store) Load(baseURL string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.list(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *Session
+	for _, sess := range sessions {
+		if latest == nil || sess.SavedAt.After(latest.SavedAt) {
+			latest = sess
+		}
+	}
+
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+
+	return latest, nil
+}
+
+// Delete removes the session stored for baseURL and userID
+func (s *Store) Delete(baseURL string, userID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(baseURL, userID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAll removes every session stored for baseURL
+func (s *Store) DeleteAll(baseURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.list(baseURL)
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range sessions {
+		err := os.Remove(s.path(sess.BaseURL, sess.UserID))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) list(baseURL string) ([]*Session, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session dir: %w", err)
+	}
+
+	var sessions []*Session
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), sessionSuffix) {
+			continue
+		}
+
+		sealed, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		plain, err := s.open(sealed)
+		if err != nil {
+			// Left on disk so that a restored keyring entry can still read it
+			return nil, fmt.Errorf("failed to decrypt session %s: %w", entry.Name(), err)
+		}
+
+		sess := &Session{}
+		if err := json.Unmarshal(plain, sess); err != nil || sess.BaseURL != baseURL {
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+
+	return sessions, nil
+}
+
+func (s *Store) path(baseURL string, userID int) string {
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + strconv.Itoa(userID)))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+sessionSuffix)
+}
+
+func (s *Store) seal(plain []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (s *Store) open(sealed []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("session file is truncated")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func (s *Store) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// loadKey returns the key kept in the OS keyring. A key file left by an
+// earlier version is moved into the keyring. A new key is only generated for
+// a store without sessions, since existing ones could never be read with it.
+func loadKey(service, dir string) ([]byte, error) {
+	encoded, err := keyring.Get(service, dir)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, errors.New("session key in the OS keyring is invalid")
+		}
+		return key, nil
+	}
+	if !errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("failed to read session key from the OS keyring: %w", err)
+	}
+
+	legacy := filepath.Join(dir, legacyKeyFile)
+	key, err := os.ReadFile(legacy)
+	switch {
+	case err == nil:
+		if len(key) != keySize {
+			return nil, fmt.Errorf("session key %s has %d bytes, want %d", legacy, len(key), keySize)
+		}
+	case errors.Is(err, os.ErrNotExist):
+		count, err := countSessions(dir)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("session key is missing from the OS keyring, %d saved sessions in %s cannot be read", count, dir)
+		}
+
+		key = make([]byte, keySize)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, fmt.Errorf("failed to generate session key: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to read session key: %w", err)
+	}
+
+	if err := keyring.Set(service, dir, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to store session key in the OS keyring: %w", err)
+	}
+
+	if err := os.Remove(legacy); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove session key file: %w", err)
+	}
+
+	return key, nil
+}
+
+func countSessions(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read session dir: %w", err)
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), sessionSuffix) {
+			count++
+		}
+	}
+	return count, nil
+}
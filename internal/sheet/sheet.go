@@ -0,0 +1,245 @@
+// Package sheet reads and writes the spreadsheets staff exchange with the
+// university office and banks: .xlsx workbooks and CSV files.
+package sheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrNoHeader is returned when no row looks like the expected header
+var ErrNoHeader = errors.New("could not find the header row")
+
+// headerRows is how far down a file a header is looked for; exports often
+// start with a title and several rows of details
+const headerRows = 30
+
+// utf8BOM makes Excel open CSV files as UTF-8 instead of the ANSI code page
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// csvDelimiters are the separators CSV files are sniffed for. Excel saves
+// CSV with semicolons in locales that use a decimal comma.
+var csvDelimiters = []rune{',', ';', '\t'}
+
+// Format returns "xlsx" or "csv" for a file name, or an error for anything else
+func Format(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv", nil
+	case ".xlsx", ".xlsm":
+		return "xlsx", nil
+	}
+	return "", fmt.Errorf("unsupported file format %q, expected .csv or .xlsx", filepath.Ext(filename))
+}
+
+// Read returns the rows of a CSV file or of the first sheet of a workbook
+func Read(filename string, data []byte) ([][]string, error) {
+	format, err := Format(filename)
+	if err != nil {
+		return nil, err
+	}
+	if format == "csv" {
+		return readCSV(data)
+	}
+	return readXLSX(data)
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	var best [][]string
+	bestScore := -1
+	var lastErr error
+	for _, delimiter := range csvDelimiters {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.Comma = delimiter
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		rows, err := reader.ReadAll()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if score := consistency(rows); score > bestScore {
+			best, bestScore = rows, score
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", lastErr)
+	}
+	return best, nil
+}
+
+// consistency counts the rows sharing the most common field count above one.
+// The right delimiter splits every data row into the same number of fields.
+func consistency(rows [][]string) int {
+	counts := make(map[int]int)
+	best := 0
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		counts[len(row)]++
+		best = max(best, counts[len(row)])
+	}
+	return best
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook: %w", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+
+	rows, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheets[0], err)
+	}
+	return rows, nil
+}
+
+// Write encodes a header and rows as a CSV file or an .xlsx workbook,
+// depending on the file name
+func Write(filename string, header []string, rows [][]string) ([]byte, error) {
+	format, err := Format(filename)
+	if err != nil {
+		return nil, err
+	}
+	if format == "csv" {
+		return writeCSV(header, rows)
+	}
+	return writeXLSX(header, rows)
+}
+
+func writeCSV(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(utf8BOM)
+
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeXLSX(header []string, rows [][]string) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	name := file.GetSheetName(0)
+	writer, err := file.NewStreamWriter(name)
+	if err != nil {
+		return nil, err
+	}
+
+	bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([]interface{}, len(header))
+	for i, title := range header {
+		cells[i] = excelize.Cell{StyleID: bold, Value: title}
+	}
+	if err := writer.SetRow("A1", cells); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, value := range row {
+			cells[j] = value
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+		if err := writer.SetRow(cell, cells); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Fold strips diacritics and lowercases, so "Nội dung" becomes "noi dung"
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ' || r == 'Đ':
+			r = 'd'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// HeaderKey folds a header cell and collapses its whitespace and underscores
+func HeaderKey(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(Fold(s), "_", " ")), " ")
+}
+
+// FindHeader locates the header row among the first rows and maps each
+// column name to its index. aliases lists, per column, the header texts it
+// may appear under, already passed through HeaderKey. The header must
+// contain every required column.
+func FindHeader(rows [][]string, aliases map[string][]string, required ...string) (int, map[string]int, error) {
+	for i := 0; i < len(rows) && i < headerRows; i++ {
+		columns := make(map[string]int)
+		for j, cell := range rows[i] {
+			key := HeaderKey(cell)
+			for column, names := range aliases {
+				if _, ok := columns[column]; ok {
+					continue
+				}
+				for _, name := range names {
+					if key == name {
+						columns[column] = j
+						break
+					}
+				}
+			}
+		}
+
+		complete := true
+		for _, column := range required {
+			if _, ok := columns[column]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return i, columns, nil
+		}
+	}
+	return 0, nil, ErrNoHeader
+}
@@ -0,0 +1,80 @@
+package sheet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var dateLayouts = []string{
+	"02/01/2006",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"2/1/2006",
+	"02-01-2006",
+	"02-01-2006 15:04:05",
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	time.RFC3339,
+}
+
+// ParseDate reads dates written day first, as ISO dates, or as the serial
+// numbers Excel stores in unformatted cells
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("empty date")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 1 {
+		return excelize.ExcelDateToTime(serial, false)
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+}
+
+// ParseAmount reads amounts written as 1.500.000, 1,500,000, 1500000.00 or
+// 1.500.000,00, with or without a currency
+func ParseAmount(value string) (float64, error) {
+	value = strings.NewReplacer(" ", "", " ", "", "VND", "", "vnd", "", "đ", "", "₫", "", "+", "").Replace(value)
+	if value == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	dot, comma := strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		// Whichever separator comes last is the decimal one
+		if dot > comma {
+			value = strings.ReplaceAll(value, ",", "")
+		} else {
+			value = strings.ReplaceAll(value, ".", "")
+			value = strings.Replace(value, ",", ".", 1)
+		}
+	case dot >= 0:
+		value = thousands(value, ".")
+	case comma >= 0:
+		value = strings.Replace(thousands(value, ","), ",", ".", 1)
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// thousands drops sep when it separates groups of three digits, and keeps
+// it as the decimal separator otherwise
+func thousands(value, sep string) string {
+	parts := strings.Split(value, sep)
+	if len(parts) > 2 || len(parts[len(parts)-1]) == 3 {
+		return strings.Join(parts, "")
+	}
+	return value
+}
@@ -0,0 +1,188 @@
+package transfer
+
+import (
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// undo reverts the steps of a transfer that already succeeded
+type undo []func(ctx context.Context) error
+
+func (u *undo) push(step func(ctx context.Context) error) {
+	*u = append(*u, step)
+}
+
+// run reverts in reverse order and keeps going past failures so as much as
+// possible is restored
+func (u undo) run(ctx context.Context) error {
+	var errs []error
+	for i := len(u) - 1; i >= 0; i-- {
+		if err := u[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Execute validates and carries out a transfer as one operation: old
+// contracts are closed, new ones opened, deposits carried over, students
+// moved, the old room's days of the move month invoiced and the move
+// recorded. When a step fails every earlier step is reverted.
+func (m *Mover) Execute(ctx context.Context, req Request) (*Result, error) {
+	preview, err := m.Preview(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback undo
+	result, err := m.execute(ctx, preview, &rollback)
+	if err != nil {
+		// Reverting must not stop because the caller gave up on the transfer
+		if rbErr := rollback.run(context.WithoutCancel(ctx)); rbErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("rollback incomplete: %w", rbErr))
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *Mover) execute(ctx context.Context, preview *Preview, rollback *undo) (*Result, error) {
+	result := &Result{Preview: preview}
+
+	for _, leg := range preview.Legs {
+		old := leg.contract
+		_, err := m.api.Contract().UpdateContract(ctx, old.ID, map[string]interface{}{
+			"end_date": leg.OldEndDate.Format(time.DateOnly),
+			"status":   models.ContractStatusInactive,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to close contract %s: %w", old.Code, err)
+		}
+		rollback.push(func(ctx context.Context) error {
+			_, err := m.api.Contract().UpdateContract(ctx, old.ID, map[string]interface{}{
+				"end_date": old.EndDate.Format(time.DateOnly),
+				"status":   old.Status,
+			})
+			return err
+		})
+	}
+
+	for _, leg := range preview.Legs {
+		resp, err := m.api.Contract().CreateContract(ctx, map[string]interface{}{
+			"user_id":     leg.UserID,
+			"room_id":     leg.ToRoomID,
+			"start_date":  leg.NewStartDate.Format(time.DateOnly),
+			"end_date":    leg.NewEndDate.Format(time.DateOnly),
+			"price":       leg.NewPrice,
+			"description": description(leg, preview.Reason),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open a contract for %s: %w", leg.StudentName, err)
+		}
+		id := resp.Data.ID
+		leg.newContract = id
+		result.Contracts = append(result.Contracts, resp.Data)
+		rollback.push(func(ctx context.Context) error {
+			_, err := m.api.Contract().UpdateContractStatus(ctx, id, models.ContractStatusCancelled)
+			return err
+		})
+	}
+
+	for _, leg := range preview.Legs {
+		if !leg.hasDeposit {
+			continue
+		}
+		from, to := leg.OldContractID, leg.newContract
+		if _, err := m.api.Deposit().TransferDeposit(ctx, from, to); err != nil {
+			return nil, fmt.Errorf("failed to carry over the deposit of %s: %w", leg.OldContractCode, err)
+		}
+		rollback.push(func(ctx context.Context) error {
+			_, err := m.api.Deposit().TransferDeposit(ctx, to, from)
+			return err
+		})
+	}
+
+	// In a swap the first student leaves before the second arrives so that
+	// neither room is ever over capacity
+	first := preview.Legs[0]
+	if !preview.Swap {
+		if err := m.move(ctx, rollback, first, &first.FromRoomID, &first.ToRoomID); err != nil {
+			return nil, err
+		}
+	} else {
+		second := preview.Legs[1]
+		if err := m.move(ctx, rollback, first, &first.FromRoomID, nil); err != nil {
+			return nil, err
+		}
+		if err := m.move(ctx, rollback, second, &second.FromRoomID, &second.ToRoomID); err != nil {
+			return nil, err
+		}
+		if err := m.move(ctx, rollback, first, nil, &first.ToRoomID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, leg := range preview.Legs {
+		if leg.FinalInvoice == nil {
+			continue
+		}
+		resp, err := m.api.Invoice().CreateInvoice(ctx, leg.FinalInvoice.Payload())
+		if err != nil {
+			return nil, fmt.Errorf("failed to invoice the last days of %s: %w", leg.OldContractCode, err)
+		}
+		id := resp.Data.ID
+		rollback.push(func(ctx context.Context) error {
+			_, err := m.api.Invoice().VoidInvoice(ctx, id, "Hoàn tác chuyển phòng")
+			return err
+		})
+	}
+
+	// The records come last. In a swap the second record can still fail, so
+	// each one is removed again on rollback.
+	for _, leg := range preview.Legs {
+		resp, err := m.api.RoomTransfer().RecordRoomTransfer(ctx, map[string]interface{}{
+			"user_id":           leg.UserID,
+			"from_room_id":      leg.FromRoomID,
+			"to_room_id":        leg.ToRoomID,
+			"from_contract_id":  leg.OldContractID,
+			"to_contract_id":    leg.newContract,
+			"swap_with_user_id": leg.swapWith,
+			"move_date":         preview.MoveDate,
+			"reason":            preview.Reason,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record the transfer of %s: %w", leg.StudentName, err)
+		}
+		id := resp.Data.ID
+		result.Transfers = append(result.Transfers, resp.Data)
+		rollback.push(func(ctx context.Context) error {
+			_, err := m.api.RoomTransfer().DeleteRoomTransfer(ctx, id)
+			return err
+		})
+	}
+
+	return result, nil
+}
+
+// move takes a leg's student from one room to another; nil stands for no room
+func (m *Mover) move(ctx context.Context, rollback *undo, leg *Leg, from, to *int) error {
+	if _, err := m.api.User().UpdateStudentRoom(ctx, leg.UserID, to); err != nil {
+		return fmt.Errorf("failed to move %s: %w", leg.StudentName, err)
+	}
+	rollback.push(func(ctx context.Context) error {
+		_, err := m.api.User().UpdateStudentRoom(ctx, leg.UserID, from)
+		return err
+	})
+	return nil
+}
+
+func description(leg *Leg, reason string) string {
+	text := fmt.Sprintf("Chuyển từ phòng %s (hợp đồng %s) ngày %s", leg.FromRoomNumber, leg.OldContractCode, leg.NewStartDate.Format("02/01/2006"))
+	if reason != "" {
+		text += ": " + reason
+	}
+	return text
+}
@@ -0,0 +1,233 @@
+package transfer
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/client"
+	"changeme/internal/models"
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// dorm is a fake server with two students in two rooms, each on an active
+// contract for 2026. The failOn-th call to the fail route answers 500.
+type dorm struct {
+	mu        sync.Mutex
+	rooms     map[int]int
+	contracts map[int]models.Contract
+	transfers map[int]models.RoomTransfer
+	calls     map[string]int
+	fail      string
+	failOn    int
+}
+
+func newDorm(t *testing.T, fail string, failOn int) (*dorm, *api.API) {
+	t.Helper()
+
+	d := &dorm{
+		rooms:     map[int]int{1: 10, 2: 11},
+		contracts: map[int]models.Contract{},
+		transfers: map[int]models.RoomTransfer{},
+		calls:     map[string]int{},
+		fail:      fail,
+		failOn:    failOn,
+	}
+	for _, id := range []int{1, 2} {
+		d.contracts[99+id] = models.Contract{
+			ID:        99 + id,
+			Code:      "C" + strconv.Itoa(99+id),
+			UserID:    id,
+			RoomID:    d.rooms[id],
+			StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+			EndDate:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local),
+			Price:     1_500_000,
+			Status:    models.ContractStatusActive,
+		}
+	}
+
+	mux := http.NewServeMux()
+	handle := func(pattern string, handler func(r *http.Request, id int) any) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+
+			d.calls[pattern]++
+			if pattern == d.fail && d.calls[pattern] == d.failOn {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if pattern == "GET /contracts/{id}/deposit" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": handler(r, id), "total": len(d.contracts)})
+		})
+	}
+	decode := func(r *http.Request) map[string]any {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		return body
+	}
+
+	handle("GET /users/{id}", func(r *http.Request, id int) any { return d.user(id) })
+	handle("GET /rooms/{id}", func(r *http.Request, id int) any {
+		room := models.Room{ID: id, RoomNumber: "A" + strconv.Itoa(id), Status: models.RoomStatusOccupied, RoomCategoryID: 1,
+			RoomCategory: models.RoomCategory{ID: 1, Name: "Phòng 2", Capacity: 2, Price: 1_500_000}}
+		for userID, roomID := range d.rooms {
+			if roomID == id {
+				room.Users = append(room.Users, d.user(userID))
+			}
+		}
+		room.UserCount = len(room.Users)
+		return room
+	})
+	handle("GET /contracts", func(r *http.Request, id int) any { return contractList(d.contracts) })
+	handle("GET /contracts/{id}/deposit", nil)
+	handle("PATCH /contracts/{id}", func(r *http.Request, id int) any {
+		c := d.contracts[id]
+		c.Status = models.ContractStatus(decode(r)["status"].(string))
+		d.contracts[id] = c
+		return c
+	})
+	handle("PUT /contracts/{id}/status", func(r *http.Request, id int) any {
+		c := d.contracts[id]
+		c.Status = models.ContractStatus(decode(r)["status"].(string))
+		d.contracts[id] = c
+		return c
+	})
+	handle("POST /contracts", func(r *http.Request, id int) any {
+		body := decode(r)
+		c := models.Contract{ID: 200 + len(d.contracts), Code: "NEW", UserID: int(body["user_id"].(float64)), RoomID: int(body["room_id"].(float64)), Status: models.ContractStatusActive}
+		d.contracts[c.ID] = c
+		return c
+	})
+	handle("PUT /users/{id}/room", func(r *http.Request, id int) any {
+		if roomID, ok := decode(r)["room_id"].(float64); ok {
+			d.rooms[id] = int(roomID)
+		} else {
+			delete(d.rooms, id)
+		}
+		return d.user(id)
+	})
+	handle("POST /room-transfers", func(r *http.Request, id int) any {
+		body := decode(r)
+		record := models.RoomTransfer{ID: d.calls["POST /room-transfers"], UserID: int(body["user_id"].(float64))}
+		d.transfers[record.ID] = record
+		return record
+	})
+	handle("DELETE /room-transfers/{id}", func(r *http.Request, id int) any {
+		delete(d.transfers, id)
+		return nil
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return d, api.NewAPI(client.New().SetBaseURL(server.URL).SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+}
+
+func (d *dorm) user(id int) models.User {
+	user := models.User{ID: id, FullName: "SV" + strconv.Itoa(id), StudentCode: "2152000" + strconv.Itoa(id), Role: models.UserRoleStudent, Gender: models.GenderMale}
+	if roomID, ok := d.rooms[id]; ok {
+		user.RoomID = &roomID
+	}
+	return user
+}
+
+func contractList(contracts map[int]models.Contract) []models.Contract {
+	list := make([]models.Contract, 0, len(contracts))
+	for _, c := range contracts {
+		list = append(list, c)
+	}
+	return list
+}
+
+func TestExecuteSwap(t *testing.T) {
+	restored := map[int]int{1: 10, 2: 11}
+
+	tests := []struct {
+		name   string
+		fail   string
+		failOn int
+		// wantRooms is where each student lives afterwards
+		wantRooms     map[int]int
+		wantActive    []int
+		wantTransfers int
+		wantErr       bool
+	}{
+		{
+			name:          "completed",
+			wantRooms:     map[int]int{1: 11, 2: 10},
+			wantActive:    []int{202, 203},
+			wantTransfers: 2,
+		},
+		{
+			name:       "second record fails",
+			fail:       "POST /room-transfers",
+			failOn:     2,
+			wantRooms:  restored,
+			wantActive: []int{100, 101},
+			wantErr:    true,
+		},
+		{
+			name:       "second student cannot move",
+			fail:       "PUT /users/{id}/room",
+			failOn:     2,
+			wantRooms:  restored,
+			wantActive: []int{100, 101},
+			wantErr:    true,
+		},
+		{
+			name:       "second contract cannot be opened",
+			fail:       "POST /contracts",
+			failOn:     2,
+			wantRooms:  restored,
+			wantActive: []int{100, 101},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, a := newDorm(t, tt.fail, tt.failOn)
+
+			_, err := NewMover(a).Execute(context.Background(), Request{
+				UserID:         1,
+				SwapWithUserID: 2,
+				MoveDate:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, want an error: %v", err, tt.wantErr)
+			}
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if !maps.Equal(d.rooms, tt.wantRooms) {
+				t.Errorf("students live in %v, want %v", d.rooms, tt.wantRooms)
+			}
+			var active []int
+			for id, c := range d.contracts {
+				if c.Status == models.ContractStatusActive {
+					active = append(active, id)
+				}
+			}
+			slices.Sort(active)
+			if !slices.Equal(active, tt.wantActive) {
+				t.Errorf("active contracts = %v, want %v", active, tt.wantActive)
+			}
+			if len(d.transfers) != tt.wantTransfers {
+				t.Errorf("%d transfer records remain, want %d", len(d.transfers), tt.wantTransfers)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package transfer
+
+import (
+	"changeme/internal/api"
+	"changeme/internal/billing"
+	"changeme/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNoRoom is returned when the student to move is not in a room
+	ErrNoRoom = errors.New("the student is not in a room")
+	// ErrNoActiveContract is returned when the student's current room has no active contract
+	ErrNoActiveContract = errors.New("the student has no active contract for their room")
+	// ErrSameRoom is returned when moving a student into the room they are in
+	ErrSameRoom = errors.New("the student is already in that room")
+	// ErrRoomFull is returned when the target room has no free bed
+	ErrRoomFull = errors.New("the target room is full")
+	// ErrRoomUnavailable is returned when the target room is under maintenance
+	ErrRoomUnavailable = errors.New("the target room is under maintenance")
+	// ErrGenderMismatch is returned when a move would mix genders in a room
+	ErrGenderMismatch = errors.New("the target room houses students of another gender")
+	// ErrNoCapacity is returned when the target room's category has no capacity set
+	ErrNoCapacity = errors.New("the target room's category has no capacity set")
+	// ErrMoveDate is returned when the move date is outside the current contract
+	ErrMoveDate = errors.New("the move date must fall after the start and within the end of the current contract")
+)
+
+// Request asks to move UserID into ToRoomID, or to swap rooms with
+// SwapWithUserID when that is set
+type Request struct {
+	UserID         int       `json:"user_id"`
+	ToRoomID       int       `json:"to_room_id"`
+	SwapWithUserID int       `json:"swap_with_user_id"`
+	MoveDate       time.Time `json:"move_date"`
+	Reason         string    `json:"reason"`
+}
+
+// Proration splits the rent of the move month between the two contracts
+type Proration struct {
+	Period    string  `json:"billing_period"`
+	OldDays   int     `json:"old_days"`
+	OldAmount float64 `json:"old_amount"`
+	NewDays   int     `json:"new_days"`
+	NewAmount float64 `json:"new_amount"`
+}
+
+// Leg is the move of one student. A swap has two.
+type Leg struct {
+	UserID          int       `json:"user_id"`
+	StudentName     string    `json:"student_name"`
+	StudentCode     string    `json:"student_code"`
+	FromRoomID      int       `json:"from_room_id"`
+	FromRoomNumber  string    `json:"from_room_number"`
+	ToRoomID        int       `json:"to_room_id"`
+	ToRoomNumber    string    `json:"to_room_number"`
+	OldContractID   int       `json:"old_contract_id"`
+	OldContractCode string    `json:"old_contract_code"`
+	OldPrice        float64   `json:"old_price"`
+	OldEndDate      time.Time `json:"old_end_date"`
+	NewPrice        float64   `json:"new_price"`
+	NewStartDate    time.Time `json:"new_start_date"`
+	NewEndDate      time.Time `json:"new_end_date"`
+	Proration       Proration `json:"proration"`
+	// FinalInvoice is the old contract's rent for the move month up to the
+	// day before the move, created when that month was not billed yet
+	FinalInvoice *billing.Draft `json:"final_invoice"`
+
+	contract    models.Contract
+	hasDeposit  bool
+	swapWith    *int
+	newContract int
+}
+
+// Preview is what a transfer will do, computed without changing anything
+type Preview struct {
+	Swap     bool     `json:"swap"`
+	MoveDate string   `json:"move_date"`
+	Reason   string   `json:"reason"`
+	Legs     []*Leg   `json:"legs"`
+	Warnings []string `json:"warnings"`
+}
+
+// Result is a completed transfer
+type Result struct {
+	*Preview
+	Contracts []models.Contract     `json:"contracts"`
+	Transfers []models.RoomTransfer `json:"transfers"`
+}
+
+// Mover runs room transfers and swaps
+type Mover struct {
+	api *api.API
+}
+
+func NewMover(api *api.API) *Mover {
+	return &Mover{api: api}
+}
+
+// Preview validates a request and works out the contracts and invoices it
+// will produce
+func (m *Mover) Preview(ctx context.Context, req Request) (*Preview, error) {
+	date := billing.Date(req.MoveDate)
+	preview := &Preview{
+		Swap:     req.SwapWithUserID != 0,
+		MoveDate: date.Format(time.DateOnly),
+		Reason:   req.Reason,
+		Warnings: []string{},
+	}
+
+	user, from, err := m.housed(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !preview.Swap {
+		to, err := m.room(ctx, req.ToRoomID)
+		if err != nil {
+			return nil, err
+		}
+		if err := admit(user, from, to, nil); err != nil {
+			return nil, err
+		}
+
+		leg, err := m.leg(ctx, user, from, to, date, preview)
+		if err != nil {
+			return nil, err
+		}
+		preview.Legs = []*Leg{leg}
+		return preview, nil
+	}
+
+	other, otherRoom, err := m.housed(ctx, req.SwapWithUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := admit(user, from, otherRoom, &other); err != nil {
+		return nil, err
+	}
+	if err := admit(other, otherRoom, from, &user); err != nil {
+		return nil, err
+	}
+
+	first, err := m.leg(ctx, user, from, otherRoom, date, preview)
+	if err != nil {
+		return nil, err
+	}
+	second, err := m.leg(ctx, other, otherRoom, from, date, preview)
+	if err != nil {
+		return nil, err
+	}
+	first.swapWith, second.swapWith = &other.ID, &user.ID
+	preview.Legs = []*Leg{first, second}
+	return preview, nil
+}
+
+// housed loads a student together with the room they live in
+func (m *Mover) housed(ctx context.Context, userID int) (models.User, models.Room, error) {
+	resp, err := m.api.User().GetUserDetails(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return models.User{}, models.Room{}, fmt.Errorf("failed to load student %d: %w", userID, err)
+	}
+	user := resp.Data
+	if user.RoomID == nil {
+		return models.User{}, models.Room{}, fmt.Errorf("%s: %w", user.FullName, ErrNoRoom)
+	}
+
+	room, err := m.room(ctx, *user.RoomID)
+	if err != nil {
+		return models.User{}, models.Room{}, err
+	}
+	return user, room, nil
+}
+
+// room loads a room with its category. The category is fetched on its own
+// when the room details do not embed it, since its capacity and price decide
+// the move.
+func (m *Mover) room(ctx context.Context, roomID int) (models.Room, error) {
+	resp, err := m.api.Room().GetRoomDetails(ctx, roomID)
+	if err != nil {
+		return models.Room{}, fmt.Errorf("failed to load room %d: %w", roomID, err)
+	}
+	room := resp.Data
+
+	if room.RoomCategory.Capacity <= 0 && room.RoomCategoryID != 0 {
+		category, err := m.api.RoomCategory().GetRoomCategoryDetails(ctx, strconv.Itoa(room.RoomCategoryID))
+		if err != nil {
+			return models.Room{}, fmt.Errorf("failed to load the category of room %s: %w", room.RoomNumber, err)
+		}
+		room.RoomCategory = category.Data
+	}
+	return room, nil
+}
+
+// admit checks that user can move from one room into another. leaving is the
+// student who frees a bed in the target room in a swap.
+func admit(user models.User, from, to models.Room, leaving *models.User) error {
+	if to.ID == from.ID {
+		return fmt.Errorf("%s: %w", user.FullName, ErrSameRoom)
+	}
+	if to.Status == models.RoomStatusMaintenance {
+		return fmt.Errorf("room %s: %w", to.RoomNumber, ErrRoomUnavailable)
+	}
+
+	occupants := slices.DeleteFunc(slices.Clone(to.Users), func(u models.User) bool {
+		return leaving != nil && u.ID == leaving.ID
+	})
+	if to.RoomCategory.Capacity <= 0 {
+		return fmt.Errorf("room %s: %w", to.RoomNumber, ErrNoCapacity)
+	}
+	if leaving == nil && max(to.UserCount, len(to.Users)) >= to.RoomCategory.Capacity {
+		return fmt.Errorf("room %s: %w", to.RoomNumber, ErrRoomFull)
+	}
+	for _, u := range occupants {
+		if u.Gender != user.Gender {
+			return fmt.Errorf("room %s: %w", to.RoomNumber, ErrGenderMismatch)
+		}
+	}
+	return nil
+}
+
+// leg plans one student's move: the old contract ends the day before the
+// move and a new one runs from the move date to the old end date at the
+// target room's category price
+func (m *Mover) leg(ctx context.Context, user models.User, from, to models.Room, date time.Time, preview *Preview) (*Leg, error) {
+	contract, err := m.activeContract(ctx, user, from)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := billing.Date(contract.StartDate), billing.Date(contract.EndDate)
+	if !date.After(start) || date.After(end) {
+		return nil, fmt.Errorf("%s %s–%s: %w", contract.Code, start.Format(time.DateOnly), end.Format(time.DateOnly), ErrMoveDate)
+	}
+
+	leg := &Leg{
+		UserID:          user.ID,
+		StudentName:     user.FullName,
+		StudentCode:     user.StudentCode,
+		FromRoomID:      from.ID,
+		FromRoomNumber:  from.RoomNumber,
+		ToRoomID:        to.ID,
+		ToRoomNumber:    to.RoomNumber,
+		OldContractID:   contract.ID,
+		OldContractCode: contract.Code,
+		OldPrice:        contract.Price,
+		OldEndDate:      date.AddDate(0, 0, -1),
+		NewPrice:        to.RoomCategory.Price,
+		NewStartDate:    date,
+		NewEndDate:      end,
+		contract:        contract,
+	}
+
+	p := billing.PeriodOf(date)
+	leg.Proration.Period = p.String()
+	leg.Proration.OldAmount, leg.Proration.OldDays = billing.Prorate(leg.OldPrice, p, start, leg.OldEndDate)
+	leg.Proration.NewAmount, leg.Proration.NewDays = billing.Prorate(leg.NewPrice, p, date, end)
+
+	if leg.Proration.OldDays > 0 {
+		draft, err := billing.NewEngine(m.api, billing.Options{IssueDate: date}).Closing(ctx, contract, leg.OldEndDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan the last invoice of %s: %w", contract.Code, err)
+		}
+		if draft == nil {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf(
+				"Tiền phòng tháng %s của %s đã được lập hóa đơn theo hợp đồng %s; phần từ ngày %s cần được điều chỉnh thủ công",
+				p, user.FullName, contract.Code, date.Format("02/01/2006")))
+		}
+		leg.FinalInvoice = draft
+	}
+
+	deposit, err := m.api.Deposit().GetContractDeposit(ctx, contract.ID)
+	switch {
+	case api.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("failed to load the deposit of %s: %w", contract.Code, err)
+	default:
+		leg.hasDeposit = deposit.Data.Status == models.DepositStatusHeld
+	}
+
+	return leg, nil
+}
+
+func (m *Mover) activeContract(ctx context.Context, user models.User, room models.Room) (models.Contract, error) {
+	contracts, err := api.FetchAll(ctx, func(ctx context.Context, page int) (*api.Response[[]models.Contract], error) {
+		return m.api.Contract().GetListContracts(ctx, page, &user.StudentCode)
+	})
+	if err != nil {
+		return models.Contract{}, fmt.Errorf("failed to load contracts of %s: %w", user.FullName, err)
+	}
+
+	for _, c := range contracts {
+		if c.UserID == user.ID && c.RoomID == room.ID && c.Status == models.ContractStatusActive {
+			return c, nil
+		}
+	}
+	return models.Contract{}, fmt.Errorf("%s, room %s: %w", user.FullName, room.RoomNumber, ErrNoActiveContract)
+}
@@ -2,9 +2,14 @@ package main
 
 import (
 	"changeme/app"
-	"changeme/internal/client"
+	"changeme/internal/api"
 	"changeme/internal/config"
+	"changeme/internal/logger"
 	"embed"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -14,16 +19,35 @@ import (
 //go:embed all:frontend/dist
 var assets embed.FS
 
+//go:embed configs/config.yaml
+var defaultConfig []byte
+
 func main() {
-	cfg, err := config.LoadConfig()
+	// Unknown flags are ignored so that arguments added by the Wails tooling do not abort startup
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configPath := flags.String("config", "", "path to a config file overriding the user config")
+	flags.Parse(os.Args[1:])
+
+	cfg, err := config.Load(config.Options{
+		Defaults: defaultConfig,
+		Path:     *configPath,
+	})
 	if err != nil {
 		panic("Failed to load configuration: " + err.Error())
 	}
 
-	client := client.New()
-	client.SetBaseURL(cfg.Client.BaseURL)
+	log, logCloser, err := logger.New(cfg.Logger)
+	if err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
+	defer logCloser.Close()
+	slog.SetDefault(log)
 
-	app := app.NewApp(client)
+	app, err := app.NewApp(cfg)
+	if err != nil {
+		panic("Failed to initialize application: " + err.Error())
+	}
 
 	// Create application with options
 	err = wails.Run(&options.App{
@@ -35,13 +59,15 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.Startup,
+		OnShutdown:       app.Shutdown,
+		ErrorFormatter:   api.FormatError,
 		Bind: []interface{}{
 			app,
 		},
 	})
 
 	if err != nil {
-		println("Error:", err.Error())
+		log.Error("application exited with error", "error", err)
 	}
 
 }